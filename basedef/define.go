@@ -13,6 +13,22 @@ var (
 	MARKET_HUOBI         = "huobi"
 	MARKET_COINCHECK     = "coincheck"
 	MARKET_SELF          = "self"
+	MARKET_COINGECKO     = "coingecko"
+)
+
+// DEFAULT_REORG_DEPTH is the blocks kept to detect a chain reorganization
+// when ReorgDepth is not set.
+const DEFAULT_REORG_DEPTH = 64
+
+// DEFAULT_WEBHOOK_SUBSCRIPTIONS is the subscriptions allowed for the same user,
+// asset and server id when MaxSubscriptions is not set.
+const DEFAULT_WEBHOOK_SUBSCRIPTIONS = 20
+
+const (
+	API_SCOPE_READ    = "read"
+	API_SCOPE_FEE     = "fee"
+	API_SCOPE_WEBHOOK = "webhook"
+	API_SCOPE_ADMIN   = "admin"
 )
 
 const (
@@ -35,6 +51,30 @@ const (
 	SERVER_STAKE       = "stake"
 )
 
+const (
+	DB_DRIVER_MYSQL    = "mysql"
+	DB_DRIVER_POSTGRES = "postgres"
+	DB_DRIVER_SQLITE   = "sqlite"
+)
+
+const (
+	FAMILY_POLY     = "poly"
+	FAMILY_EVM      = "evm"
+	FAMILY_O3       = "o3"
+	FAMILY_NEO      = "neo"
+	FAMILY_NEO3     = "neo3"
+	FAMILY_ONTOLOGY = "ontology"
+	FAMILY_SWITCHEO = "switcheo"
+	FAMILY_ZILLIQA  = "zilliqa"
+)
+
+const (
+	FINALITY_INSTANT   = "instant"   // final once in a block
+	FINALITY_DEPTH     = "depth"     // final after a number of blocks on top
+	FINALITY_FINALIZED = "finalized" // final at the finalized block of the node
+	FINALITY_L1        = "l1"        // final when the batch is included on L1
+)
+
 const (
 	MAINNET = "mainnet"
 	TESTNET = "testnet"
@@ -246,3 +246,43 @@ func GetChainName(id uint64) string {
 		return fmt.Sprintf("Unknown(%d)", id)
 	}
 }
+
+// GetChainFamily returns the family of the chains known to this build, it is
+// used when the chain config does not set a Family.
+func GetChainFamily(id uint64) string {
+	switch id {
+	case POLY_CROSSCHAIN_ID:
+		return FAMILY_POLY
+	case ETHEREUM_CROSSCHAIN_ID, BSC_CROSSCHAIN_ID, PLT_CROSSCHAIN_ID, OK_CROSSCHAIN_ID,
+		HECO_CROSSCHAIN_ID, MATIC_CROSSCHAIN_ID, ARBITRUM_CROSSCHAIN_ID, XDAI_CROSSCHAIN_ID,
+		FANTOM_CROSSCHAIN_ID, AVAX_CROSSCHAIN_ID, OPTIMISTIC_CROSSCHAIN_ID, METIS_CROSSCHAIN_ID,
+		BOBA_CROSSCHAIN_ID, RINKEBY_CROSSCHAIN_ID, OASIS_CROSSCHAIN_ID:
+		return FAMILY_EVM
+	case O3_CROSSCHAIN_ID:
+		return FAMILY_O3
+	case NEO_CROSSCHAIN_ID:
+		return FAMILY_NEO
+	case NEO3_CROSSCHAIN_ID:
+		return FAMILY_NEO3
+	case ONT_CROSSCHAIN_ID:
+		return FAMILY_ONTOLOGY
+	case SWITCHEO_CROSSCHAIN_ID:
+		return FAMILY_SWITCHEO
+	case ZILLIQA_CROSSCHAIN_ID:
+		return FAMILY_ZILLIQA
+	default:
+		return ""
+	}
+}
+
+func GetChainFinality(id uint64) string {
+	switch id {
+	case POLY_CROSSCHAIN_ID, NEO_CROSSCHAIN_ID, NEO3_CROSSCHAIN_ID, ONT_CROSSCHAIN_ID,
+		SWITCHEO_CROSSCHAIN_ID, ZILLIQA_CROSSCHAIN_ID:
+		return FINALITY_INSTANT
+	case ARBITRUM_CROSSCHAIN_ID, OPTIMISTIC_CROSSCHAIN_ID, BOBA_CROSSCHAIN_ID, METIS_CROSSCHAIN_ID:
+		return FINALITY_L1
+	default:
+		return FINALITY_DEPTH
+	}
+}
@@ -24,9 +24,9 @@ import (
 	"poly-bridge/models"
 	"strings"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"poly-bridge/database"
 )
 
 func startDeploy(cfg *conf.DeployConfig, servercfg *serverconf.Config) {
@@ -35,8 +35,7 @@ func startDeploy(cfg *conf.DeployConfig, servercfg *serverconf.Config) {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -49,6 +48,7 @@ func startDeploy(cfg *conf.DeployConfig, servercfg *serverconf.Config) {
 		&models.NFTProfile{},
 		&models.PolyTransaction{},
 		&models.PriceMarket{},
+		&models.TokenPriceHistory{},
 		&models.SrcSwap{},
 		&models.SrcTransaction{},
 		&models.SrcTransfer{},
@@ -22,9 +22,9 @@ import (
 	"poly-bridge/conf"
 	"poly-bridge/models"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"poly-bridge/database"
 )
 
 func dumpStatus(dbCfg *conf.DBConfig) {
@@ -32,8 +32,7 @@ func dumpStatus(dbCfg *conf.DBConfig) {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"poly-bridge/conf"
+	"poly-bridge/database"
+	"poly-bridge/http"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+	"github.com/urfave/cli"
+	"gorm.io/gorm"
+)
+
+const exportDateLayout = "2006-01-02"
+
+var (
+	exportAddressFlag = cli.StringSliceFlag{
+		Name:  "address",
+		Usage: "address sending or receiving the transfers, repeat it for more addresses",
+	}
+	exportAssetFlag = cli.StringSliceFlag{
+		Name:  "asset",
+		Usage: "source token hash of the transfers, repeat it for more assets, all the assets by default",
+	}
+	exportSrcChainFlag = cli.IntFlag{
+		Name:  "srcchain",
+		Usage: "source chain id, all the chains by default",
+	}
+	exportDstChainFlag = cli.IntFlag{
+		Name:  "dstchain",
+		Usage: "destination chain id, all the chains by default",
+	}
+	exportFromFlag = cli.StringFlag{
+		Name:  "from",
+		Usage: "first day of the export like 2006-01-02, in utc",
+	}
+	exportToFlag = cli.StringFlag{
+		Name:  "to",
+		Usage: "last day of the export like 2006-01-02, in utc",
+	}
+	exportFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "csv or ndjson",
+		Value: http.ExportFormatCsv,
+	}
+	exportOutputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "output file `<path>`, transactions.<format> by default",
+	}
+
+	exportCommand = cli.Command{
+		Name:   "export",
+		Usage:  "export the transactions of addresses to a csv or ndjson file",
+		Action: export,
+		Flags: []cli.Flag{
+			exportAddressFlag,
+			exportAssetFlag,
+			exportSrcChainFlag,
+			exportDstChainFlag,
+			exportFromFlag,
+			exportToFlag,
+			exportFormatFlag,
+			exportOutputFlag,
+		},
+	}
+)
+
+// exportDay returns the unix time of the start of the day, 0 when it is empty.
+func exportDay(day string) (uint64, error) {
+	if day == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(exportDateLayout, day)
+	if err != nil {
+		return 0, fmt.Errorf("day %s is invalid: %v", day, err)
+	}
+	return uint64(t.Unix()), nil
+}
+
+func export(ctx *cli.Context) error {
+	req := &models.TransactionsExportReq{
+		Addresses:  ctx.StringSlice(exportAddressFlag.Name),
+		Assets:     ctx.StringSlice(exportAssetFlag.Name),
+		SrcChainId: ctx.Int(exportSrcChainFlag.Name),
+		DstChainId: ctx.Int(exportDstChainFlag.Name),
+		Format:     ctx.String(exportFormatFlag.Name),
+	}
+	var err error
+	if req.StartTime, err = exportDay(ctx.String(exportFromFlag.Name)); err != nil {
+		return err
+	}
+	if req.EndTime, err = exportDay(ctx.String(exportToFlag.Name)); err != nil {
+		return err
+	}
+	if req.EndTime > 0 {
+		// the last day is included
+		req.EndTime += uint64(24 * time.Hour / time.Second)
+	}
+	config := conf.NewConfig(ctx.GlobalString(getFlagName(configPathFlag)))
+	if config == nil {
+		return fmt.Errorf("read config failed")
+	}
+	db, err := database.Open(config.DBConfig, &gorm.Config{})
+	if err != nil {
+		return err
+	}
+
+	path := ctx.String(exportOutputFlag.Name)
+	if path == "" {
+		path = "transactions." + req.Format
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := http.ExportTransactions(db, req, file); err != nil {
+		return fmt.Errorf("export to %s: %v", path, err)
+	}
+	logs.Info("export of %v is written to %s", req.Addresses, path)
+	return nil
+}
@@ -87,7 +87,10 @@ func setupApp() *cli.App {
 		cmdFlag,
 		methodFlag,
 	}
-	app.Commands = []cli.Command{}
+	app.Commands = []cli.Command{
+		reindexCommand,
+		exportCommand,
+	}
 	app.Before = func(context *cli.Context) error {
 		runtime.GOMAXPROCS(runtime.NumCPU())
 		return nil
@@ -5,13 +5,13 @@ import (
 	"fmt"
 	"github.com/beego/beego/v2/core/logs"
 	"github.com/cosmos/cosmos-sdk/types"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"os"
 	"poly-bridge/basedef"
 	"poly-bridge/conf"
 	"poly-bridge/crosschaindao/explorerdao"
+	"poly-bridge/database"
 	"poly-bridge/models"
 	"reflect"
 	"strings"
@@ -100,8 +100,7 @@ func merge() {
 	conn := func(cfg *conf.DBConfig) *gorm.DB {
 		Logger := logger.Default
 		Logger = Logger.LogMode(logger.Info)
-		db, err := gorm.Open(mysql.Open(cfg.User+":"+cfg.Password+"@tcp("+cfg.URL+")/"+
-			cfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+		db, err := database.Open(cfg, &gorm.Config{Logger: Logger})
 		checkError(err, "Connecting to db")
 		return db
 	}
@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"github.com/beego/beego/v2/core/logs"
 	"github.com/urfave/cli"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"os"
@@ -32,6 +31,7 @@ import (
 	"poly-bridge/conf"
 	"poly-bridge/crosschaindao"
 	"poly-bridge/crosschainlisten"
+	"poly-bridge/database"
 	"poly-bridge/models"
 	"strconv"
 	"strings"
@@ -179,8 +179,7 @@ func bingfaSWTH(config *conf.Config) {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	srcHeights := make([]int, 0)
 	dstHeights := make([]int, 0)
 	err = db.Table("src_transactions").
@@ -221,8 +220,7 @@ func initcoinmarketid(config *conf.Config) {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 
 	var coinmarketsdk *coinmarketcap.CoinMarketCapSdk
 	for _, coinconfig := range config.CoinPriceListenConfig {
@@ -265,8 +263,7 @@ func migrateLockTokenStatisticTable(config *conf.Config) {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		logs.Error("Open mysql err", err)
 	}
@@ -291,8 +288,7 @@ func updateZilliqaPolyOldData(config *conf.Config) {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Warn)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(fmt.Sprintf("db err,%v", err))
 	}
@@ -0,0 +1,186 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"poly-bridge/conf"
+	"poly-bridge/crosschaindao"
+	"poly-bridge/crosschainlisten"
+
+	"github.com/beego/beego/v2/core/logs"
+	"github.com/urfave/cli"
+)
+
+var (
+	reindexChainFlag = cli.Uint64Flag{
+		Name:  "chain",
+		Usage: "chain id to re-index",
+	}
+	reindexStartFlag = cli.Uint64Flag{
+		Name:  "start",
+		Usage: "first height to re-index",
+	}
+	reindexEndFlag = cli.Uint64Flag{
+		Name:  "end",
+		Usage: "last height to re-index",
+	}
+	reindexConcurrencyFlag = cli.Uint64Flag{
+		Name:  "concurrency",
+		Usage: "number of blocks fetched at the same time",
+		Value: 8,
+	}
+	reindexProgressFlag = cli.StringFlag{
+		Name:  "progress",
+		Usage: "progress file `<path>`, reindex_<chain>_<start>_<end>.json by default",
+	}
+
+	reindexCommand = cli.Command{
+		Name:   "reindex",
+		Usage:  "re-index a height range of a chain without moving the listen height",
+		Action: reindex,
+		Flags: []cli.Flag{
+			reindexChainFlag,
+			reindexStartFlag,
+			reindexEndFlag,
+			reindexConcurrencyFlag,
+			reindexProgressFlag,
+		},
+	}
+)
+
+// reindexProgress is saved after every committed block so an interrupted
+// re-index resumes from Height+1.
+type reindexProgress struct {
+	ChainId uint64
+	Start   uint64
+	End     uint64
+	Height  uint64
+}
+
+func loadReindexProgress(path string, progress *reindexProgress) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	saved := new(reindexProgress)
+	if err := json.Unmarshal(data, saved); err != nil {
+		return err
+	}
+	if saved.ChainId != progress.ChainId || saved.Start != progress.Start || saved.End != progress.End {
+		return fmt.Errorf("progress file %s belongs to chain %d range %d-%d", path, saved.ChainId, saved.Start, saved.End)
+	}
+	progress.Height = saved.Height
+	return nil
+}
+
+func saveReindexProgress(path string, progress *reindexProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func reindex(ctx *cli.Context) error {
+	chainId := ctx.Uint64(reindexChainFlag.Name)
+	start := ctx.Uint64(reindexStartFlag.Name)
+	end := ctx.Uint64(reindexEndFlag.Name)
+	if start == 0 || end < start {
+		return fmt.Errorf("invalid range %d-%d", start, end)
+	}
+	config := conf.NewConfig(ctx.GlobalString(getFlagName(configPathFlag)))
+	if config == nil {
+		return fmt.Errorf("read config failed")
+	}
+	listenConfig := config.GetChainListenConfig(chainId)
+	if listenConfig == nil {
+		return fmt.Errorf("chain %d is not configured", chainId)
+	}
+	handle := crosschainlisten.NewChainHandle(listenConfig)
+	if handle == nil {
+		return fmt.Errorf("chain %d handler is invalid", chainId)
+	}
+	dao := crosschaindao.NewCrossChainDao(config.Server, false, config.DBConfig)
+	if dao == nil {
+		return fmt.Errorf("server %s is not valid", config.Server)
+	}
+
+	path := ctx.String(reindexProgressFlag.Name)
+	if path == "" {
+		path = fmt.Sprintf("reindex_%d_%d_%d.json", chainId, start, end)
+	}
+	progress := &reindexProgress{ChainId: chainId, Start: start, End: end, Height: start - 1}
+	if err := loadReindexProgress(path, progress); err != nil {
+		return err
+	}
+	if progress.Height >= end {
+		logs.Info("reindex chain %d range %d-%d is already done", chainId, start, end)
+		return nil
+	}
+	logs.Info("reindex chain %d range %d-%d from height %d", chainId, start, end, progress.Height+1)
+
+	handleBlock := func(height uint64) error {
+		wrapperTransactions, srcTransactions, polyTransactions, dstTransactions, _, _, err := handle.HandleNewBlock(height)
+		if err != nil {
+			return err
+		}
+		logs.Info("reindex chain %d height %d wrapper %d src %d poly %d dst %d", chainId, height,
+			len(wrapperTransactions), len(srcTransactions), len(polyTransactions), len(dstTransactions))
+		return dao.UpdateEvents(wrapperTransactions, srcTransactions, polyTransactions, dstTransactions)
+	}
+	commit := func(height uint64) {
+		progress.Height = height
+		if err := saveReindexProgress(path, progress); err != nil {
+			logs.Error("save reindex progress at height %d err: %v", height, err)
+		}
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sc)
+	go func() {
+		select {
+		case sig := <-sc:
+			logs.Info("reindex received signal:(%s), waiting for blocks in flight.", sig.String())
+			cancel()
+		case <-runCtx.Done():
+		}
+	}()
+	err := crosschainlisten.HandleBlocks(runCtx, handle.GetChainName(), progress.Height, end, ctx.Uint64(reindexConcurrencyFlag.Name), handleBlock, commit)
+	if err != nil {
+		return fmt.Errorf("reindex chain %d stopped at height %d: %v", chainId, progress.Height, err)
+	}
+	logs.Info("reindex chain %d range %d-%d done", chainId, start, end)
+	return nil
+}
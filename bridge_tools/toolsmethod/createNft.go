@@ -9,7 +9,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"io/ioutil"
@@ -17,6 +16,7 @@ import (
 	"os"
 	"poly-bridge/basedef"
 	"poly-bridge/conf"
+	"poly-bridge/database"
 	"poly-bridge/models"
 	"strconv"
 	"strings"
@@ -47,8 +47,7 @@ func Nft(cfg *conf.Config) {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	x, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	x, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(fmt.Sprintf("database err", err))
 	}
@@ -18,12 +18,12 @@
 package main
 
 import (
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"poly-bridge/bridge_tools/conf"
 	serverconf "poly-bridge/conf"
 	"poly-bridge/crosschaindao"
+	"poly-bridge/database"
 	"poly-bridge/models"
 	"strings"
 )
@@ -34,8 +34,7 @@ func startUpdateToken(cfg *conf.DeployConfig, servercfg *serverconf.Config) {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,461 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: bridge.proto
+
+package bridgepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BridgeClient is the client API for Bridge service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BridgeClient interface {
+	Token(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*Token, error)
+	Tokens(ctx context.Context, in *TokensRequest, opts ...grpc.CallOption) (*TokensResponse, error)
+	TokenMap(ctx context.Context, in *TokenMapRequest, opts ...grpc.CallOption) (*TokenMapsResponse, error)
+	TokenMapReverse(ctx context.Context, in *TokenMapRequest, opts ...grpc.CallOption) (*TokenMapsResponse, error)
+	GetFee(ctx context.Context, in *GetFeeRequest, opts ...grpc.CallOption) (*GetFeeResponse, error)
+	CheckFee(ctx context.Context, in *CheckFeeRequest, opts ...grpc.CallOption) (*CheckFeeResponse, error)
+	TransactionOfHash(ctx context.Context, in *TransactionOfHashRequest, opts ...grpc.CallOption) (*Transaction, error)
+	TransactionsOfHashes(ctx context.Context, in *TransactionsOfHashesRequest, opts ...grpc.CallOption) (*TransactionsOfHashesResponse, error)
+	TransactionsOfAddress(ctx context.Context, in *TransactionsOfAddressRequest, opts ...grpc.CallOption) (*TransactionsOfAddressResponse, error)
+	// WatchStatus streams the status transitions of the transactions selected
+	// by hash, poly hash or user until the client cancels.
+	WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (Bridge_WatchStatusClient, error)
+}
+
+type bridgeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBridgeClient(cc grpc.ClientConnInterface) BridgeClient {
+	return &bridgeClient{cc}
+}
+
+func (c *bridgeClient) Token(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*Token, error) {
+	out := new(Token)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/Token", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) Tokens(ctx context.Context, in *TokensRequest, opts ...grpc.CallOption) (*TokensResponse, error) {
+	out := new(TokensResponse)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/Tokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) TokenMap(ctx context.Context, in *TokenMapRequest, opts ...grpc.CallOption) (*TokenMapsResponse, error) {
+	out := new(TokenMapsResponse)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/TokenMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) TokenMapReverse(ctx context.Context, in *TokenMapRequest, opts ...grpc.CallOption) (*TokenMapsResponse, error) {
+	out := new(TokenMapsResponse)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/TokenMapReverse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) GetFee(ctx context.Context, in *GetFeeRequest, opts ...grpc.CallOption) (*GetFeeResponse, error) {
+	out := new(GetFeeResponse)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/GetFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) CheckFee(ctx context.Context, in *CheckFeeRequest, opts ...grpc.CallOption) (*CheckFeeResponse, error) {
+	out := new(CheckFeeResponse)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/CheckFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) TransactionOfHash(ctx context.Context, in *TransactionOfHashRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/TransactionOfHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) TransactionsOfHashes(ctx context.Context, in *TransactionsOfHashesRequest, opts ...grpc.CallOption) (*TransactionsOfHashesResponse, error) {
+	out := new(TransactionsOfHashesResponse)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/TransactionsOfHashes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) TransactionsOfAddress(ctx context.Context, in *TransactionsOfAddressRequest, opts ...grpc.CallOption) (*TransactionsOfAddressResponse, error) {
+	out := new(TransactionsOfAddressResponse)
+	err := c.cc.Invoke(ctx, "/bridge.Bridge/TransactionsOfAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bridgeClient) WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (Bridge_WatchStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Bridge_ServiceDesc.Streams[0], "/bridge.Bridge/WatchStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bridgeWatchStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Bridge_WatchStatusClient interface {
+	Recv() (*TransactionStatusEvent, error)
+	grpc.ClientStream
+}
+
+type bridgeWatchStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *bridgeWatchStatusClient) Recv() (*TransactionStatusEvent, error) {
+	m := new(TransactionStatusEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BridgeServer is the server API for Bridge service.
+// All implementations must embed UnimplementedBridgeServer
+// for forward compatibility
+type BridgeServer interface {
+	Token(context.Context, *TokenRequest) (*Token, error)
+	Tokens(context.Context, *TokensRequest) (*TokensResponse, error)
+	TokenMap(context.Context, *TokenMapRequest) (*TokenMapsResponse, error)
+	TokenMapReverse(context.Context, *TokenMapRequest) (*TokenMapsResponse, error)
+	GetFee(context.Context, *GetFeeRequest) (*GetFeeResponse, error)
+	CheckFee(context.Context, *CheckFeeRequest) (*CheckFeeResponse, error)
+	TransactionOfHash(context.Context, *TransactionOfHashRequest) (*Transaction, error)
+	TransactionsOfHashes(context.Context, *TransactionsOfHashesRequest) (*TransactionsOfHashesResponse, error)
+	TransactionsOfAddress(context.Context, *TransactionsOfAddressRequest) (*TransactionsOfAddressResponse, error)
+	// WatchStatus streams the status transitions of the transactions selected
+	// by hash, poly hash or user until the client cancels.
+	WatchStatus(*WatchStatusRequest, Bridge_WatchStatusServer) error
+	mustEmbedUnimplementedBridgeServer()
+}
+
+// UnimplementedBridgeServer must be embedded to have forward compatible implementations.
+type UnimplementedBridgeServer struct {
+}
+
+func (UnimplementedBridgeServer) Token(context.Context, *TokenRequest) (*Token, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Token not implemented")
+}
+func (UnimplementedBridgeServer) Tokens(context.Context, *TokensRequest) (*TokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tokens not implemented")
+}
+func (UnimplementedBridgeServer) TokenMap(context.Context, *TokenMapRequest) (*TokenMapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TokenMap not implemented")
+}
+func (UnimplementedBridgeServer) TokenMapReverse(context.Context, *TokenMapRequest) (*TokenMapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TokenMapReverse not implemented")
+}
+func (UnimplementedBridgeServer) GetFee(context.Context, *GetFeeRequest) (*GetFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFee not implemented")
+}
+func (UnimplementedBridgeServer) CheckFee(context.Context, *CheckFeeRequest) (*CheckFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckFee not implemented")
+}
+func (UnimplementedBridgeServer) TransactionOfHash(context.Context, *TransactionOfHashRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransactionOfHash not implemented")
+}
+func (UnimplementedBridgeServer) TransactionsOfHashes(context.Context, *TransactionsOfHashesRequest) (*TransactionsOfHashesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransactionsOfHashes not implemented")
+}
+func (UnimplementedBridgeServer) TransactionsOfAddress(context.Context, *TransactionsOfAddressRequest) (*TransactionsOfAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransactionsOfAddress not implemented")
+}
+func (UnimplementedBridgeServer) WatchStatus(*WatchStatusRequest, Bridge_WatchStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+}
+func (UnimplementedBridgeServer) mustEmbedUnimplementedBridgeServer() {}
+
+// UnsafeBridgeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BridgeServer will
+// result in compilation errors.
+type UnsafeBridgeServer interface {
+	mustEmbedUnimplementedBridgeServer()
+}
+
+func RegisterBridgeServer(s grpc.ServiceRegistrar, srv BridgeServer) {
+	s.RegisterService(&Bridge_ServiceDesc, srv)
+}
+
+func _Bridge_Token_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).Token(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/Token",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).Token(ctx, req.(*TokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_Tokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).Tokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/Tokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).Tokens(ctx, req.(*TokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_TokenMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).TokenMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/TokenMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).TokenMap(ctx, req.(*TokenMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_TokenMapReverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).TokenMapReverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/TokenMapReverse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).TokenMapReverse(ctx, req.(*TokenMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_GetFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).GetFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/GetFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).GetFee(ctx, req.(*GetFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_CheckFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).CheckFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/CheckFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).CheckFee(ctx, req.(*CheckFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_TransactionOfHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionOfHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).TransactionOfHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/TransactionOfHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).TransactionOfHash(ctx, req.(*TransactionOfHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_TransactionsOfHashes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionsOfHashesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).TransactionsOfHashes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/TransactionsOfHashes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).TransactionsOfHashes(ctx, req.(*TransactionsOfHashesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_TransactionsOfAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionsOfAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BridgeServer).TransactionsOfAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bridge.Bridge/TransactionsOfAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BridgeServer).TransactionsOfAddress(ctx, req.(*TransactionsOfAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bridge_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BridgeServer).WatchStatus(m, &bridgeWatchStatusServer{stream})
+}
+
+type Bridge_WatchStatusServer interface {
+	Send(*TransactionStatusEvent) error
+	grpc.ServerStream
+}
+
+type bridgeWatchStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *bridgeWatchStatusServer) Send(m *TransactionStatusEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Bridge_ServiceDesc is the grpc.ServiceDesc for Bridge service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Bridge_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bridge.Bridge",
+	HandlerType: (*BridgeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Token",
+			Handler:    _Bridge_Token_Handler,
+		},
+		{
+			MethodName: "Tokens",
+			Handler:    _Bridge_Tokens_Handler,
+		},
+		{
+			MethodName: "TokenMap",
+			Handler:    _Bridge_TokenMap_Handler,
+		},
+		{
+			MethodName: "TokenMapReverse",
+			Handler:    _Bridge_TokenMapReverse_Handler,
+		},
+		{
+			MethodName: "GetFee",
+			Handler:    _Bridge_GetFee_Handler,
+		},
+		{
+			MethodName: "CheckFee",
+			Handler:    _Bridge_CheckFee_Handler,
+		},
+		{
+			MethodName: "TransactionOfHash",
+			Handler:    _Bridge_TransactionOfHash_Handler,
+		},
+		{
+			MethodName: "TransactionsOfHashes",
+			Handler:    _Bridge_TransactionsOfHashes_Handler,
+		},
+		{
+			MethodName: "TransactionsOfAddress",
+			Handler:    _Bridge_TransactionsOfAddress_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _Bridge_WatchStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bridge.proto",
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package bridgesdk
+
+import (
+	"context"
+	"poly-bridge/bridgepb"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const grpcRequestTimeout = time.Second * 10
+
+// BridgeGrpcSdk queries the grpc server of the bridge. CheckFee and GetFee
+// return the same types as BridgeSdk, the other queries are reached through
+// Client.
+type BridgeGrpcSdk struct {
+	conn   *grpc.ClientConn
+	client bridgepb.BridgeClient
+}
+
+// NewBridgeGrpcSdk connects to the server in plaintext unless options are
+// given, like grpc.WithTransportCredentials for a server with tls, or
+// grpc.WithPerRPCCredentials sending the x-api-key metadata.
+func NewBridgeGrpcSdk(target string, opts ...grpc.DialOption) (*BridgeGrpcSdk, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &BridgeGrpcSdk{
+		conn:   conn,
+		client: bridgepb.NewBridgeClient(conn),
+	}, nil
+}
+
+func (sdk *BridgeGrpcSdk) Client() bridgepb.BridgeClient {
+	return sdk.client
+}
+
+func (sdk *BridgeGrpcSdk) Close() error {
+	return sdk.conn.Close()
+}
+
+func (sdk *BridgeGrpcSdk) CheckFee(checks []*CheckFeeReq) ([]*CheckFeeRsp, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcRequestTimeout)
+	defer cancel()
+	req := &bridgepb.CheckFeeRequest{}
+	for _, check := range checks {
+		req.Checks = append(req.Checks, &bridgepb.FeeCheck{ChainId: check.ChainId, Hash: check.Hash})
+	}
+	rsp, err := sdk.client.CheckFee(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	checkFees := make([]*CheckFeeRsp, 0, len(rsp.CheckFees))
+	for _, checkFee := range rsp.CheckFees {
+		checkFees = append(checkFees, &CheckFeeRsp{
+			ChainId:     checkFee.ChainId,
+			Hash:        checkFee.Hash,
+			PayState:    int(checkFee.PayState),
+			Amount:      checkFee.Amount,
+			MinProxyFee: checkFee.MinProxyFee,
+		})
+	}
+	return checkFees, nil
+}
+
+func (sdk *BridgeGrpcSdk) GetFee(srcChainId uint64, dstChainId uint64, feeTokenHash string, swapTokenHash string) (*GetFeeRsp, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), grpcRequestTimeout)
+	defer cancel()
+	rsp, err := sdk.client.GetFee(ctx, &bridgepb.GetFeeRequest{
+		SrcChainId:    srcChainId,
+		Hash:          feeTokenHash,
+		DstChainId:    dstChainId,
+		SwapTokenHash: swapTokenHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GetFeeRsp{
+		SrcChainId:               rsp.SrcChainId,
+		Hash:                     rsp.Hash,
+		DstChainId:               rsp.DstChainId,
+		UsdtAmount:               rsp.UsdtAmount,
+		TokenAmount:              rsp.TokenAmount,
+		TokenAmountWithPrecision: rsp.TokenAmountWithPrecision,
+		SwapTokenHash:            rsp.SwapTokenHash,
+		Balance:                  rsp.Balance,
+		BalanceWithPrecision:     rsp.BalanceWithPrecision,
+		Quote:                    feeQuote(rsp.Quote),
+	}, nil
+}
+
+func feeQuote(quote *bridgepb.FeeQuote) *FeeQuote {
+	if quote == nil {
+		return nil
+	}
+	return &FeeQuote{
+		Id:         quote.Id,
+		SrcChainId: quote.SrcChainId,
+		Hash:       quote.Hash,
+		DstChainId: quote.DstChainId,
+		Amount:     quote.Amount,
+		Time:       quote.Time,
+		Expiry:     quote.Expiry,
+		Signature:  quote.Signature,
+	}
+}
+
+// WatchStatus streams the status transitions of the transactions selected by
+// hash, poly hash or user until ctx is canceled.
+func (sdk *BridgeGrpcSdk) WatchStatus(ctx context.Context, hash string, polyHash string, user string) (bridgepb.Bridge_WatchStatusClient, error) {
+	return sdk.client.WatchStatus(ctx, &bridgepb.WatchStatusRequest{Hash: hash, PolyHash: polyHash, User: user})
+}
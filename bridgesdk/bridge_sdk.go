@@ -70,6 +70,20 @@ type GetFeeRsp struct {
 	SwapTokenHash            string
 	Balance                  string
 	BalanceWithPrecision     string
+	Quote                    *FeeQuote
+}
+
+// FeeQuote is the signed quote of GetFeeRsp, paying at least Amount before
+// Expiry is accepted by CheckFee even if the fee rises.
+type FeeQuote struct {
+	Id         string
+	SrcChainId uint64
+	Hash       string
+	DstChainId uint64
+	Amount     string
+	Time       int64
+	Expiry     int64
+	Signature  string
 }
 
 type GetFeeReq struct {
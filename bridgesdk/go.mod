@@ -2,4 +2,8 @@ module github.com/polynetwork/poly-bridge/bridgesdk
 
 go 1.15
 
-require github.com/beego/beego/v2 v2.0.1
+require (
+	github.com/beego/beego/v2 v2.0.1
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
+)
@@ -10,6 +10,7 @@ import (
 	"poly-bridge/conf"
 	"poly-bridge/models"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -33,6 +34,11 @@ const (
 	_GetManualTxData                = "GetManualTxData_"
 	RelayerAccountStatusPrefix      = "RelayerAccountStatusPrefix_"
 	RelayerAccountStatusAlarmPrefix = "RelayerAccountStatusAlarmPrefix_"
+	TransactionStatusChannel        = "TransactionStatusChannel"
+	WebhookSentPrefix               = "WebhookSent_"
+	ApiRateLimitPrefix              = "ApiRateLimit_"
+	ApiUsagePrefix                  = "ApiUsage_"
+	FeeQuotePrefix                  = "FeeQuote_"
 )
 
 type RedisCache struct {
@@ -278,3 +284,126 @@ func (r *RedisCache) LRange(key string, start, stop int64) ([]string, error) {
 		return vals, nil
 	}
 }
+
+func (r *RedisCache) PublishTransactionStatus(events []*models.TransactionStatusEvent) error {
+	for _, event := range events {
+		message, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if err := r.c.Publish(TransactionStatusChannel, string(message)).Err(); err != nil {
+			logs.Error("Redis Publish[%s: %s] err: %s", TransactionStatusChannel, event.Hash, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// SubscribeTransactionStatus subscribes to the events published by every
+// listener and effect instance, the caller closes the returned PubSub.
+func (r *RedisCache) SubscribeTransactionStatus() *goredis.PubSub {
+	return r.c.Subscribe(TransactionStatusChannel)
+}
+
+// takeTokenScript refills the bucket by the elapsed time and takes one token
+// when there is any, the bucket expires once it would be full again.
+var takeTokenScript = goredis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "time")
+local tokens = tonumber(bucket[1])
+local last = tonumber(bucket[2])
+if tokens == nil or last == nil then
+	tokens = burst
+	last = now
+end
+if now > last then
+	tokens = math.min(burst, tokens + (now - last) * rate / 1000)
+end
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "time", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, math.floor(tokens)}
+`)
+
+// TakeToken takes a token from the bucket of the key, rate is the tokens
+// refilled per second and burst the size of the bucket.
+func (r *RedisCache) TakeToken(key string, rate float64, burst int64) (bool, int64, error) {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	res, err := takeTokenScript.Run(r.c, []string{ApiRateLimitPrefix + key}, rate, burst, now).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 2 {
+		return false, 0, fmt.Errorf("unexpected token bucket result %v", res)
+	}
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+	return allowed == 1, remaining, nil
+}
+
+// IncrApiUsage counts a request of the client on the route for the day and
+// returns the total of the day.
+func (r *RedisCache) IncrApiUsage(day string, client string, route string) (int64, error) {
+	key := ApiUsagePrefix + day + "_" + client
+	pipe := r.c.TxPipeline()
+	total := pipe.HIncrBy(key, "total", 1)
+	pipe.HIncrBy(key, route, 1)
+	pipe.Expire(key, time.Hour*24*8)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+	return total.Val(), nil
+}
+
+func (r *RedisCache) GetApiUsage(day string, client string) (map[string]string, error) {
+	return r.c.HGetAll(ApiUsagePrefix + day + "_" + client).Result()
+}
+
+func feeQuoteKey(srcChainId uint64, hash string, dstChainId uint64) string {
+	return fmt.Sprintf("%s%d_%s_%d", FeeQuotePrefix, srcChainId, strings.ToLower(hash), dstChainId)
+}
+
+// SaveFeeQuote adds the quote to the quotes of its route, scored by expiry.
+// The quotes expired for more than retention are dropped.
+func (r *RedisCache) SaveFeeQuote(quote *models.FeeQuote, retention time.Duration) error {
+	member, err := json.Marshal(quote)
+	if err != nil {
+		return err
+	}
+	key := feeQuoteKey(quote.SrcChainId, quote.Hash, quote.DstChainId)
+	stale := time.Now().Add(-retention).Unix()
+	pipe := r.c.TxPipeline()
+	pipe.ZAdd(key, goredis.Z{Score: float64(quote.Expiry), Member: string(member)})
+	pipe.ZRemRangeByScore(key, "-inf", "("+strconv.FormatInt(stale, 10))
+	pipe.ExpireAt(key, time.Unix(quote.Expiry, 0).Add(retention))
+	_, err = pipe.Exec()
+	return err
+}
+
+// GetFeeQuotes returns the quotes of the route expiring between from and to.
+func (r *RedisCache) GetFeeQuotes(srcChainId uint64, hash string, dstChainId uint64, from int64, to int64) ([]*models.FeeQuote, error) {
+	members, err := r.c.ZRangeByScore(feeQuoteKey(srcChainId, hash, dstChainId), goredis.ZRangeBy{
+		Min: strconv.FormatInt(from, 10),
+		Max: strconv.FormatInt(to, 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	quotes := make([]*models.FeeQuote, 0, len(members))
+	for _, member := range members {
+		quote := new(models.FeeQuote)
+		if err := json.Unmarshal([]byte(member), quote); err != nil {
+			logs.Error("invalid fee quote %s: %v", member, err)
+			continue
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes, nil
+}
@@ -0,0 +1,164 @@
+package cacheRedis
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"poly-bridge/conf"
+
+	"github.com/beego/beego/v2/core/logs"
+	goredis "github.com/go-redis/redis"
+)
+
+const (
+	ResponseCachePrefix     = "ResponseCache_"
+	ResponseCacheGeneration = "ResponseCacheGeneration"
+	responseCacheLockPrefix = "ResponseCacheLock_"
+
+	// responseLoadTimeout bounds the wait for another instance loading the same entry
+	responseLoadTimeout = time.Second * 5
+	responsePollSlot    = time.Millisecond * 50
+)
+
+// responseCall is a load in flight, the callers of the same key wait for it.
+type responseCall struct {
+	wg    sync.WaitGroup
+	value []byte
+	err   error
+}
+
+type responseFlight struct {
+	calls map[string]*responseCall
+	mutex sync.Mutex
+}
+
+var flight = &responseFlight{calls: make(map[string]*responseCall)}
+
+func (f *responseFlight) do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	f.mutex.Lock()
+	if call, ok := f.calls[key]; ok {
+		f.mutex.Unlock()
+		call.wg.Wait()
+		return call.value, call.err
+	}
+	call := new(responseCall)
+	call.wg.Add(1)
+	f.calls[key] = call
+	f.mutex.Unlock()
+
+	call.value, call.err = fn()
+	call.wg.Done()
+
+	f.mutex.Lock()
+	delete(f.calls, key)
+	f.mutex.Unlock()
+	return call.value, call.err
+}
+
+// ResponseTTL returns the ttl configured for the route, or def when the route
+// is not listed. Nothing is cached when the response cache is not configured.
+func ResponseTTL(route string, def time.Duration) time.Duration {
+	cfg := conf.GlobalConfig
+	if cfg == nil || cfg.ResponseCacheConfig == nil {
+		return 0
+	}
+	if ttl, ok := cfg.ResponseCacheConfig.TTL[route]; ok {
+		return time.Duration(ttl) * time.Second
+	}
+	return def
+}
+
+func responseCacheKey(generation string, route string, body []byte) string {
+	compact := new(bytes.Buffer)
+	if err := json.Compact(compact, body); err == nil {
+		body = compact.Bytes()
+	}
+	sum := sha1.Sum(body)
+	return fmt.Sprintf("%s%s_%s_%s", ResponseCachePrefix, generation, route, hex.EncodeToString(sum[:]))
+}
+
+func (r *RedisCache) responseGeneration() (string, error) {
+	generation, err := r.c.Get(ResponseCacheGeneration).Result()
+	if err == goredis.Nil {
+		return "0", nil
+	}
+	return generation, err
+}
+
+// ReadThrough returns the response of the route for the request body from the
+// cache, or calls load and caches what it returns for ttl. Concurrent misses
+// of the same entry call load once in the process and once across instances.
+// Errors of load are returned and not cached, redis errors only skip the cache.
+func (r *RedisCache) ReadThrough(route string, body []byte, ttl time.Duration, load func() ([]byte, error)) ([]byte, error) {
+	if ttl <= 0 {
+		return load()
+	}
+	generation, err := r.responseGeneration()
+	if err != nil {
+		logs.Error("get response cache generation err: %v", err)
+		return load()
+	}
+	key := responseCacheKey(generation, route, body)
+	if value, err := r.c.Get(key).Bytes(); err == nil {
+		return value, nil
+	}
+	return flight.do(key, func() ([]byte, error) {
+		lockKey := responseCacheLockPrefix + key
+		locked, err := r.c.SetNX(lockKey, 1, responseLoadTimeout).Result()
+		if err == nil && !locked {
+			// another instance is loading the entry
+			deadline := time.Now().Add(responseLoadTimeout)
+			for time.Now().Before(deadline) {
+				time.Sleep(responsePollSlot)
+				if value, err := r.c.Get(key).Bytes(); err == nil {
+					return value, nil
+				}
+			}
+		}
+		if locked {
+			defer r.c.Del(lockKey)
+		}
+		value, err := load()
+		if err != nil {
+			return nil, err
+		}
+		if err := r.c.Set(key, value, ttl).Err(); err != nil {
+			logs.Error("set response cache %s err: %v", key, err)
+		}
+		return value, nil
+	})
+}
+
+var (
+	invalidateOnce  sync.Once
+	invalidateRedis *RedisCache
+)
+
+// InvalidateResponses drops every cached response by moving to a new
+// generation, the entries of the old one expire by their ttl. It is called by
+// the daos after they change tokens, prices or fees, the processes that did
+// not Init the cache connect on the first call.
+func InvalidateResponses() {
+	invalidateOnce.Do(func() {
+		invalidateRedis = Redis
+		if invalidateRedis == nil && conf.GlobalConfig != nil && conf.GlobalConfig.RedisConfig != nil {
+			redis, err := GetRedisClient(conf.GlobalConfig.RedisConfig)
+			if err != nil {
+				logs.Error("response cache invalidation is disabled, err: %v", err)
+				return
+			}
+			invalidateRedis = redis
+		}
+	})
+	if invalidateRedis == nil {
+		return
+	}
+	if err := invalidateRedis.c.Incr(ResponseCacheGeneration).Err(); err != nil {
+		logs.Error("invalidate response cache err: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package cacheRedis
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"poly-bridge/conf"
+)
+
+func TestResponseCacheKey(t *testing.T) {
+	a := responseCacheKey("0", "tokens", []byte(`{"ChainId": 2}`))
+	b := responseCacheKey("0", "tokens", []byte("{\n\t\"ChainId\":2\n}"))
+	if a != b {
+		t.Errorf("keys of the same json differ: %s, %s", a, b)
+	}
+	if a == responseCacheKey("1", "tokens", []byte(`{"ChainId":2}`)) {
+		t.Errorf("keys of different generations are equal")
+	}
+	if a == responseCacheKey("0", "tokenmap", []byte(`{"ChainId":2}`)) {
+		t.Errorf("keys of different routes are equal")
+	}
+}
+
+func TestResponseFlight(t *testing.T) {
+	f := &responseFlight{calls: make(map[string]*responseCall)}
+	var loads int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := f.do("key", func() ([]byte, error) {
+				atomic.AddInt32(&loads, 1)
+				<-release
+				return []byte("value"), nil
+			})
+			if err != nil || string(value) != "value" {
+				t.Errorf("do = %s, %v", value, err)
+			}
+		}()
+	}
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	wg.Wait()
+	if loads != 1 {
+		t.Errorf("loads = %d, want 1", loads)
+	}
+}
+
+func TestResponseTTL(t *testing.T) {
+	defer func(cfg *conf.Config) { conf.GlobalConfig = cfg }(conf.GlobalConfig)
+	conf.GlobalConfig = &conf.Config{}
+	if ttl := ResponseTTL("tokens", time.Minute); ttl != 0 {
+		t.Errorf("ttl without config = %v", ttl)
+	}
+	conf.GlobalConfig.ResponseCacheConfig = &conf.ResponseCacheConfig{TTL: map[string]int64{"tokens": 30, "tokenmap": 0}}
+	if ttl := ResponseTTL("tokens", time.Minute); ttl != time.Second*30 {
+		t.Errorf("configured ttl = %v", ttl)
+	}
+	if ttl := ResponseTTL("tokenmap", time.Minute); ttl != 0 {
+		t.Errorf("disabled ttl = %v", ttl)
+	}
+	if ttl := ResponseTTL("expecttime", time.Minute); ttl != time.Minute {
+		t.Errorf("default ttl = %v", ttl)
+	}
+}
@@ -20,12 +20,13 @@ package bridgedao
 import (
 	"fmt"
 	"github.com/polynetwork/bridge-common/metrics"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"math/big"
 	"poly-bridge/basedef"
+	"poly-bridge/cacheRedis"
 	"poly-bridge/conf"
+	"poly-bridge/database"
 	"poly-bridge/models"
 	"poly-bridge/utils/decimal"
 )
@@ -43,8 +44,7 @@ func NewBridgeDao(dbCfg *conf.DBConfig) *BridgeDao {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -69,6 +69,7 @@ func (dao *BridgeDao) SaveFees(fees []*models.ChainFee) error {
 		if res.Error != nil {
 			return res.Error
 		}
+		cacheRedis.InvalidateResponses()
 	}
 	chainFees := make([]*models.ChainFee, 0)
 	dao.db.Preload("TokenBasic").Find(&chainFees)
@@ -18,10 +18,18 @@
 package ethereumfee
 
 import (
+	"fmt"
 	"math/big"
 	"poly-bridge/basedef"
 	"poly-bridge/chainsdk"
 	"poly-bridge/conf"
+	"sort"
+)
+
+const (
+	defaultHistoryBlocks    = 20
+	defaultRewardPercentile = 60
+	defaultAheadBlocks      = 3
 )
 
 type EthereumFee struct {
@@ -40,14 +48,10 @@ func NewEthereumFee(ethCfg *conf.FeeListenConfig, feeUpdateSlot int64) *Ethereum
 }
 
 func (this *EthereumFee) GetFee() (*big.Int, *big.Int, *big.Int, error) {
-	gasPrice, err := this.ethSdk.SuggestGasPrice()
+	gasPrice, err := this.getGasPrice()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	//bsc mainnet gasprice normal 5Gwei
-	if this.GetChainId() == basedef.BSC_CROSSCHAIN_ID && gasPrice.Cmp(big.NewInt(basedef.BSC_NORMAL_GASPRICE*0.84)) < 0 {
-		return nil, nil, nil, err
-	}
 	gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(basedef.FEE_PRECISION))
 	gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(this.ethCfg.GasLimit))
 	proxyFee := new(big.Int).Mul(gasPrice, new(big.Int).SetInt64(this.ethCfg.ProxyFee))
@@ -57,6 +61,75 @@ func (this *EthereumFee) GetFee() (*big.Int, *big.Int, *big.Int, error) {
 	return minFee, gasPrice, proxyFee, nil
 }
 
+// getGasPrice estimates by eth_feeHistory when Eip1559 is configured, or else
+// it is the suggested gas price of the node.
+func (this *EthereumFee) getGasPrice() (*big.Int, error) {
+	if cfg := this.ethCfg.Eip1559; cfg != nil {
+		historyBlocks, rewardPercentile, aheadBlocks := cfg.HistoryBlocks, cfg.RewardPercentile, cfg.AheadBlocks
+		if historyBlocks == 0 {
+			historyBlocks = defaultHistoryBlocks
+		}
+		if rewardPercentile <= 0 || rewardPercentile > 100 {
+			rewardPercentile = defaultRewardPercentile
+		}
+		if aheadBlocks <= 0 {
+			aheadBlocks = defaultAheadBlocks
+		}
+		history, err := this.ethSdk.FeeHistory(historyBlocks, []float64{rewardPercentile})
+		if err != nil {
+			return nil, err
+		}
+		return eip1559GasPrice(history, aheadBlocks)
+	}
+	gasPrice, err := this.ethSdk.SuggestGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	//bsc mainnet gasprice normal 5Gwei
+	if this.GetChainId() == basedef.BSC_CROSSCHAIN_ID && gasPrice.Cmp(big.NewInt(basedef.BSC_NORMAL_GASPRICE*0.84)) < 0 {
+		return nil, fmt.Errorf("gas price %s of bsc is lower than normal", gasPrice.String())
+	}
+	return gasPrice, nil
+}
+
+// eip1559GasPrice is the base fee of the next block raised by its max increase
+// of 12.5% for each of the blocks ahead, so a transaction sent in them is not
+// underpriced, plus the median of the priority fees of the blocks that have
+// transactions.
+func eip1559GasPrice(history *chainsdk.FeeHistory, aheadBlocks int) (*big.Int, error) {
+	if len(history.BaseFee) == 0 {
+		return nil, fmt.Errorf("fee history has no base fee")
+	}
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	if baseFee == nil || baseFee.Sign() <= 0 {
+		return nil, fmt.Errorf("fee history has no base fee, the chain may be before the london fork")
+	}
+	baseFee = new(big.Int).Set(baseFee)
+	for i := 0; i < aheadBlocks; i++ {
+		// rounded up like the max increase of a full block
+		baseFee.Mul(baseFee, big.NewInt(9)).Add(baseFee, big.NewInt(7)).Quo(baseFee, big.NewInt(8))
+	}
+	rewards := make([]*big.Int, 0, len(history.Reward))
+	for i, blockReward := range history.Reward {
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		if len(blockReward) > 0 && blockReward[0] != nil {
+			rewards = append(rewards, blockReward[0])
+		}
+	}
+	if len(rewards) == 0 {
+		return baseFee, nil
+	}
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+	priorityFee := rewards[len(rewards)/2]
+	if len(rewards)%2 == 0 {
+		priorityFee = new(big.Int).Add(rewards[len(rewards)/2-1], priorityFee)
+		priorityFee.Quo(priorityFee, big.NewInt(2))
+	}
+	return new(big.Int).Add(baseFee, priorityFee), nil
+}
+
 func (this *EthereumFee) GetChainId() uint64 {
 	return this.ethCfg.ChainId
 }
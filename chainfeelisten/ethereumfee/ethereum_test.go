@@ -0,0 +1,42 @@
+package ethereumfee
+
+import (
+	"math/big"
+	"poly-bridge/chainsdk"
+	"testing"
+)
+
+func bigInts(values ...int64) []*big.Int {
+	ints := make([]*big.Int, 0, len(values))
+	for _, value := range values {
+		ints = append(ints, big.NewInt(value))
+	}
+	return ints
+}
+
+func TestEip1559GasPrice(t *testing.T) {
+	rewards := [][]*big.Int{bigInts(10), bigInts(30), bigInts(20), bigInts(0)}
+	cases := []struct {
+		history     *chainsdk.FeeHistory
+		aheadBlocks int
+		gasPrice    int64
+	}{
+		// 1000 -> 1125 -> 1266 -> 1425 in the 3 blocks ahead
+		{&chainsdk.FeeHistory{BaseFee: bigInts(100, 200, 300, 400, 1000), GasUsedRatio: []float64{0.5, 0.9, 0.3, 0}, Reward: rewards}, 3, 1445},
+		{&chainsdk.FeeHistory{BaseFee: bigInts(100, 200, 300, 400, 1000), GasUsedRatio: []float64{0.5, 0.9, 0.3, 0.1}, Reward: rewards}, 3, 1440},
+		{&chainsdk.FeeHistory{BaseFee: bigInts(100, 200, 300, 400, 1000), GasUsedRatio: []float64{0.5, 0.9, 0.3, 0}, Reward: rewards}, 0, 1020},
+		{&chainsdk.FeeHistory{BaseFee: bigInts(1000)}, 3, 1425},
+	}
+	for i, c := range cases {
+		gasPrice, err := eip1559GasPrice(c.history, c.aheadBlocks)
+		if err != nil || gasPrice.Int64() != c.gasPrice {
+			t.Errorf("case %d: gas price = %v, err %v, want %d", i, gasPrice, err, c.gasPrice)
+		}
+	}
+	if _, err := eip1559GasPrice(&chainsdk.FeeHistory{}, 3); err == nil {
+		t.Errorf("fee history without base fee has no error")
+	}
+	if _, err := eip1559GasPrice(&chainsdk.FeeHistory{BaseFee: bigInts(0, 0)}, 3); err == nil {
+		t.Errorf("fee history before london fork has no error")
+	}
+}
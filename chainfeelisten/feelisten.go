@@ -18,19 +18,14 @@
 package chainfeelisten
 
 import (
+	"context"
 	"math/big"
 	"runtime/debug"
 	"strings"
+	"sync"
 	"time"
 
-	"poly-bridge/basedef"
 	"poly-bridge/chainfeedao"
-	"poly-bridge/chainfeelisten/ethereumfee"
-	"poly-bridge/chainfeelisten/neo3fee"
-	"poly-bridge/chainfeelisten/neofee"
-	"poly-bridge/chainfeelisten/ontologyfee"
-	"poly-bridge/chainfeelisten/switcheofee"
-	"poly-bridge/chainfeelisten/zilliqafee"
 	"poly-bridge/conf"
 	"poly-bridge/models"
 
@@ -40,7 +35,7 @@ import (
 var feeListen *FeeListen
 var listenFeeCfgs []*conf.FeeListenConfig
 
-func StartFeeListen(server string, feeUpdateSlot int64, feeListenCfgs []*conf.FeeListenConfig, dbCfg *conf.DBConfig) {
+func StartFeeListen(ctx context.Context, server string, feeUpdateSlot int64, feeListenCfgs []*conf.FeeListenConfig, dbCfg *conf.DBConfig) {
 	dao := chainfeedao.NewChainFeeDao(server, dbCfg)
 	if dao == nil {
 		panic("server is not valid")
@@ -55,12 +50,13 @@ func StartFeeListen(server string, feeUpdateSlot int64, feeListenCfgs []*conf.Fe
 	}
 	listenFeeCfgs = feeListenCfgs
 	feeListen = NewFeeListen(feeUpdateSlot, chainFees, dao)
-	feeListen.Start()
+	feeListen.Start(ctx)
 }
 
 func StopFeeListen() {
 	if feeListen != nil {
 		feeListen.Stop()
+		feeListen = nil
 	}
 }
 
@@ -71,40 +67,26 @@ type ChainFee interface {
 }
 
 func NewChainFee(cfg *conf.FeeListenConfig, feeUpdateSlot int64) ChainFee {
-	switch cfg.ChainId {
-	case basedef.ETHEREUM_CROSSCHAIN_ID, basedef.BSC_CROSSCHAIN_ID, basedef.HECO_CROSSCHAIN_ID, basedef.OK_CROSSCHAIN_ID,
-		basedef.MATIC_CROSSCHAIN_ID, basedef.PLT_CROSSCHAIN_ID, basedef.ARBITRUM_CROSSCHAIN_ID, basedef.XDAI_CROSSCHAIN_ID,
-		basedef.FANTOM_CROSSCHAIN_ID, basedef.AVAX_CROSSCHAIN_ID, basedef.OPTIMISTIC_CROSSCHAIN_ID, basedef.METIS_CROSSCHAIN_ID,
-		basedef.BOBA_CROSSCHAIN_ID, basedef.RINKEBY_CROSSCHAIN_ID, basedef.OASIS_CROSSCHAIN_ID:
-		return ethereumfee.NewEthereumFee(cfg, feeUpdateSlot)
-	case basedef.NEO_CROSSCHAIN_ID:
-		return neofee.NewNeoFee(cfg, feeUpdateSlot)
-	case basedef.NEO3_CROSSCHAIN_ID:
-		return neo3fee.NewNeo3Fee(cfg, feeUpdateSlot)
-	case basedef.ONT_CROSSCHAIN_ID:
-		return ontologyfee.NewOntologyFee(cfg, feeUpdateSlot)
-	case basedef.SWITCHEO_CROSSCHAIN_ID:
-		return switcheofee.NewSwitcheoFee(cfg, feeUpdateSlot)
-	case basedef.ZILLIQA_CROSSCHAIN_ID:
-		return zilliqafee.NewZilliqaFee(cfg, feeUpdateSlot)
-
-	default:
+	factory, ok := chainFeeFactories[cfg.GetFamily()]
+	if !ok {
 		return nil
 	}
+	return factory(cfg, feeUpdateSlot)
 }
 
 type FeeListen struct {
 	feeUpdateSlot int64
 	fees          map[uint64]ChainFee
 	db            chainfeedao.ChainFeeDao
-	exit          chan bool
+	ctx           context.Context
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
 }
 
 func NewFeeListen(feeUpdateSlot int64, fees []ChainFee, db chainfeedao.ChainFeeDao) *FeeListen {
 	feeListen := &FeeListen{}
 	feeListen.feeUpdateSlot = feeUpdateSlot
 	feeListen.db = db
-	feeListen.exit = make(chan bool, 0)
 	feeListen.fees = make(map[uint64]ChainFee)
 	for _, fee := range fees {
 		feeListen.fees[fee.GetChainId()] = fee
@@ -125,24 +107,34 @@ func NewFeeListen(feeUpdateSlot int64, fees []ChainFee, db chainfeedao.ChainFeeD
 	return feeListen
 }
 
-func (fl *FeeListen) Start() {
+func (fl *FeeListen) Start(ctx context.Context) {
 	logs.Info("start chain fee listen.")
+	fl.ctx, fl.cancel = context.WithCancel(ctx)
+	fl.wg.Add(1)
 	go fl.ListenFee()
 }
 
 func (fl *FeeListen) Stop() {
-	fl.exit <- true
+	if fl.cancel == nil {
+		return
+	}
+	fl.cancel()
+	fl.wg.Wait()
 	logs.Info("stop chain fee listen.")
 }
 
 func (fl *FeeListen) ListenFee() {
+	defer fl.wg.Done()
 	for {
 		exit := fl.listenFee()
 		if exit {
-			close(fl.exit)
 			break
 		}
-		time.Sleep(time.Second * 5)
+		select {
+		case <-fl.ctx.Done():
+			return
+		case <-time.After(time.Second * 5):
+		}
 	}
 }
 
@@ -156,6 +148,7 @@ func (fl *FeeListen) listenFee() (exit bool) {
 
 	logs.Debug("fee listen, chain: %s, dao: %s......", fl.GetChainFees(), fl.db.Name())
 	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
@@ -186,7 +179,7 @@ func (fl *FeeListen) listenFee() (exit bool) {
 				}
 				break
 			}
-		case <-fl.exit:
+		case <-fl.ctx.Done():
 			logs.Info("fee listen exit, chain: %s, dao: %s......", fl.GetChainFees(), fl.db.Name())
 			return true
 		}
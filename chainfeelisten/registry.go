@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package chainfeelisten
+
+import (
+	"poly-bridge/basedef"
+	"poly-bridge/chainfeelisten/ethereumfee"
+	"poly-bridge/chainfeelisten/neo3fee"
+	"poly-bridge/chainfeelisten/neofee"
+	"poly-bridge/chainfeelisten/ontologyfee"
+	"poly-bridge/chainfeelisten/switcheofee"
+	"poly-bridge/chainfeelisten/zilliqafee"
+	"poly-bridge/conf"
+)
+
+type ChainFeeFactory func(cfg *conf.FeeListenConfig, feeUpdateSlot int64) ChainFee
+
+var chainFeeFactories = make(map[string]ChainFeeFactory)
+
+// RegisterChainFee sets the factory of the fee listens of a chain family.
+func RegisterChainFee(family string, factory ChainFeeFactory) {
+	chainFeeFactories[family] = factory
+}
+
+func init() {
+	RegisterChainFee(basedef.FAMILY_EVM, func(cfg *conf.FeeListenConfig, feeUpdateSlot int64) ChainFee {
+		return ethereumfee.NewEthereumFee(cfg, feeUpdateSlot)
+	})
+	RegisterChainFee(basedef.FAMILY_NEO, func(cfg *conf.FeeListenConfig, feeUpdateSlot int64) ChainFee {
+		return neofee.NewNeoFee(cfg, feeUpdateSlot)
+	})
+	RegisterChainFee(basedef.FAMILY_NEO3, func(cfg *conf.FeeListenConfig, feeUpdateSlot int64) ChainFee {
+		return neo3fee.NewNeo3Fee(cfg, feeUpdateSlot)
+	})
+	RegisterChainFee(basedef.FAMILY_ONTOLOGY, func(cfg *conf.FeeListenConfig, feeUpdateSlot int64) ChainFee {
+		return ontologyfee.NewOntologyFee(cfg, feeUpdateSlot)
+	})
+	RegisterChainFee(basedef.FAMILY_SWITCHEO, func(cfg *conf.FeeListenConfig, feeUpdateSlot int64) ChainFee {
+		return switcheofee.NewSwitcheoFee(cfg, feeUpdateSlot)
+	})
+	RegisterChainFee(basedef.FAMILY_ZILLIQA, func(cfg *conf.FeeListenConfig, feeUpdateSlot int64) ChainFee {
+		return zilliqafee.NewZilliqaFee(cfg, feeUpdateSlot)
+	})
+}
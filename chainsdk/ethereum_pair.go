@@ -0,0 +1,188 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package chainsdk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	erc20 "poly-bridge/go_abi/mintable_erc20_abi"
+	"strings"
+
+	"github.com/beego/beego/v2/core/logs"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// uniswapV2ABI has the methods of the uniswap v2 factory and pair that are
+// read to price tokens, forks like pancakeswap and sushiswap have the same.
+const uniswapV2ABI = `[
+{"constant":true,"inputs":[{"name":"tokenA","type":"address"},{"name":"tokenB","type":"address"}],"name":"getPair","outputs":[{"name":"pair","type":"address"}],"type":"function"},
+{"constant":true,"inputs":[],"name":"token0","outputs":[{"name":"","type":"address"}],"type":"function"},
+{"constant":true,"inputs":[],"name":"token1","outputs":[{"name":"","type":"address"}],"type":"function"},
+{"constant":true,"inputs":[],"name":"getReserves","outputs":[{"name":"_reserve0","type":"uint112"},{"name":"_reserve1","type":"uint112"},{"name":"_blockTimestampLast","type":"uint32"}],"type":"function"},
+{"constant":true,"inputs":[],"name":"price0CumulativeLast","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+{"constant":true,"inputs":[],"name":"price1CumulativeLast","outputs":[{"name":"","type":"uint256"}],"type":"function"}
+]`
+
+var uniswapV2 abi.ABI
+
+func init() {
+	var err error
+	if uniswapV2, err = abi.JSON(strings.NewReader(uniswapV2ABI)); err != nil {
+		panic(err)
+	}
+}
+
+// PairState is a uniswap v2 pair read at a block.
+type PairState struct {
+	Token0               common.Address
+	Token1               common.Address
+	Reserve0             *big.Int
+	Reserve1             *big.Int
+	BlockTimestampLast   uint32
+	Price0CumulativeLast *big.Int
+	Price1CumulativeLast *big.Int
+	Height               uint64
+	Timestamp            uint64 // of the block
+}
+
+// callUniswapV2 calls the method of the factory or pair at the height and
+// returns its unpacked outputs.
+func (s *EthereumSdk) callUniswapV2(contract common.Address, height uint64, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := uniswapV2.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	msg := ethereum.CallMsg{To: &contract, Data: data}
+	raw, err := s.rawClient.CallContract(context.Background(), msg, new(big.Int).SetUint64(height))
+	if err != nil {
+		return nil, err
+	}
+	return uniswapV2.Unpack(method, raw)
+}
+
+// GetPair returns the pair of the tokens created by the factory, the empty
+// address when there is none.
+func (s *EthereumSdk) GetPair(factory, tokenA, tokenB common.Address, height uint64) (common.Address, error) {
+	outputs, err := s.callUniswapV2(factory, height, "getPair", tokenA, tokenB)
+	if err != nil {
+		return EmptyAddress, err
+	}
+	return outputs[0].(common.Address), nil
+}
+
+// GetPairState reads the tokens, the reserves and the cumulative prices of
+// the pair at the height.
+func (s *EthereumSdk) GetPairState(pair common.Address, height uint64) (*PairState, error) {
+	header, err := s.GetHeaderByNumber(height)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %d is not found", height)
+	}
+	state := &PairState{Height: height, Timestamp: header.Time}
+	outputs, err := s.callUniswapV2(pair, height, "token0")
+	if err != nil {
+		return nil, err
+	}
+	state.Token0 = outputs[0].(common.Address)
+	if outputs, err = s.callUniswapV2(pair, height, "token1"); err != nil {
+		return nil, err
+	}
+	state.Token1 = outputs[0].(common.Address)
+	if outputs, err = s.callUniswapV2(pair, height, "getReserves"); err != nil {
+		return nil, err
+	}
+	state.Reserve0, state.Reserve1, state.BlockTimestampLast = outputs[0].(*big.Int), outputs[1].(*big.Int), outputs[2].(uint32)
+	if outputs, err = s.callUniswapV2(pair, height, "price0CumulativeLast"); err != nil {
+		return nil, err
+	}
+	state.Price0CumulativeLast = outputs[0].(*big.Int)
+	if outputs, err = s.callUniswapV2(pair, height, "price1CumulativeLast"); err != nil {
+		return nil, err
+	}
+	state.Price1CumulativeLast = outputs[0].(*big.Int)
+	return state, nil
+}
+
+func (s *EthereumSdk) GetERC20Decimals(asset common.Address) (uint8, error) {
+	contract, err := erc20.NewERC20Detailed(asset, s.backend())
+	if err != nil {
+		return 0, err
+	}
+	return contract.Decimals(&bind.CallOpts{})
+}
+
+// GetPair returns the pair of the tokens created by the factory at the latest
+// height, the empty address when there is none.
+func (pro *EthereumSdkPro) GetPair(factory, tokenA, tokenB common.Address) (common.Address, error) {
+	info := pro.GetLatest()
+	if info == nil {
+		return EmptyAddress, fmt.Errorf("all node is not working")
+	}
+	for info != nil {
+		pair, err := info.sdk.GetPair(factory, tokenA, tokenB, info.latestHeight)
+		if err != nil {
+			logs.Error("GetPair chain:%v, node:%v, err: %v", pro.id, info.sdk.url, err)
+			info = pro.reset(info)
+		} else {
+			return pair, nil
+		}
+	}
+	return EmptyAddress, fmt.Errorf("all node is not working")
+}
+
+// GetPairState reads the pair at the latest height of a working node, all the
+// fields are read at the same block.
+func (pro *EthereumSdkPro) GetPairState(pair common.Address) (*PairState, error) {
+	info := pro.GetLatest()
+	if info == nil {
+		return nil, fmt.Errorf("all node is not working")
+	}
+	for info != nil {
+		state, err := info.sdk.GetPairState(pair, info.latestHeight)
+		if err != nil {
+			logs.Error("GetPairState chain:%v, node:%v, err: %v", pro.id, info.sdk.url, err)
+			info = pro.reset(info)
+		} else {
+			return state, nil
+		}
+	}
+	return nil, fmt.Errorf("all node is not working")
+}
+
+func (pro *EthereumSdkPro) Erc20Decimals(token string) (uint8, error) {
+	info := pro.GetLatest()
+	if info == nil {
+		return 0, fmt.Errorf("all node is not working")
+	}
+	tokenAddress := common.HexToAddress(token)
+	for info != nil {
+		decimals, err := info.sdk.GetERC20Decimals(tokenAddress)
+		if err != nil {
+			info = pro.reset(info)
+		} else {
+			return decimals, nil
+		}
+	}
+	return 0, fmt.Errorf("all node is not working")
+}
@@ -29,6 +29,8 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	//"github.com/polynetwork/eth-contracts/go_abi/erc20_abi"
 	"math/big"
+	"net/http"
+	"strings"
 )
 
 type EthereumSdk struct {
@@ -50,6 +52,29 @@ func NewEthereumSdk(url string) (*EthereumSdk, error) {
 	}, nil
 }
 
+// NewEthereumSdkWithRateLimit limits the http requests sent to the node to
+// requestsPerSecond, 0 means no limit.
+func NewEthereumSdkWithRateLimit(url string, requestsPerSecond uint64) (*EthereumSdk, error) {
+	if requestsPerSecond == 0 || !strings.HasPrefix(url, "http") {
+		return NewEthereumSdk(url)
+	}
+	httpClient := &http.Client{
+		Transport: &rateLimitedTransport{
+			limiter: newRateLimiter(requestsPerSecond),
+			base:    http.DefaultTransport,
+		},
+	}
+	rpcClient, err := rpc.DialHTTPWithClient(url, httpClient)
+	if rpcClient == nil || err != nil {
+		return nil, fmt.Errorf("ethereum node is not working!, err: %v", err)
+	}
+	return &EthereumSdk{
+		rpcClient: rpcClient,
+		rawClient: ethclient.NewClient(rpcClient),
+		url:       url,
+	}, nil
+}
+
 func (s *EthereumSdk) GetClient() *ethclient.Client {
 	return s.rawClient
 }
@@ -90,6 +115,19 @@ func (s *EthereumSdk) GetHeaderByNumber(number uint64) (*types.Header, error) {
 	return header, err
 }
 
+// GetHeaderByTag returns the header of a block tag like finalized or safe
+func (s *EthereumSdk) GetHeaderByTag(tag string) (*types.Header, error) {
+	var header *types.Header
+	err := s.rpcClient.CallContext(context.Background(), &header, "eth_getBlockByNumber", tag, false)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("there is no %s block", tag)
+	}
+	return header, nil
+}
+
 func (s *EthereumSdk) GetBlockByNumber(number uint64) (*types.Block, error) {
 	return s.rawClient.BlockByNumber(context.Background(), new(big.Int).SetUint64(number))
 }
@@ -154,6 +192,46 @@ func (s *EthereumSdk) EstimateGas(msg ethereum.CallMsg) (uint64, error) {
 	return gasLimit, err
 }
 
+// FeeHistory is the result of eth_feeHistory. BaseFee has one more item than
+// the blocks, the base fee of the next block, Reward has the priority fees at
+// the percentiles of each block.
+type FeeHistory struct {
+	OldestBlock  uint64
+	BaseFee      []*big.Int
+	GasUsedRatio []float64
+	Reward       [][]*big.Int
+}
+
+func (s *EthereumSdk) FeeHistory(blocks uint64, percentiles []float64) (*FeeHistory, error) {
+	var result struct {
+		OldestBlock   hexutil.Uint64   `json:"oldestBlock"`
+		BaseFeePerGas []*hexutil.Big   `json:"baseFeePerGas"`
+		GasUsedRatio  []float64        `json:"gasUsedRatio"`
+		Reward        [][]*hexutil.Big `json:"reward"`
+	}
+	err := s.rpcClient.CallContext(context.Background(), &result, "eth_feeHistory", hexutil.Uint64(blocks), "latest", percentiles)
+	if err != nil {
+		return nil, err
+	}
+	history := &FeeHistory{
+		OldestBlock:  uint64(result.OldestBlock),
+		BaseFee:      make([]*big.Int, 0, len(result.BaseFeePerGas)),
+		GasUsedRatio: result.GasUsedRatio,
+		Reward:       make([][]*big.Int, 0, len(result.Reward)),
+	}
+	for _, baseFee := range result.BaseFeePerGas {
+		history.BaseFee = append(history.BaseFee, (*big.Int)(baseFee))
+	}
+	for _, blockReward := range result.Reward {
+		rewards := make([]*big.Int, 0, len(blockReward))
+		for _, reward := range blockReward {
+			rewards = append(rewards, (*big.Int)(reward))
+		}
+		history.Reward = append(history.Reward, rewards)
+	}
+	return history, nil
+}
+
 //func (ec *EthereumSdk) Erc20Info(hash string) (string, string, int64, string, error) {
 //	erc20Address := common.HexToAddress(hash)
 //	erc20Contract, err := usdt_abi.NewTetherToken(erc20Address, ec.rawClient)
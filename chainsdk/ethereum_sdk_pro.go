@@ -40,7 +40,11 @@ type EthereumInfo struct {
 }
 
 func NewEthereumInfo(url string) *EthereumInfo {
-	sdk, err := NewEthereumSdk(url)
+	return newEthereumInfo(url, 0)
+}
+
+func newEthereumInfo(url string, requestsPerSecond uint64) *EthereumInfo {
+	sdk, err := NewEthereumSdkWithRateLimit(url, requestsPerSecond)
 	if err != nil || sdk == nil {
 		panic(err)
 	}
@@ -58,9 +62,15 @@ type EthereumSdkPro struct {
 }
 
 func NewEthereumSdkPro(urls []string, slot uint64, id uint64) *EthereumSdkPro {
+	return NewEthereumSdkProWithRateLimit(urls, slot, id, 0)
+}
+
+// NewEthereumSdkProWithRateLimit limits the requests sent to each node to
+// requestsPerSecond, 0 means no limit.
+func NewEthereumSdkProWithRateLimit(urls []string, slot uint64, id uint64, requestsPerSecond uint64) *EthereumSdkPro {
 	infos := make(map[string]*EthereumInfo, len(urls))
 	for _, url := range urls {
-		infos[url] = NewEthereumInfo(url)
+		infos[url] = newEthereumInfo(url, requestsPerSecond)
 	}
 	pro := &EthereumSdkPro{infos: infos, selectionSlot: slot, id: id}
 	pro.selection()
@@ -188,6 +198,14 @@ func (pro *EthereumSdkPro) GetHeaderByNumber(number uint64) (*types.Header, erro
 	return nil, fmt.Errorf("all node is not working")
 }
 
+func (pro *EthereumSdkPro) GetHeaderByTag(tag string) (*types.Header, error) {
+	info := pro.GetLatest()
+	if info == nil {
+		return nil, fmt.Errorf("all node is not working")
+	}
+	return info.sdk.GetHeaderByTag(tag)
+}
+
 func (pro *EthereumSdkPro) GetTransactionByHash(hash common.Hash) (*types.Transaction, error) {
 	info := pro.GetLatest()
 	if info == nil {
@@ -328,6 +346,25 @@ func (pro *EthereumSdkPro) EstimateGas(msg ethereum.CallMsg) (uint64, error) {
 	return 0, fmt.Errorf("all node is not working")
 }
 
+func (pro *EthereumSdkPro) FeeHistory(blocks uint64, percentiles []float64) (*FeeHistory, error) {
+	info := pro.GetLatest()
+	if info == nil {
+		return nil, fmt.Errorf("all node is not working")
+	}
+
+	for info != nil {
+		history, err := info.sdk.FeeHistory(blocks, percentiles)
+		if err != nil {
+			logs.Error("FeeHistory chain:%v, node:%v, err: %v", pro.id, info.sdk.url, err)
+			info.latestHeight = 0
+			info = pro.GetLatest()
+		} else {
+			return history, nil
+		}
+	}
+	return nil, fmt.Errorf("all node is not working")
+}
+
 //func (pro *EthereumSdkPro) Erc20Info(hash string) (string, string, int64, string, error) {
 //	info := pro.GetLatest()
 //	if info == nil {
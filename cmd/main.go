@@ -18,12 +18,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"poly-bridge/cacheRedis"
 	"runtime"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/polynetwork/bridge-common/metrics"
 	"poly-bridge/basedef"
@@ -34,6 +37,7 @@ import (
 	"poly-bridge/crosschaineffect"
 	"poly-bridge/crosschainlisten"
 	"poly-bridge/crosschainstats"
+	"poly-bridge/webhook"
 
 	"github.com/beego/beego/v2/core/logs"
 	"github.com/beego/beego/v2/server/web"
@@ -57,25 +61,37 @@ func setupApp() *cli.App {
 	return app
 }
 
+const defaultShutdownTimeout = 30
+
+var webOnce sync.Once
+
+// StartServer runs the services until SIGINT or SIGTERM. On SIGHUP the config
+// file is read again and only the chain listens whose config changed are
+// restarted, the other services keep running.
 func StartServer(ctx *cli.Context) {
+	serviceCtx, cancel := context.WithCancel(context.Background())
+	config := startServer(serviceCtx, ctx)
 	for true {
-		startServer(ctx)
 		sig := waitSignal()
-		stopServer()
 		if sig != syscall.SIGHUP {
 			break
-		} else {
-			continue
+		}
+		if config == nil {
+			config = startServer(serviceCtx, ctx)
+		} else if newConfig := reloadServer(ctx); newConfig != nil {
+			config = newConfig
 		}
 	}
+	cancel()
+	stopServer(config)
 }
 
-func startServer(ctx *cli.Context) {
+func startServer(serviceCtx context.Context, ctx *cli.Context) *conf.Config {
 	configFile := ctx.GlobalString("config")
 	config := conf.NewConfig(configFile)
 	if config == nil {
 		logs.Error("startServer - read config failed!")
-		return
+		return nil
 	}
 	logs.SetLogger(logs.AdapterFile, fmt.Sprintf(`{"filename":"%s"}`, config.LogFile))
 
@@ -90,15 +106,18 @@ func startServer(ctx *cli.Context) {
 	basedef.ConfirmEnv(config.Env)
 	common.SetupChainsSDK(config)
 	if config.Backup {
-		crosschainlisten.StartCrossChainListen(config)
-		crosschainlisten.StartCrossChainListenPatch(config)
-		return
+		crosschainlisten.StartCrossChainListen(serviceCtx, config)
+		crosschainlisten.StartCrossChainListenPatch(serviceCtx, config)
+		return config
+	}
+	crosschainlisten.StartCrossChainListen(serviceCtx, config)
+	coinpricelisten.StartCoinPriceListen(serviceCtx, config.Server, config.CoinPriceUpdateSlot, config.CoinPriceListenConfig, config.DBConfig)
+	chainfeelisten.StartFeeListen(serviceCtx, config.Server, config.FeeUpdateSlot, config.FeeListenConfig, config.DBConfig)
+	crosschaineffect.StartCrossChainEffect(serviceCtx, config.Server, config.EventEffectConfig, config.DBConfig, config.RedisConfig)
+	crosschainstats.StartCrossChainStats(serviceCtx, config.Server, config.StatsConfig, config.DBConfig, config.IPPortConfig, config.ChainListenConfig)
+	if config.WebhookConfig != nil {
+		webhook.StartWebhook(serviceCtx, config.Server, config.WebhookConfig, config.DBConfig)
 	}
-	crosschainlisten.StartCrossChainListen(config)
-	coinpricelisten.StartCoinPriceListen(config.Server, config.CoinPriceUpdateSlot, config.CoinPriceListenConfig, config.DBConfig)
-	chainfeelisten.StartFeeListen(config.Server, config.FeeUpdateSlot, config.FeeListenConfig, config.DBConfig)
-	crosschaineffect.StartCrossChainEffect(config.Server, config.EventEffectConfig, config.DBConfig, config.RedisConfig)
-	crosschainstats.StartCrossChainStats(config.Server, config.StatsConfig, config.DBConfig, config.IPPortConfig, config.ChainListenConfig)
 
 	metricConfig := config.MetricConfig
 	if metricConfig == nil {
@@ -115,7 +134,26 @@ func startServer(ctx *cli.Context) {
 	web.BConfig.AppName = "bridge-server"
 	web.BConfig.CopyRequestBody = true
 	web.BConfig.EnableErrorsRender = false
-	go web.Run()
+	// startServer runs again on SIGHUP if the first start failed
+	webOnce.Do(func() {
+		go web.Run()
+	})
+	return config
+}
+
+// reloadServer only restarts the chain listens whose config changed and keeps
+// the running config when the new one is invalid. The chain sdks of common are
+// created once at start and are not reloaded, their nodes need a restart.
+func reloadServer(ctx *cli.Context) *conf.Config {
+	configFile := ctx.GlobalString("config")
+	config := conf.NewConfig(configFile)
+	if config == nil {
+		logs.Error("reloadServer - read config failed!")
+		return nil
+	}
+	logs.Info("reload chain listen config from %s", configFile)
+	crosschainlisten.ReloadCrossChainListen(config)
+	return config
 }
 
 func waitSignal() os.Signal {
@@ -135,12 +173,42 @@ func waitSignal() os.Signal {
 	return sig
 }
 
-func stopServer() {
-	crosschainlisten.StopCrossChainListen()
-	coinpricelisten.StopCoinPriceListen()
-	chainfeelisten.StopFeeListen()
-	crosschaineffect.StopCrossChainEffect()
-	crosschainstats.StopCrossChainStats()
+// stopServer stops all services at the same time and waits for them to
+// finish the work in flight. When they do not stop in the shutdown timeout the
+// process exits with status 1, so the supervisor sees the unclean stop.
+func stopServer(config *conf.Config) {
+	timeout := int64(defaultShutdownTimeout)
+	if config != nil && config.ShutdownTimeout > 0 {
+		timeout = config.ShutdownTimeout
+	}
+	stops := []func(){
+		crosschainlisten.StopCrossChainListen,
+		coinpricelisten.StopCoinPriceListen,
+		chainfeelisten.StopFeeListen,
+		crosschaineffect.StopCrossChainEffect,
+		crosschainstats.StopCrossChainStats,
+		webhook.StopWebhook,
+	}
+	wg := new(sync.WaitGroup)
+	for _, stop := range stops {
+		wg.Add(1)
+		go func(stop func()) {
+			defer wg.Done()
+			stop()
+		}(stop)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logs.Info("all services stopped.")
+	case <-time.After(time.Second * time.Duration(timeout)):
+		logs.Error("services did not stop in %d seconds, exit with work in flight.", timeout)
+		os.Exit(1)
+	}
 }
 
 func main() {
@@ -19,11 +19,12 @@ package swapdao
 
 import (
 	"fmt"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 	"poly-bridge/basedef"
 	"poly-bridge/conf"
+	"poly-bridge/database"
 	"poly-bridge/models"
 )
 
@@ -40,8 +41,7 @@ func NewSwapDao(dbCfg *conf.DBConfig) *SwapDao {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -55,10 +55,32 @@ func (dao *SwapDao) SavePrices(tokens []*models.TokenBasic) error {
 		if res.Error != nil {
 			return res.Error
 		}
+		if err := dao.savePriceHistory(tokens); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// savePriceHistory keeps the prices of the tokens that are available, a
+// price already kept for the same time is skipped.
+func (dao *SwapDao) savePriceHistory(tokens []*models.TokenBasic) error {
+	histories := make([]*models.TokenPriceHistory, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Ind == 1 && token.Time > 0 {
+			histories = append(histories, &models.TokenPriceHistory{
+				TokenBasicName: token.Name,
+				Price:          token.Price,
+				Time:           token.Time,
+			})
+		}
+	}
+	if len(histories) == 0 {
+		return nil
+	}
+	return dao.db.Clauses(clause.OnConflict{DoNothing: true}).Create(histories).Error
+}
+
 func (dao *SwapDao) GetTokens() ([]*models.TokenBasic, error) {
 	tokens := make([]*models.TokenBasic, 0)
 	res := dao.db.Preload("PriceMarkets").Find(&tokens)
@@ -0,0 +1,192 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package coinpricelisten
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"poly-bridge/basedef"
+	"poly-bridge/common"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beego/beego/v2/core/logs"
+)
+
+const (
+	PriceMethodMean           = "mean"
+	PriceMethodMedian         = "median"
+	PriceMethodWeightedMedian = "weighted_median"
+)
+
+// pricePolicy returns the policy of the token basic, the mean of at least one
+// market when none is configured.
+func pricePolicy(name string) *conf.PricePolicy {
+	policy := &conf.PricePolicy{Method: PriceMethodMean, MinSources: 1}
+	if conf.GlobalConfig != nil && conf.GlobalConfig.PricePolicyConfig != nil {
+		cfg := conf.GlobalConfig.PricePolicyConfig
+		if tokenPolicy, ok := cfg.Tokens[name]; ok && tokenPolicy != nil {
+			policy = tokenPolicy
+		} else if cfg.Default != nil {
+			policy = cfg.Default
+		}
+	}
+	return policy
+}
+
+// freshPrice tells whether the price of the market can be used at now. A price
+// of this update is always fresh, an older one is while it is not older than
+// the max age of its market.
+func freshPrice(policy *conf.PricePolicy, priceMarket *models.PriceMarket, now int64) bool {
+	if priceMarket.Ind == 1 {
+		return true
+	}
+	maxAge := policy.MaxAge[priceMarket.MarketName]
+	return maxAge > 0 && priceMarket.Price > 0 && priceMarket.Time > 0 && now-priceMarket.Time <= maxAge
+}
+
+// aggregatePrice combines the prices of the markets by the policy. last is the
+// last good price of the token, 0 when there is none, the update is rejected
+// with an error when it is too far from it or there are not enough markets.
+func aggregatePrice(policy *conf.PricePolicy, priceMarkets []*models.PriceMarket, last int64) (int64, error) {
+	minSources := policy.MinSources
+	if minSources < 1 {
+		minSources = 1
+	}
+	if len(priceMarkets) < minSources {
+		return 0, fmt.Errorf("%d markets have a price, %d are required", len(priceMarkets), minSources)
+	}
+	var price int64
+	switch policy.Method {
+	case "", PriceMethodMean:
+		price = meanPrice(priceMarkets)
+	case PriceMethodMedian:
+		price = medianPrice(priceMarkets)
+	case PriceMethodWeightedMedian:
+		var err error
+		if price, err = weightedMedianPrice(priceMarkets, policy.Weights); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("price method %s is not supported", policy.Method)
+	}
+	if policy.MaxDeviation > 0 && last > 0 {
+		deviation := math.Abs(float64(price-last)) / float64(last)
+		if deviation > policy.MaxDeviation {
+			return 0, fmt.Errorf("price %s deviates %.2f%% from the last price %s, at most %.2f%% is allowed",
+				formatPrice(price), deviation*100, formatPrice(last), policy.MaxDeviation*100)
+		}
+	}
+	return price, nil
+}
+
+func meanPrice(priceMarkets []*models.PriceMarket) int64 {
+	sum := new(big.Int)
+	for _, priceMarket := range priceMarkets {
+		sum.Add(sum, big.NewInt(priceMarket.Price))
+	}
+	return sum.Quo(sum, big.NewInt(int64(len(priceMarkets)))).Int64()
+}
+
+func sortedPrices(priceMarkets []*models.PriceMarket) []*models.PriceMarket {
+	sorted := make([]*models.PriceMarket, len(priceMarkets))
+	copy(sorted, priceMarkets)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Price < sorted[j].Price })
+	return sorted
+}
+
+// medianPrice returns the middle price, the mean of the two middle ones of an
+// even number of prices.
+func medianPrice(priceMarkets []*models.PriceMarket) int64 {
+	sorted := sortedPrices(priceMarkets)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle].Price
+	}
+	return meanPrice(sorted[middle-1 : middle+1])
+}
+
+// weightedMedianPrice returns the lowest price where the weights of the
+// markets up to it reach half of the total, markets with a weight not above 0
+// are ignored.
+func weightedMedianPrice(priceMarkets []*models.PriceMarket, weights map[string]float64) (int64, error) {
+	weightOf := func(priceMarket *models.PriceMarket) float64 {
+		if weight, ok := weights[priceMarket.MarketName]; ok {
+			return weight
+		}
+		return 1
+	}
+	sorted := sortedPrices(priceMarkets)
+	total := float64(0)
+	for _, priceMarket := range sorted {
+		if weight := weightOf(priceMarket); weight > 0 {
+			total += weight
+		}
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("no market has a weight")
+	}
+	cumulative := float64(0)
+	for _, priceMarket := range sorted {
+		if weight := weightOf(priceMarket); weight > 0 {
+			cumulative += weight
+			if cumulative >= total/2 {
+				return priceMarket.Price, nil
+			}
+		}
+	}
+	return sorted[len(sorted)-1].Price, nil
+}
+
+func formatPrice(price int64) string {
+	return new(big.Float).Quo(new(big.Float).SetInt64(price), new(big.Float).SetInt64(basedef.PRICE_PRECISION)).String()
+}
+
+// rejectedPrice is an update of a token price rejected by its policy.
+type rejectedPrice struct {
+	name   string
+	last   int64
+	reason error
+}
+
+// sendRejectedPricesDingAlarm reports the rejected updates of a round in one
+// card to BotConfig.PriceDingUrl, or to DingUrl when it is not set.
+func sendRejectedPricesDingAlarm(rejections []*rejectedPrice) error {
+	if len(rejections) == 0 || conf.GlobalConfig == nil || conf.GlobalConfig.BotConfig == nil {
+		return nil
+	}
+	dingUrl := conf.GlobalConfig.BotConfig.PriceDingUrl
+	if dingUrl == "" {
+		dingUrl = conf.GlobalConfig.BotConfig.DingUrl
+	}
+	if dingUrl == "" {
+		return nil
+	}
+	title := fmt.Sprintf("%d token price updates rejected", len(rejections))
+	lines := make([]string, 0, len(rejections))
+	for _, rejection := range rejections {
+		lines = append(lines, fmt.Sprintf("- %s: keep %s, %v", rejection.name, formatPrice(rejection.last), rejection.reason))
+	}
+	body := fmt.Sprintf("## %s\n%s\n- Time: %s\n", title, strings.Join(lines, "\n"), time.Now().Format("2006-01-02 15:04:05"))
+	logs.Info(body)
+	return common.PostDingCard(title, body, []map[string]string{}, dingUrl)
+}
@@ -0,0 +1,63 @@
+package coinpricelisten
+
+import (
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"testing"
+)
+
+func marketPrices(prices map[string]int64) []*models.PriceMarket {
+	priceMarkets := make([]*models.PriceMarket, 0)
+	for market, price := range prices {
+		priceMarkets = append(priceMarkets, &models.PriceMarket{MarketName: market, Price: price, Ind: 1})
+	}
+	return priceMarkets
+}
+
+func TestAggregatePrice(t *testing.T) {
+	prices := marketPrices(map[string]int64{"binance": 100, "coinmarketcap": 104, "coincheck": 400, "self": 102})
+	cases := []struct {
+		policy *conf.PricePolicy
+		last   int64
+		price  int64
+		reject bool
+	}{
+		{&conf.PricePolicy{}, 0, 176, false},
+		{&conf.PricePolicy{Method: PriceMethodMedian}, 0, 103, false},
+		{&conf.PricePolicy{Method: PriceMethodWeightedMedian}, 0, 102, false},
+		{&conf.PricePolicy{Method: PriceMethodWeightedMedian, Weights: map[string]float64{"coincheck": 5}}, 0, 400, false},
+		{&conf.PricePolicy{Method: PriceMethodWeightedMedian, Weights: map[string]float64{"binance": 0, "self": 0, "coinmarketcap": 3}}, 0, 104, false},
+		{&conf.PricePolicy{Method: PriceMethodMedian, MaxDeviation: 0.1}, 100, 103, false},
+		{&conf.PricePolicy{Method: PriceMethodMedian, MaxDeviation: 0.1}, 80, 0, true},
+		{&conf.PricePolicy{Method: PriceMethodMedian, MinSources: 5}, 0, 0, true},
+		{&conf.PricePolicy{Method: "max"}, 0, 0, true},
+	}
+	for i, c := range cases {
+		price, err := aggregatePrice(c.policy, prices, c.last)
+		if (err != nil) != c.reject || price != c.price {
+			t.Errorf("case %d: price = %d, err %v, want %d", i, price, err, c.price)
+		}
+	}
+	if _, err := aggregatePrice(&conf.PricePolicy{}, nil, 100); err == nil {
+		t.Errorf("update without markets is not rejected")
+	}
+}
+
+func TestFreshPrice(t *testing.T) {
+	policy := &conf.PricePolicy{MaxAge: map[string]int64{"binance": 600}}
+	cases := []struct {
+		priceMarket *models.PriceMarket
+		fresh       bool
+	}{
+		{&models.PriceMarket{MarketName: "coincheck", Price: 100, Ind: 1, Time: 1000}, true},
+		{&models.PriceMarket{MarketName: "coincheck", Price: 100, Time: 1000}, false},
+		{&models.PriceMarket{MarketName: "binance", Price: 100, Time: 500}, true},
+		{&models.PriceMarket{MarketName: "binance", Price: 100, Time: 499}, false},
+		{&models.PriceMarket{MarketName: "binance", Time: 1000}, false},
+	}
+	for i, c := range cases {
+		if fresh := freshPrice(policy, c.priceMarket, 1100); fresh != c.fresh {
+			t.Errorf("case %d: fresh = %v", i, fresh)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package coingecko
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beego/beego/v2/core/logs"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"poly-bridge/basedef"
+	"poly-bridge/coinpricelisten/restful"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"strings"
+	"time"
+)
+
+// batchSize is the number of coin ids of a request
+const batchSize = 100
+
+type CoinGeckoSdk struct {
+	client *http.Client
+	nodes  *restful.Nodes
+}
+
+func DefaultCoinGeckoSdk() *CoinGeckoSdk {
+	return NewCoinGeckoSdk(&conf.CoinPriceListenConfig{
+		MarketName: basedef.MARKET_COINGECKO,
+		Nodes: []*conf.Restful{
+			{
+				Url: "https://api.coingecko.com/api/v3/",
+			},
+		},
+	})
+}
+
+// NewCoinGeckoSdk returns the sdk of the nodes of the config. A node with a
+// Key is a pro api node and sends the key, the nodes are used in turns so the
+// requests are spread over the keys.
+func NewCoinGeckoSdk(cfg *conf.CoinPriceListenConfig) *CoinGeckoSdk {
+	client := &http.Client{Timeout: time.Second * 30}
+	sdk := &CoinGeckoSdk{
+		client: client,
+		nodes:  restful.NewNodes("CoinGecko", cfg.Nodes),
+	}
+	return sdk
+}
+
+// QuotesLatest returns the usd prices of the coin ids. A node answering 429
+// is skipped until it allows requests again.
+func (sdk *CoinGeckoSdk) QuotesLatest(ids []string) (quotes SimplePrice, err error) {
+	err = sdk.nodes.QuotesLatest(func(node *conf.Restful) error {
+		quotes, err = sdk.quotesLatest(node, ids)
+		return err
+	})
+	return quotes, err
+}
+
+func (sdk *CoinGeckoSdk) quotesLatest(node *conf.Restful, ids []string) (SimplePrice, error) {
+	req, err := http.NewRequest("GET", node.Url+"simple/price", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Add("ids", strings.Join(ids, ","))
+	q.Add("vs_currencies", "usd")
+
+	req.Header.Set("Accepts", "application/json")
+	if node.Key != "" {
+		req.Header.Add("x-cg-pro-api-key", node.Key)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := sdk.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := restful.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	quotes := make(SimplePrice)
+	err = json.Unmarshal(respBody, &quotes)
+	if err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+func (sdk *CoinGeckoSdk) GetMarketName() string {
+	return basedef.MARKET_COINGECKO
+}
+
+// GetCoinPrice returns the usd prices of the coins, the names of the coins are
+// CoinGecko coin ids like ethereum. The ids are requested by batches, the
+// prices of the batches that fail are left out and an error is returned only
+// when every batch fails.
+func (sdk *CoinGeckoSdk) GetCoinPrice(coins []models.NameAndmarketId) (map[string]float64, error) {
+	ids := make([]string, 0)
+	coinInId := make(map[string]bool, 0)
+	for _, coin := range coins {
+		id := strings.ToLower(coin.PriceMarketName)
+		if id == "" || coinInId[id] {
+			continue
+		}
+		coinInId[id] = true
+		ids = append(ids, id)
+	}
+	id2Price := make(map[string]float64, 0)
+	batches, failed := 0, 0
+	for start := 0; start < len(ids); start += batchSize {
+		batches++
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		quotes, err := sdk.QuotesLatest(ids[start:end])
+		if err != nil {
+			failed++
+			continue
+		}
+		for id, quote := range quotes {
+			if price, ok := quote["usd"]; ok {
+				id2Price[id] = price
+			}
+		}
+	}
+	if batches > 0 && failed == batches {
+		return nil, fmt.Errorf("Cannot get CoinGecko prices of %d coins!", len(ids))
+	}
+	coinPrice := make(map[string]float64, 0)
+	for _, coin := range coins {
+		price, ok := id2Price[strings.ToLower(coin.PriceMarketName)]
+		if !ok || price <= 0 {
+			logs.Warn("There is no coin price %s in CoinGecko!", coin.PriceMarketName)
+			continue
+		}
+		coinPrice[coin.PriceMarketName] = price
+	}
+	return coinPrice, nil
+}
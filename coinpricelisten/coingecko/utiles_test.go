@@ -0,0 +1,84 @@
+package coingecko
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"strings"
+	"testing"
+)
+
+// fixtureServer serves the recorded responses in testdata, the batch with
+// bitcoin gets simple_price_1.json and the others simple_price_2.json. The
+// requests with the key "limited" are answered with 429. It records the keys
+// of the requests.
+func fixtureServer(t *testing.T, keys *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("x-cg-pro-api-key")
+		*keys = append(*keys, key)
+		if key == "limited" {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		if r.URL.Path != "/simple/price" || r.URL.Query().Get("vs_currencies") != "usd" || len(ids) > batchSize {
+			t.Errorf("request = %s", r.URL)
+		}
+		fixture := "testdata/simple_price_2.json"
+		if strings.Contains(r.URL.Query().Get("ids"), "bitcoin") {
+			fixture = "testdata/simple_price_1.json"
+		}
+		data, err := ioutil.ReadFile(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}))
+}
+
+func TestGetCoinPrice(t *testing.T) {
+	keys := make([]string, 0)
+	server := fixtureServer(t, &keys)
+	defer server.Close()
+	sdk := NewCoinGeckoSdk(&conf.CoinPriceListenConfig{Nodes: []*conf.Restful{
+		{Url: server.URL + "/", Key: "limited"},
+		{Url: server.URL + "/", Key: "key-a"},
+		{Url: server.URL + "/", Key: "key-b"},
+	}})
+
+	coins := []models.NameAndmarketId{{PriceMarketName: "bitcoin"}, {PriceMarketName: "Ethereum"}, {PriceMarketName: "tether"}}
+	for i := 0; i < batchSize; i++ {
+		coins = append(coins, models.NameAndmarketId{PriceMarketName: fmt.Sprintf("coin-%d", i)})
+	}
+	coins = append(coins, models.NameAndmarketId{PriceMarketName: "huobi-token"}, models.NameAndmarketId{PriceMarketName: "dead-coin"})
+	prices, err := sdk.GetCoinPrice(coins)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 4 || prices["bitcoin"] != 37301.52 || prices["Ethereum"] != 2633.41 || prices["tether"] != 1.001 || prices["huobi-token"] != 15.02 {
+		t.Errorf("prices = %v", prices)
+	}
+	if got := strings.Join(keys, ","); got != "limited,key-a,key-b" {
+		t.Errorf("keys of the requests = %s", got)
+	}
+}
+
+func TestGetCoinPriceLimited(t *testing.T) {
+	keys := make([]string, 0)
+	server := fixtureServer(t, &keys)
+	defer server.Close()
+	sdk := NewCoinGeckoSdk(&conf.CoinPriceListenConfig{Nodes: []*conf.Restful{{Url: server.URL + "/", Key: "limited"}}})
+	coins := []models.NameAndmarketId{{PriceMarketName: "bitcoin"}}
+	for i := 0; i < 2; i++ {
+		if _, err := sdk.GetCoinPrice(coins); err == nil {
+			t.Errorf("prices of a rate limited node are returned")
+		}
+	}
+	if len(keys) != 1 {
+		t.Errorf("rate limited node is requested %d times", len(keys))
+	}
+}
@@ -18,24 +18,29 @@
 package coinpricelisten
 
 import (
+	"context"
 	"github.com/beego/beego/v2/core/logs"
 	"math/big"
 	"poly-bridge/basedef"
 	"poly-bridge/coinpricedao"
 	"poly-bridge/coinpricelisten/binance"
 	"poly-bridge/coinpricelisten/coincheck"
+	"poly-bridge/coinpricelisten/coingecko"
 	"poly-bridge/coinpricelisten/coinmarketcap"
+	"poly-bridge/coinpricelisten/dex"
+	"poly-bridge/coinpricelisten/huobi"
 	"poly-bridge/coinpricelisten/self"
 	"poly-bridge/conf"
 	"poly-bridge/models"
 	"runtime/debug"
 	"strings"
+	"sync"
 	"time"
 )
 
 var cpListen *CoinPriceListen
 
-func StartCoinPriceListen(server string, priceUpdateSlot int64, coinPricecfg []*conf.CoinPriceListenConfig, dbCfg *conf.DBConfig) {
+func StartCoinPriceListen(ctx context.Context, server string, priceUpdateSlot int64, coinPricecfg []*conf.CoinPriceListenConfig, dbCfg *conf.DBConfig) {
 	dao := coinpricedao.NewCoinPriceDao(server, dbCfg)
 	if dao == nil {
 		panic("server is not valid")
@@ -49,12 +54,13 @@ func StartCoinPriceListen(server string, priceUpdateSlot int64, coinPricecfg []*
 		priceMarkets = append(priceMarkets, priceMarket)
 	}
 	cpListen = NewCoinPriceListen(priceUpdateSlot, priceMarkets, dao)
-	cpListen.Start()
+	cpListen.Start(ctx)
 }
 
 func StopCoinPriceListen() {
 	if cpListen != nil {
 		cpListen.Stop()
+		cpListen = nil
 	}
 }
 
@@ -64,7 +70,9 @@ type PriceMarket interface {
 }
 
 func NewPriceMarket(cfg *conf.CoinPriceListenConfig) PriceMarket {
-	if cfg.MarketName == basedef.MARKET_COINMARKETCAP {
+	if cfg.Dex != nil {
+		return dex.NewDexSdk(cfg)
+	} else if cfg.MarketName == basedef.MARKET_COINMARKETCAP {
 		return coinmarketcap.NewCoinMarketCapSdk(cfg)
 	} else if cfg.MarketName == basedef.MARKET_BINANCE {
 		return binance.NewBinanceSdk(cfg)
@@ -72,6 +80,10 @@ func NewPriceMarket(cfg *conf.CoinPriceListenConfig) PriceMarket {
 		return coincheck.NewCoincheckSdk(cfg)
 	} else if cfg.MarketName == basedef.MARKET_SELF {
 		return self.NewSelfSdk(cfg)
+	} else if cfg.MarketName == basedef.MARKET_HUOBI {
+		return huobi.NewHuobiSdk(cfg)
+	} else if cfg.MarketName == basedef.MARKET_COINGECKO {
+		return coingecko.NewCoinGeckoSdk(cfg)
 	} else {
 		return nil
 	}
@@ -81,14 +93,15 @@ type CoinPriceListen struct {
 	priceUpdateSlot int64
 	priceMarket     map[string]PriceMarket
 	db              coinpricedao.CoinPriceDao
-	exit            chan bool
+	ctx             context.Context
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup
 }
 
 func NewCoinPriceListen(priceUpdateSlot int64, priceMarkets []PriceMarket, db coinpricedao.CoinPriceDao) *CoinPriceListen {
 	cpListen := &CoinPriceListen{}
 	cpListen.priceUpdateSlot = priceUpdateSlot
 	cpListen.db = db
-	cpListen.exit = make(chan bool, 0)
 	cpListen.priceMarket = make(map[string]PriceMarket)
 	for _, market := range priceMarkets {
 		cpListen.priceMarket[market.GetMarketName()] = market
@@ -113,24 +126,34 @@ func (cpl *CoinPriceListen) RegisterPriceQuery(priceMarket PriceMarket) {
 	cpl.priceMarket[priceMarket.GetMarketName()] = priceMarket
 }
 
-func (cpl *CoinPriceListen) Start() {
+func (cpl *CoinPriceListen) Start(ctx context.Context) {
 	logs.Info("start coin price listen.")
+	cpl.ctx, cpl.cancel = context.WithCancel(ctx)
+	cpl.wg.Add(1)
 	go cpl.ListenPrice()
 }
 
 func (cpl *CoinPriceListen) Stop() {
-	cpl.exit <- true
+	if cpl.cancel == nil {
+		return
+	}
+	cpl.cancel()
+	cpl.wg.Wait()
 	logs.Info("stop coin price listen.")
 }
 
 func (cpl *CoinPriceListen) ListenPrice() {
+	defer cpl.wg.Done()
 	for {
 		exit := cpl.listenPrice()
 		if exit {
-			close(cpl.exit)
 			break
 		}
-		time.Sleep(time.Second * 5)
+		select {
+		case <-cpl.ctx.Done():
+			return
+		case <-time.After(time.Second * 5):
+		}
 	}
 }
 
@@ -144,6 +167,7 @@ func (cpl *CoinPriceListen) listenPrice() (exit bool) {
 
 	logs.Debug("coin price listen, market: %s, dao: %s......", cpl.GetPriceMarket(), cpl.db.Name())
 	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
@@ -173,7 +197,7 @@ func (cpl *CoinPriceListen) listenPrice() (exit bool) {
 				}
 				break
 			}
-		case <-cpl.exit:
+		case <-cpl.ctx.Done():
 			logs.Info("coin price listen exit, market: %s, dao: %s......", cpl.GetPriceMarket(), cpl.db.Name())
 			return true
 		}
@@ -184,7 +208,10 @@ func (cpl *CoinPriceListen) updateCoinPrice(tokenBasics []*models.TokenBasic) er
 
 	marketCoins := make(map[string][]models.NameAndmarketId)
 	marketCoinPrices := make(map[string][]*models.PriceMarket)
+	// the last good prices are kept when an update is rejected
+	lastInds := make(map[string]uint64)
 	for _, tokenBasic := range tokenBasics {
+		lastInds[tokenBasic.Name] = tokenBasic.Ind
 		for _, priceMarket := range tokenBasic.PriceMarkets {
 			_, ok := marketCoins[priceMarket.MarketName]
 			if !ok {
@@ -231,24 +258,39 @@ func (cpl *CoinPriceListen) updateCoinPrice(tokenBasics []*models.TokenBasic) er
 			}
 		}
 	}
+	now := time.Now().Unix()
+	rejections := make([]*rejectedPrice, 0)
 	for _, tokenBasic := range tokenBasics {
-		price := int64(0)
-		counter := int64(0)
+		if len(tokenBasic.PriceMarkets) == 0 {
+			continue
+		}
+		policy := pricePolicy(tokenBasic.Name)
+		tokenPrices := make([]*models.PriceMarket, 0)
 		for _, tokenPrice := range tokenBasic.PriceMarkets {
-			if tokenPrice.Ind == 1 {
-				price += tokenPrice.Price
-				counter++
+			if freshPrice(policy, tokenPrice, now) {
+				tokenPrice.Ind = 1
+				tokenPrices = append(tokenPrices, tokenPrice)
 			}
 		}
-		if counter > 0 {
-			price = price / counter
-			tokenBasic.Price = price
-			tokenBasic.Ind = 1
-			tokenBasic.Time = time.Now().Unix()
+		lastPrice := int64(0)
+		if lastInds[tokenBasic.Name] == 1 {
+			lastPrice = tokenBasic.Price
 		}
-		if tokenBasic.Ind == 0 {
-			logs.Error("Price of token %s is not update", tokenBasic.Name)
+		price, err := aggregatePrice(policy, tokenPrices, lastPrice)
+		if err != nil {
+			logs.Error("Price of token %s is not update: %v", tokenBasic.Name, err)
+			tokenBasic.Ind = lastInds[tokenBasic.Name]
+			if lastPrice > 0 {
+				rejections = append(rejections, &rejectedPrice{name: tokenBasic.Name, last: lastPrice, reason: err})
+			}
+			continue
 		}
+		tokenBasic.Price = price
+		tokenBasic.Ind = 1
+		tokenBasic.Time = now
+	}
+	if err := sendRejectedPricesDingAlarm(rejections); err != nil {
+		logs.Error("send rejected prices alarm err: %v", err)
 	}
 	return nil
 }
@@ -0,0 +1,51 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dex
+
+import (
+	"math/big"
+	"poly-bridge/chainsdk"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Chain reads the factory and pairs of a dex, it is implemented by
+// chainsdk.EthereumSdkPro.
+type Chain interface {
+	GetPair(factory, tokenA, tokenB common.Address) (common.Address, error)
+	GetPairState(pair common.Address) (*chainsdk.PairState, error)
+	Erc20Decimals(erc20 string) (uint8, error)
+}
+
+// observation is the cumulative prices of a pair at a block time, the average
+// prices between two observations are the differences of their cumulative
+// prices divided by the seconds between them.
+type observation struct {
+	timestamp        uint64
+	price0Cumulative *big.Int
+	price1Cumulative *big.Int
+}
+
+// pairPrice is a pair sampled in a round with its time weighted average
+// prices, price0 is token1 per token0 in raw amounts as an UQ112x112 number.
+// The prices are nil while the pair is not observed for a twap window yet.
+type pairPrice struct {
+	state  *chainsdk.PairState
+	price0 *big.Int
+	price1 *big.Int
+}
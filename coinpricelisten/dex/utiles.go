@@ -0,0 +1,305 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dex
+
+import (
+	"fmt"
+	"math/big"
+	"poly-bridge/chainsdk"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultMinLiquidity = 10000
+	defaultTwapWindow   = 1800
+	// nodeSelectionSlot is the seconds between the selections of the chain nodes
+	nodeSelectionSlot = 60
+)
+
+var (
+	q112    = new(big.Int).Lsh(big.NewInt(1), 112)
+	uint256 = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+type DexSdk struct {
+	name         string
+	chain        Chain
+	factory      common.Address
+	stable       common.Address
+	quotes       []common.Address
+	minLiquidity float64
+	twapWindow   uint64
+	pairs        map[[2]common.Address]common.Address
+	decimals     map[common.Address]uint8
+	observations map[common.Address][]*observation
+}
+
+func NewDexSdk(cfg *conf.CoinPriceListenConfig) *DexSdk {
+	chain := chainsdk.NewEthereumSdkPro(cfg.GetNodesUrl(), nodeSelectionSlot, cfg.Dex.ChainId)
+	return NewDexSdkWithChain(cfg, chain)
+}
+
+// NewDexSdkWithChain returns the sdk of the dex of the config reading the
+// pairs from chain.
+func NewDexSdkWithChain(cfg *conf.CoinPriceListenConfig, chain Chain) *DexSdk {
+	sdk := &DexSdk{
+		name:         cfg.MarketName,
+		chain:        chain,
+		factory:      common.HexToAddress(cfg.Dex.Factory),
+		stable:       common.HexToAddress(cfg.Dex.Stable),
+		quotes:       make([]common.Address, 0, len(cfg.Dex.Quotes)),
+		minLiquidity: cfg.Dex.MinLiquidity,
+		twapWindow:   uint64(cfg.Dex.TwapWindow),
+		pairs:        make(map[[2]common.Address]common.Address),
+		decimals:     make(map[common.Address]uint8),
+		observations: make(map[common.Address][]*observation),
+	}
+	for _, quote := range cfg.Dex.Quotes {
+		sdk.quotes = append(sdk.quotes, common.HexToAddress(quote))
+	}
+	if sdk.minLiquidity <= 0 {
+		sdk.minLiquidity = defaultMinLiquidity
+	}
+	if cfg.Dex.TwapWindow <= 0 {
+		sdk.twapWindow = defaultTwapWindow
+	}
+	return sdk
+}
+
+func (sdk *DexSdk) GetMarketName() string {
+	return sdk.name
+}
+
+// GetCoinPrice returns the usd prices of the coins, the names of the coins are
+// their token addresses. A token is priced by its pair with the stable coin, or
+// else by its pair with the first quote token that has one, and only when the
+// quote side of the pair has enough liquidity. The prices are averaged over the
+// twap window, so a token has no price until its pair has been sampled for a
+// window. An error is returned only when every coin fails.
+func (sdk *DexSdk) GetCoinPrice(coins []models.NameAndmarketId) (map[string]float64, error) {
+	round := make(map[common.Address]*pairPrice)
+	coinPrice := make(map[string]float64, 0)
+	failed := 0
+	for _, coin := range coins {
+		if !common.IsHexAddress(coin.PriceMarketName) {
+			logs.Warn("%s is not a token address of dex %s!", coin.PriceMarketName, sdk.name)
+			continue
+		}
+		price, err := sdk.tokenPrice(common.HexToAddress(coin.PriceMarketName), round)
+		if err != nil {
+			failed++
+			logs.Error("get price of token %s in dex %s err: %v", coin.PriceMarketName, sdk.name, err)
+			continue
+		}
+		if price <= 0 {
+			logs.Warn("There is no coin price %s in dex %s!", coin.PriceMarketName, sdk.name)
+			continue
+		}
+		coinPrice[coin.PriceMarketName] = price
+	}
+	if len(coins) > 0 && failed == len(coins) {
+		return nil, fmt.Errorf("Cannot get dex %s prices of %d coins!", sdk.name, len(coins))
+	}
+	return coinPrice, nil
+}
+
+// tokenPrice returns the usd price of the token, 0 when it can not be priced.
+func (sdk *DexSdk) tokenPrice(token common.Address, round map[common.Address]*pairPrice) (float64, error) {
+	if token == sdk.stable {
+		return 1, nil
+	}
+	for _, quote := range sdk.quotes {
+		if token == quote {
+			return sdk.priceIn(token, sdk.stable, 1, round)
+		}
+	}
+	price, err := sdk.priceIn(token, sdk.stable, 1, round)
+	if err != nil || price > 0 {
+		return price, err
+	}
+	for _, quote := range sdk.quotes {
+		quotePrice, err := sdk.priceIn(quote, sdk.stable, 1, round)
+		if err != nil {
+			return 0, err
+		}
+		price, err = sdk.priceIn(token, quote, quotePrice, round)
+		if err != nil || price > 0 {
+			return price, err
+		}
+	}
+	return 0, nil
+}
+
+// priceIn returns the usd price of the token by its pair with the quote token
+// of usd price quotePrice, 0 when there is no such pair, it has not enough
+// liquidity or it is not observed for a twap window yet. The pair is sampled
+// even when the quote token has no price yet, so both are ready together.
+func (sdk *DexSdk) priceIn(token, quote common.Address, quotePrice float64, round map[common.Address]*pairPrice) (float64, error) {
+	pair, err := sdk.getPair(token, quote)
+	if err != nil || pair == (common.Address{}) {
+		return 0, err
+	}
+	pp, err := sdk.samplePair(pair, round)
+	if err != nil || quotePrice <= 0 {
+		return 0, err
+	}
+	tokenDecimals, err := sdk.getDecimals(token)
+	if err != nil {
+		return 0, err
+	}
+	quoteDecimals, err := sdk.getDecimals(quote)
+	if err != nil {
+		return 0, err
+	}
+	price, quoteReserve := pp.price0, pp.state.Reserve1
+	if pp.state.Token0 != token {
+		price, quoteReserve = pp.price1, pp.state.Reserve0
+	}
+	liquidity, _ := new(big.Float).Mul(amount(quoteReserve, quoteDecimals), big.NewFloat(quotePrice)).Float64()
+	if liquidity < sdk.minLiquidity {
+		logs.Warn("pair %s of dex %s has %.2f usd liquidity, %.2f is required", pair.Hex(), sdk.name, liquidity, sdk.minLiquidity)
+		return 0, nil
+	}
+	if price == nil {
+		logs.Info("pair %s of dex %s is not observed for %d seconds yet", pair.Hex(), sdk.name, sdk.twapWindow)
+		return 0, nil
+	}
+	usd, _ := new(big.Float).Mul(twapPrice(price, tokenDecimals, quoteDecimals), big.NewFloat(quotePrice)).Float64()
+	return usd, nil
+}
+
+func (sdk *DexSdk) getPair(tokenA, tokenB common.Address) (common.Address, error) {
+	key := [2]common.Address{tokenA, tokenB}
+	if pair, ok := sdk.pairs[key]; ok {
+		return pair, nil
+	}
+	pair, err := sdk.chain.GetPair(sdk.factory, tokenA, tokenB)
+	if err != nil {
+		return common.Address{}, err
+	}
+	// a missing pair is asked again, it may be created later
+	if pair != (common.Address{}) {
+		sdk.pairs[key] = pair
+	}
+	return pair, nil
+}
+
+func (sdk *DexSdk) getDecimals(token common.Address) (uint8, error) {
+	if decimals, ok := sdk.decimals[token]; ok {
+		return decimals, nil
+	}
+	decimals, err := sdk.chain.Erc20Decimals(token.Hex())
+	if err != nil {
+		return 0, err
+	}
+	sdk.decimals[token] = decimals
+	return decimals, nil
+}
+
+// samplePair reads the pair once a round and returns its prices averaged
+// since the latest observation at least a twap window old.
+func (sdk *DexSdk) samplePair(pair common.Address, round map[common.Address]*pairPrice) (*pairPrice, error) {
+	if pp, ok := round[pair]; ok {
+		return pp, nil
+	}
+	state, err := sdk.chain.GetPairState(pair)
+	if err != nil {
+		return nil, err
+	}
+	price0Cumulative, price1Cumulative := cumulativePrices(state)
+	current := &observation{timestamp: state.Timestamp, price0Cumulative: price0Cumulative, price1Cumulative: price1Cumulative}
+	pp := &pairPrice{state: state}
+	if base := sdk.observe(pair, current); base != nil {
+		pp.price0, pp.price1 = averagePrices(base, current)
+	}
+	round[pair] = pp
+	return pp, nil
+}
+
+// observe keeps the observation of the pair and returns the latest one at
+// least a twap window older, the ones before it are dropped.
+func (sdk *DexSdk) observe(pair common.Address, current *observation) *observation {
+	observations := sdk.observations[pair]
+	if n := len(observations); n == 0 || observations[n-1].timestamp < current.timestamp {
+		observations = append(observations, current)
+	}
+	var base *observation
+	for i := len(observations) - 1; i >= 0; i-- {
+		if observations[i].timestamp+sdk.twapWindow <= current.timestamp {
+			base = observations[i]
+			observations = observations[i:]
+			break
+		}
+	}
+	sdk.observations[pair] = observations
+	return base
+}
+
+// cumulativePrices returns the cumulative prices of the pair at the time of
+// the block, they are only updated by the pair at its first trade of a block,
+// so the price of the reserves since then is added like the pair does.
+func cumulativePrices(state *chainsdk.PairState) (*big.Int, *big.Int) {
+	price0Cumulative := new(big.Int).Set(state.Price0CumulativeLast)
+	price1Cumulative := new(big.Int).Set(state.Price1CumulativeLast)
+	// the pair keeps the time in uint32 and lets it overflow
+	elapsed := uint32(state.Timestamp) - state.BlockTimestampLast
+	if elapsed > 0 && state.Reserve0.Sign() > 0 && state.Reserve1.Sign() > 0 {
+		price0 := new(big.Int).Quo(new(big.Int).Lsh(state.Reserve1, 112), state.Reserve0)
+		price1 := new(big.Int).Quo(new(big.Int).Lsh(state.Reserve0, 112), state.Reserve1)
+		price0Cumulative.Add(price0Cumulative, price0.Mul(price0, new(big.Int).SetUint64(uint64(elapsed))))
+		price1Cumulative.Add(price1Cumulative, price1.Mul(price1, new(big.Int).SetUint64(uint64(elapsed))))
+		price0Cumulative.Mod(price0Cumulative, uint256)
+		price1Cumulative.Mod(price1Cumulative, uint256)
+	}
+	return price0Cumulative, price1Cumulative
+}
+
+// averagePrices returns the time weighted average prices between the
+// observations, the cumulative prices may overflow uint256.
+func averagePrices(base, current *observation) (*big.Int, *big.Int) {
+	elapsed := new(big.Int).SetUint64(current.timestamp - base.timestamp)
+	price0 := new(big.Int).Sub(current.price0Cumulative, base.price0Cumulative)
+	price1 := new(big.Int).Sub(current.price1Cumulative, base.price1Cumulative)
+	price0.Mod(price0, uint256).Quo(price0, elapsed)
+	price1.Mod(price1, uint256).Quo(price1, elapsed)
+	return price0, price1
+}
+
+// twapPrice converts the UQ112x112 price of raw amounts to the price of whole
+// tokens.
+func twapPrice(price *big.Int, baseDecimals, quoteDecimals uint8) *big.Float {
+	result := new(big.Float).Quo(new(big.Float).SetInt(price), new(big.Float).SetInt(q112))
+	if baseDecimals > quoteDecimals {
+		result.Mul(result, new(big.Float).SetInt(pow10(baseDecimals-quoteDecimals)))
+	} else if baseDecimals < quoteDecimals {
+		result.Quo(result, new(big.Float).SetInt(pow10(quoteDecimals-baseDecimals)))
+	}
+	return result
+}
+
+func amount(raw *big.Int, decimals uint8) *big.Float {
+	return new(big.Float).Quo(new(big.Float).SetInt(raw), new(big.Float).SetInt(pow10(decimals)))
+}
+
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,177 @@
+package dex
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"poly-bridge/chainsdk"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	factory = common.HexToAddress("0x0000000000000000000000000000000000000f00")
+	token   = common.HexToAddress("0x0000000000000000000000000000000000000010")
+	usdt    = common.HexToAddress("0x0000000000000000000000000000000000000020")
+	weth    = common.HexToAddress("0x0000000000000000000000000000000000000030")
+	routed  = common.HexToAddress("0x0000000000000000000000000000000000000040")
+	shallow = common.HexToAddress("0x0000000000000000000000000000000000000050")
+)
+
+type fakeChain struct {
+	pairs    map[[2]common.Address]common.Address
+	states   map[common.Address]*chainsdk.PairState
+	decimals map[common.Address]uint8
+}
+
+func (c *fakeChain) GetPair(factory, tokenA, tokenB common.Address) (common.Address, error) {
+	if pair, ok := c.pairs[[2]common.Address{tokenA, tokenB}]; ok {
+		return pair, nil
+	}
+	return c.pairs[[2]common.Address{tokenB, tokenA}], nil
+}
+
+func (c *fakeChain) GetPairState(pair common.Address) (*chainsdk.PairState, error) {
+	state, ok := c.states[pair]
+	if !ok {
+		return nil, fmt.Errorf("pair %s is not found", pair.Hex())
+	}
+	return state, nil
+}
+
+func (c *fakeChain) Erc20Decimals(erc20 string) (uint8, error) {
+	return c.decimals[common.HexToAddress(erc20)], nil
+}
+
+// addPair adds a pair with the reserves of whole tokens, token0 is the lower
+// address like the factory sorts them.
+func (c *fakeChain) addPair(tokenA, tokenB common.Address, reserveA, reserveB int64) common.Address {
+	pair := common.BigToAddress(big.NewInt(int64(len(c.pairs) + 1)))
+	c.pairs[[2]common.Address{tokenA, tokenB}] = pair
+	rawA := new(big.Int).Mul(big.NewInt(reserveA), pow10(c.decimals[tokenA]))
+	rawB := new(big.Int).Mul(big.NewInt(reserveB), pow10(c.decimals[tokenB]))
+	state := &chainsdk.PairState{Token0: tokenA, Token1: tokenB, Reserve0: rawA, Reserve1: rawB}
+	if bytes.Compare(tokenB.Bytes(), tokenA.Bytes()) < 0 {
+		state.Token0, state.Token1, state.Reserve0, state.Reserve1 = tokenB, tokenA, rawB, rawA
+	}
+	state.Price0CumulativeLast, state.Price1CumulativeLast = new(big.Int), new(big.Int)
+	c.states[pair] = state
+	return pair
+}
+
+// advance moves the chain to the time without any trade.
+func (c *fakeChain) advance(timestamp uint64) {
+	for _, state := range c.states {
+		state.Timestamp = timestamp
+	}
+}
+
+// swap updates the cumulative prices like the first trade of a block and sets
+// the reserves after it.
+func (c *fakeChain) swap(pair common.Address, reserve0, reserve1 *big.Int) {
+	state := c.states[pair]
+	state.Price0CumulativeLast, state.Price1CumulativeLast = cumulativePrices(state)
+	state.BlockTimestampLast = uint32(state.Timestamp)
+	state.Reserve0, state.Reserve1 = reserve0, reserve1
+}
+
+func newTestSdk() (*DexSdk, *fakeChain) {
+	chain := &fakeChain{
+		pairs:    make(map[[2]common.Address]common.Address),
+		states:   make(map[common.Address]*chainsdk.PairState),
+		decimals: map[common.Address]uint8{token: 18, usdt: 6, weth: 18, routed: 9, shallow: 18},
+	}
+	sdk := NewDexSdkWithChain(&conf.CoinPriceListenConfig{
+		MarketName: "uniswap",
+		Dex: &conf.DexPriceConfig{
+			Factory:      factory.Hex(),
+			Stable:       usdt.Hex(),
+			Quotes:       []string{weth.Hex()},
+			MinLiquidity: 1000,
+		},
+	}, chain)
+	return sdk, chain
+}
+
+func coins(tokens ...common.Address) []models.NameAndmarketId {
+	coins := make([]models.NameAndmarketId, 0, len(tokens))
+	for _, token := range tokens {
+		coins = append(coins, models.NameAndmarketId{PriceMarketName: token.Hex()})
+	}
+	return coins
+}
+
+func checkPrices(t *testing.T, prices map[string]float64, want map[common.Address]float64) {
+	if len(prices) != len(want) {
+		t.Errorf("prices = %v, want %d", prices, len(want))
+	}
+	for token, price := range want {
+		if got, ok := prices[token.Hex()]; !ok || math.Abs(got-price) > price*1e-9 {
+			t.Errorf("price of %s = %v, want %v", token.Hex(), got, price)
+		}
+	}
+}
+
+func TestDexPrice(t *testing.T) {
+	sdk, chain := newTestSdk()
+	pair := chain.addPair(token, usdt, 1000, 2000)
+	chain.addPair(weth, usdt, 1000, 3000000)
+	chain.addPair(routed, weth, 30000, 10)
+	chain.addPair(shallow, usdt, 1000, 500)
+	all := coins(token, usdt, weth, routed, shallow)
+
+	chain.advance(1000)
+	prices, err := sdk.GetCoinPrice(all)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPrices(t, prices, map[common.Address]float64{usdt: 1})
+
+	chain.advance(2000)
+	if prices, err = sdk.GetCoinPrice(all); err != nil {
+		t.Fatal(err)
+	}
+	checkPrices(t, prices, map[common.Address]float64{usdt: 1})
+
+	chain.advance(2800)
+	if prices, err = sdk.GetCoinPrice(all); err != nil {
+		t.Fatal(err)
+	}
+	checkPrices(t, prices, map[common.Address]float64{token: 2, usdt: 1, weth: 3000, routed: 1})
+
+	// the price of a swap moving it 10000 times only counts for its 10
+	// seconds of the 1820 seconds averaged
+	chain.advance(2810)
+	chain.swap(pair, new(big.Int).Mul(big.NewInt(10), pow10(18)), new(big.Int).Mul(big.NewInt(200000), pow10(6)))
+	chain.advance(2820)
+	if prices, err = sdk.GetCoinPrice(coins(token)); err != nil {
+		t.Fatal(err)
+	}
+	checkPrices(t, prices, map[common.Address]float64{token: (2*1810 + 20000*10) / 1820.0})
+}
+
+func TestDexPriceError(t *testing.T) {
+	sdk, chain := newTestSdk()
+	chain.pairs[[2]common.Address{token, usdt}] = common.HexToAddress("0x01")
+	if _, err := sdk.GetCoinPrice(coins(token)); err == nil {
+		t.Errorf("price of a failed pair has no error")
+	}
+	prices, err := sdk.GetCoinPrice(append(coins(token, usdt), models.NameAndmarketId{PriceMarketName: "TOKEN"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPrices(t, prices, map[common.Address]float64{usdt: 1})
+}
+
+func TestAveragePricesOverflow(t *testing.T) {
+	base := &observation{timestamp: 100, price0Cumulative: new(big.Int).Sub(uint256, big.NewInt(50)), price1Cumulative: big.NewInt(0)}
+	current := &observation{timestamp: 110, price0Cumulative: big.NewInt(50), price1Cumulative: big.NewInt(100)}
+	price0, price1 := averagePrices(base, current)
+	if price0.Int64() != 10 || price1.Int64() != 10 {
+		t.Errorf("average prices = %s, %s", price0, price1)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package huobi
+
+// TickersMedia is the response of market/tickers, Status is "ok" when Data is
+// filled and "error" with ErrCode and ErrMsg otherwise.
+type TickersMedia struct {
+	Status  string    `json:"status"`
+	ErrCode string    `json:"err-code"`
+	ErrMsg  string    `json:"err-msg"`
+	Ts      int64     `json:"ts"`
+	Data    []*Ticker `json:"data"`
+}
+
+// Ticker is the 24h summary of a symbol, like btcusdt.
+type Ticker struct {
+	Symbol string  `json:"symbol"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Amount float64 `json:"amount"`
+	Vol    float64 `json:"vol"`
+	Count  int64   `json:"count"`
+	Bid    float64 `json:"bid"`
+	Ask    float64 `json:"ask"`
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package huobi
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/beego/beego/v2/core/logs"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"poly-bridge/basedef"
+	"poly-bridge/coinpricelisten/restful"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type HuobiSdk struct {
+	client *http.Client
+	nodes  *restful.Nodes
+}
+
+func DefaultHuobiSdk() *HuobiSdk {
+	return NewHuobiSdk(&conf.CoinPriceListenConfig{
+		MarketName: basedef.MARKET_HUOBI,
+		Nodes: []*conf.Restful{
+			{
+				Url: "https://api.huobi.pro/",
+			},
+		},
+	})
+}
+
+// NewHuobiSdk returns the sdk of the nodes of the config. A node with a Key
+// sends it as the AccessKeyId, the nodes are used in turns so the requests
+// are spread over the keys.
+func NewHuobiSdk(cfg *conf.CoinPriceListenConfig) *HuobiSdk {
+	client := &http.Client{Timeout: time.Second * 30}
+	sdk := &HuobiSdk{
+		client: client,
+		nodes:  restful.NewNodes("Huobi", cfg.Nodes),
+	}
+	return sdk
+}
+
+// QuotesLatest returns the tickers of all the symbols in one request. A node
+// answering 429 is skipped until it allows requests again.
+func (sdk *HuobiSdk) QuotesLatest() (tickers []*Ticker, err error) {
+	err = sdk.nodes.QuotesLatest(func(node *conf.Restful) error {
+		tickers, err = sdk.quotesLatest(node)
+		return err
+	})
+	return tickers, err
+}
+
+func (sdk *HuobiSdk) quotesLatest(node *conf.Restful) ([]*Ticker, error) {
+	req, err := http.NewRequest("GET", node.Url+"market/tickers", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accepts", "application/json")
+	if node.Key != "" {
+		q := url.Values{}
+		q.Add("AccessKeyId", node.Key)
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := sdk.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := restful.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	var body TickersMedia
+	err = json.Unmarshal(respBody, &body)
+	if err != nil {
+		return nil, err
+	}
+	if body.Status != "ok" {
+		return nil, fmt.Errorf("response status: %s, err-code: %s, err-msg: %s", body.Status, body.ErrCode, body.ErrMsg)
+	}
+	return body.Data, nil
+}
+
+// symbol returns the symbol of the coin, its CoinMarketId when it is set or
+// else its name, like btcusdt.
+func symbol(coin models.NameAndmarketId) string {
+	if coin.CoinMarketId > 0 {
+		return strconv.Itoa(coin.CoinMarketId)
+	}
+	return strings.ToLower(coin.PriceMarketName)
+}
+
+func (sdk *HuobiSdk) GetMarketName() string {
+	return basedef.MARKET_HUOBI
+}
+
+// GetCoinPrice returns the close prices of the symbols of the coins by the
+// names of the coins, the symbols are case insensitive.
+func (sdk *HuobiSdk) GetCoinPrice(coins []models.NameAndmarketId) (map[string]float64, error) {
+	tickers, err := sdk.QuotesLatest()
+	if err != nil {
+		return nil, err
+	}
+	symbol2Price := make(map[string]float64, 0)
+	for _, v := range tickers {
+		symbol2Price[strings.ToLower(v.Symbol)] = v.Close
+	}
+	coinPrice := make(map[string]float64, 0)
+	for _, coin := range coins {
+		price, ok := symbol2Price[symbol(coin)]
+		if !ok || price <= 0 {
+			logs.Warn("There is no coin price %s in Huobi!", coin.PriceMarketName)
+			continue
+		}
+		coinPrice[coin.PriceMarketName] = price
+	}
+	return coinPrice, nil
+}
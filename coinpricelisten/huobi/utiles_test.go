@@ -0,0 +1,106 @@
+package huobi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"strings"
+	"testing"
+)
+
+// fixtureServer serves the recorded response in testdata and counts the
+// requests, a status other than 200 is answered without a body.
+func fixtureServer(t *testing.T, status int, fixture string, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Path != "/market/tickers" {
+			t.Errorf("request path = %s", r.URL.Path)
+		}
+		if status != http.StatusOK {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(status)
+			return
+		}
+		data, err := ioutil.ReadFile("testdata/" + fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}))
+}
+
+func TestGetCoinPrice(t *testing.T) {
+	var limitedRequests, requests int
+	limited := fixtureServer(t, http.StatusTooManyRequests, "", &limitedRequests)
+	defer limited.Close()
+	server := fixtureServer(t, http.StatusOK, "tickers.json", &requests)
+	defer server.Close()
+	sdk := NewHuobiSdk(&conf.CoinPriceListenConfig{Nodes: []*conf.Restful{{Url: limited.URL + "/"}, {Url: server.URL + "/"}}})
+
+	coins := []models.NameAndmarketId{{PriceMarketName: "BTCUSDT"}, {PriceMarketName: "ethusdt"}, {PriceMarketName: "deadusdt"}, {PriceMarketName: "nousdt"}}
+	prices, err := sdk.GetCoinPrice(coins)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 2 || prices["BTCUSDT"] != 37301.52 || prices["ethusdt"] != 2633.41 {
+		t.Errorf("prices = %v", prices)
+	}
+	if _, err := sdk.GetCoinPrice(coins); err != nil {
+		t.Fatal(err)
+	}
+	if limitedRequests != 1 || requests != 2 {
+		t.Errorf("rate limited node is requested %d times, the other %d times", limitedRequests, requests)
+	}
+}
+
+func TestGetCoinPriceError(t *testing.T) {
+	var requests int
+	server := fixtureServer(t, http.StatusOK, "tickers_error.json", &requests)
+	defer server.Close()
+	sdk := NewHuobiSdk(&conf.CoinPriceListenConfig{Nodes: []*conf.Restful{{Url: server.URL + "/"}}})
+	if _, err := sdk.GetCoinPrice([]models.NameAndmarketId{{PriceMarketName: "btcusdt"}}); err == nil {
+		t.Errorf("error response is accepted")
+	}
+}
+
+func TestGetCoinPriceKeys(t *testing.T) {
+	keys := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.URL.Query().Get("AccessKeyId"))
+		data, err := ioutil.ReadFile("testdata/tickers.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+	sdk := NewHuobiSdk(&conf.CoinPriceListenConfig{Nodes: []*conf.Restful{
+		{Url: server.URL + "/", Key: "key-a"},
+		{Url: server.URL + "/", Key: "key-b"},
+	}})
+	for i := 0; i < 3; i++ {
+		if _, err := sdk.GetCoinPrice([]models.NameAndmarketId{{PriceMarketName: "btcusdt"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := strings.Join(keys, ","); got != "key-a,key-b,key-a" {
+		t.Errorf("keys of the requests = %s", got)
+	}
+}
+
+func TestSymbol(t *testing.T) {
+	cases := []struct {
+		coin   models.NameAndmarketId
+		symbol string
+	}{
+		{models.NameAndmarketId{PriceMarketName: "BTCUSDT"}, "btcusdt"},
+		{models.NameAndmarketId{PriceMarketName: "BTCUSDT", CoinMarketId: 1}, "1"},
+	}
+	for _, c := range cases {
+		if got := symbol(c.coin); got != c.symbol {
+			t.Errorf("symbol of %+v = %s, want %s", c.coin, got, c.symbol)
+		}
+	}
+}
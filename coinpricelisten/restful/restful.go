@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package restful
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"poly-bridge/conf"
+
+	"github.com/beego/beego/v2/core/logs"
+)
+
+// defaultRetryAfter is how long a rate limited node is skipped when it does
+// not tell
+const defaultRetryAfter = time.Minute
+
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
+}
+
+// CheckResponse returns a RateLimitError when the node answers 429, or an
+// error when it does not answer 200.
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{RetryAfter: retryAfter(resp)}
+	}
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("response status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// retryAfter returns the Retry-After seconds of the response, or the default
+// when it is missing.
+func retryAfter(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultRetryAfter
+}
+
+// Nodes are the restful nodes of a price market. They are used in turns so
+// the requests are spread over their keys, and a node answering 429 is
+// skipped until it allows requests again.
+type Nodes struct {
+	market  string
+	nodes   []*conf.Restful
+	next    int
+	limited map[int]time.Time
+}
+
+func NewNodes(market string, nodes []*conf.Restful) *Nodes {
+	return &Nodes{
+		market:  market,
+		nodes:   nodes,
+		limited: make(map[int]time.Time),
+	}
+}
+
+// QuotesLatest calls request with the nodes, from the one after the last node
+// that answered, until one of them succeeds.
+func (n *Nodes) QuotesLatest(request func(node *conf.Restful) error) error {
+	now := time.Now()
+	for i := 0; i < len(n.nodes); i++ {
+		node := (n.next + i) % len(n.nodes)
+		if until, ok := n.limited[node]; ok && now.Before(until) {
+			continue
+		}
+		if err := request(n.nodes[node]); err != nil {
+			logs.Error("%s QuotesLatest err: %s", n.market, err.Error())
+			var limit *RateLimitError
+			if errors.As(err, &limit) {
+				n.limited[node] = now.Add(limit.RetryAfter)
+			}
+			continue
+		}
+		delete(n.limited, node)
+		n.next = (node + 1) % len(n.nodes)
+		return nil
+	}
+	return fmt.Errorf("Cannot get %s QuotesLatest!", n.market)
+}
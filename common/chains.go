@@ -11,31 +11,124 @@ import (
 	"github.com/beego/beego/v2/core/logs"
 )
 
+// ChainSdk is the token query of a chain family used by the stats and http services.
+type ChainSdk interface {
+	TokenBalance(hash string, proxy string) (*big.Int, error)
+	TokenTotalSupply(hash string, proxies []string) (*big.Int, error)
+}
+
+type ChainSdkFactory func(cfg *conf.ChainListenConfig) ChainSdk
+
 var (
-	ethereumSdk   *chainsdk.EthereumSdkPro
-	pltSdk        *chainsdk.EthereumSdkPro
-	bscSdk        *chainsdk.EthereumSdkPro
-	hecoSdk       *chainsdk.EthereumSdkPro
-	okSdk         *chainsdk.EthereumSdkPro
-	neoSdk        *chainsdk.NeoSdkPro
-	neo3Sdk       *chainsdk.Neo3SdkPro
-	ontologySdk   *chainsdk.OntologySdkPro
-	maticSdk      *chainsdk.EthereumSdkPro
-	swthSdk       *chainsdk.SwitcheoSdkPro
-	arbitrumSdk   *chainsdk.EthereumSdkPro
-	zilliqaSdk    *chainsdk.ZilliqaSdkPro
-	xdaiSdk       *chainsdk.EthereumSdkPro
-	fantomSdk     *chainsdk.EthereumSdkPro
-	avaxSdk       *chainsdk.EthereumSdkPro
-	optimisticSdk *chainsdk.EthereumSdkPro
-	metisSdk      *chainsdk.EthereumSdkPro
-	bobaSdk       *chainsdk.EthereumSdkPro
-	rinkebySdk    *chainsdk.EthereumSdkPro
-	sdkMap        map[uint64]interface{}
-	oasisSdk      *chainsdk.EthereumSdkPro
-	config        *conf.Config
+	sdkFactories = make(map[string]ChainSdkFactory)
+	sdkMap       map[uint64]ChainSdk
+	config       *conf.Config
 )
 
+// RegisterChainSdk sets the factory of the sdks of a chain family.
+func RegisterChainSdk(family string, factory ChainSdkFactory) {
+	sdkFactories[family] = factory
+}
+
+func init() {
+	RegisterChainSdk(basedef.FAMILY_EVM, func(cfg *conf.ChainListenConfig) ChainSdk {
+		return &ethereumChainSdk{chainsdk.NewEthereumSdkPro(cfg.GetNodesUrl(), cfg.ListenSlot, cfg.ChainId)}
+	})
+	RegisterChainSdk(basedef.FAMILY_NEO, func(cfg *conf.ChainListenConfig) ChainSdk {
+		return &neoChainSdk{chainsdk.NewNeoSdkPro(cfg.GetNodesUrl(), cfg.ListenSlot, cfg.ChainId)}
+	})
+	RegisterChainSdk(basedef.FAMILY_NEO3, func(cfg *conf.ChainListenConfig) ChainSdk {
+		return &neo3ChainSdk{chainsdk.NewNeo3SdkPro(cfg.GetNodesUrl(), cfg.ListenSlot, cfg.ChainId)}
+	})
+	RegisterChainSdk(basedef.FAMILY_ONTOLOGY, func(cfg *conf.ChainListenConfig) ChainSdk {
+		return &ontologyChainSdk{chainsdk.NewOntologySdkPro(cfg.GetNodesUrl(), cfg.ListenSlot, cfg.ChainId)}
+	})
+	RegisterChainSdk(basedef.FAMILY_SWITCHEO, func(cfg *conf.ChainListenConfig) ChainSdk {
+		return &switcheoChainSdk{chainsdk.NewSwitcheoSdkPro(cfg.GetNodesUrl(), cfg.ListenSlot, cfg.ChainId)}
+	})
+	RegisterChainSdk(basedef.FAMILY_ZILLIQA, func(cfg *conf.ChainListenConfig) ChainSdk {
+		return &zilliqaChainSdk{chainsdk.NewZilliqaSdkPro(cfg.GetNodesUrl(), cfg.ListenSlot, cfg.ChainId)}
+	})
+}
+
+type ethereumChainSdk struct {
+	*chainsdk.EthereumSdkPro
+}
+
+func (sdk *ethereumChainSdk) TokenBalance(hash string, proxy string) (*big.Int, error) {
+	return sdk.Erc20Balance(hash, proxy)
+}
+
+func (sdk *ethereumChainSdk) TokenTotalSupply(hash string, proxies []string) (*big.Int, error) {
+	return sdk.Erc20TotalSupply(hash)
+}
+
+type neoChainSdk struct {
+	*chainsdk.NeoSdkPro
+}
+
+func (sdk *neoChainSdk) TokenBalance(hash string, proxy string) (*big.Int, error) {
+	return sdk.Nep5Balance(hash, proxy)
+}
+
+func (sdk *neoChainSdk) TokenTotalSupply(hash string, proxies []string) (*big.Int, error) {
+	return sdk.Nep5TotalSupply(hash)
+}
+
+type neo3ChainSdk struct {
+	*chainsdk.Neo3SdkPro
+}
+
+func (sdk *neo3ChainSdk) TokenBalance(hash string, proxy string) (*big.Int, error) {
+	return sdk.Nep17Balance(hash, proxy)
+}
+
+func (sdk *neo3ChainSdk) TokenTotalSupply(hash string, proxies []string) (*big.Int, error) {
+	return sdk.Nep17TotalSupply(hash)
+}
+
+type ontologyChainSdk struct {
+	*chainsdk.OntologySdkPro
+}
+
+func (sdk *ontologyChainSdk) TokenBalance(hash string, proxy string) (*big.Int, error) {
+	return sdk.Oep4Balance(hash, proxy)
+}
+
+func (sdk *ontologyChainSdk) TokenTotalSupply(hash string, proxies []string) (*big.Int, error) {
+	for _, v := range proxies {
+		if len(strings.TrimSpace(v)) != 0 {
+			return sdk.Oep4TotalSupply(hash, v)
+		}
+	}
+	return new(big.Int).SetUint64(0), nil
+}
+
+// switcheoChainSdk has no token balances, the chain only listens.
+type switcheoChainSdk struct {
+	*chainsdk.SwitcheoSdkPro
+}
+
+func (sdk *switcheoChainSdk) TokenBalance(hash string, proxy string) (*big.Int, error) {
+	return new(big.Int).SetUint64(0), nil
+}
+
+func (sdk *switcheoChainSdk) TokenTotalSupply(hash string, proxies []string) (*big.Int, error) {
+	return new(big.Int).SetUint64(0), nil
+}
+
+type zilliqaChainSdk struct {
+	*chainsdk.ZilliqaSdkPro
+}
+
+func (sdk *zilliqaChainSdk) TokenBalance(hash string, proxy string) (*big.Int, error) {
+	return sdk.Erc20Balance(hash, proxy)
+}
+
+func (sdk *zilliqaChainSdk) TokenTotalSupply(hash string, proxies []string) (*big.Int, error) {
+	return new(big.Int).SetUint64(0), nil
+}
+
 func SetupChainsSDK(cfg *conf.Config) {
 	if cfg == nil {
 		panic("Missing config")
@@ -45,451 +138,45 @@ func SetupChainsSDK(cfg *conf.Config) {
 }
 
 func newChainSdks(config *conf.Config) {
-	sdkMap = make(map[uint64]interface{}, 0)
-	{
-		ethereumConfig := config.GetChainListenConfig(basedef.ETHEREUM_CROSSCHAIN_ID)
-		if ethereumConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := ethereumConfig.GetNodesUrl()
-		ethereumSdk = chainsdk.NewEthereumSdkPro(urls, ethereumConfig.ListenSlot, ethereumConfig.ChainId)
-		sdkMap[basedef.ETHEREUM_CROSSCHAIN_ID] = ethereumSdk
-	}
-	{
-		maticConfig := config.GetChainListenConfig(basedef.MATIC_CROSSCHAIN_ID)
-		if maticConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := maticConfig.GetNodesUrl()
-		maticSdk = chainsdk.NewEthereumSdkPro(urls, maticConfig.ListenSlot, maticConfig.ChainId)
-		sdkMap[basedef.MATIC_CROSSCHAIN_ID] = maticSdk
-	}
-	{
-		bscConfig := config.GetChainListenConfig(basedef.BSC_CROSSCHAIN_ID)
-		if bscConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := bscConfig.GetNodesUrl()
-		bscSdk = chainsdk.NewEthereumSdkPro(urls, bscConfig.ListenSlot, bscConfig.ChainId)
-		sdkMap[basedef.BSC_CROSSCHAIN_ID] = bscSdk
-	}
-	{
-		hecoConfig := config.GetChainListenConfig(basedef.HECO_CROSSCHAIN_ID)
-		if hecoConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := hecoConfig.GetNodesUrl()
-		hecoSdk = chainsdk.NewEthereumSdkPro(urls, hecoConfig.ListenSlot, hecoConfig.ChainId)
-		sdkMap[basedef.HECO_CROSSCHAIN_ID] = hecoSdk
-	}
-	{
-		okConfig := config.GetChainListenConfig(basedef.OK_CROSSCHAIN_ID)
-		if okConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := okConfig.GetNodesUrl()
-		okSdk = chainsdk.NewEthereumSdkPro(urls, okConfig.ListenSlot, okConfig.ChainId)
-		sdkMap[basedef.OK_CROSSCHAIN_ID] = okSdk
-	}
-	{
-		neoConfig := config.GetChainListenConfig(basedef.NEO_CROSSCHAIN_ID)
-		if neoConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := neoConfig.GetNodesUrl()
-		neoSdk = chainsdk.NewNeoSdkPro(urls, neoConfig.ListenSlot, neoConfig.ChainId)
-		sdkMap[basedef.NEO_CROSSCHAIN_ID] = neoSdk
-	}
-	{
-		neo3Config := config.GetChainListenConfig(basedef.NEO3_CROSSCHAIN_ID)
-		if neo3Config == nil {
-			panic("chain is invalid")
-		}
-		urls := neo3Config.GetNodesUrl()
-		neo3Sdk = chainsdk.NewNeo3SdkPro(urls, neo3Config.ListenSlot, neo3Config.ChainId)
-		sdkMap[basedef.NEO3_CROSSCHAIN_ID] = neo3Sdk
-	}
-	{
-		ontConfig := config.GetChainListenConfig(basedef.ONT_CROSSCHAIN_ID)
-		if ontConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := ontConfig.GetNodesUrl()
-		ontologySdk = chainsdk.NewOntologySdkPro(urls, ontConfig.ListenSlot, ontConfig.ChainId)
-		sdkMap[basedef.ONT_CROSSCHAIN_ID] = ontologySdk
-	}
-	if basedef.ENV == basedef.MAINNET {
-		swthConfig := config.GetChainListenConfig(basedef.SWITCHEO_CROSSCHAIN_ID)
-		if swthConfig == nil {
-			panic("swth chain is invalid")
-		}
-		urls := swthConfig.GetNodesUrl()
-		swthSdk = chainsdk.NewSwitcheoSdkPro(urls, swthConfig.ListenSlot, swthConfig.ChainId)
-		sdkMap[basedef.SWITCHEO_CROSSCHAIN_ID] = swthSdk
-	}
-	{
-		conf := config.GetChainListenConfig(basedef.PLT_CROSSCHAIN_ID)
-		if conf != nil {
-			urls := conf.GetNodesUrl()
-			pltSdk = chainsdk.NewEthereumSdkPro(urls, conf.ListenSlot, conf.ChainId)
-			sdkMap[basedef.PLT_CROSSCHAIN_ID] = pltSdk
-		} else {
-			logs.Error("Missing plt chain sdk config")
-		}
-	}
-	{
-		arbitrumConfig := config.GetChainListenConfig(basedef.ARBITRUM_CROSSCHAIN_ID)
-		if arbitrumConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := arbitrumConfig.GetNodesUrl()
-		arbitrumSdk = chainsdk.NewEthereumSdkPro(urls, arbitrumConfig.ListenSlot, arbitrumConfig.ChainId)
-		sdkMap[basedef.ARBITRUM_CROSSCHAIN_ID] = arbitrumSdk
-	}
-	{
-		xdaiConfig := config.GetChainListenConfig(basedef.XDAI_CROSSCHAIN_ID)
-		if xdaiConfig == nil {
-			panic("chain:XDAI is invalid")
-		}
-		urls := xdaiConfig.GetNodesUrl()
-		xdaiSdk = chainsdk.NewEthereumSdkPro(urls, xdaiConfig.ListenSlot, xdaiConfig.ChainId)
-		sdkMap[basedef.XDAI_CROSSCHAIN_ID] = xdaiSdk
-	}
-	{
-		zilliqaCfg := config.GetChainListenConfig(basedef.ZILLIQA_CROSSCHAIN_ID)
-		if zilliqaCfg == nil {
-			panic("zilliqa GetChainListenConfig chain is invalid")
-		}
-		urls := zilliqaCfg.GetNodesUrl()
-		zilliqaSdk = chainsdk.NewZilliqaSdkPro(urls, zilliqaCfg.ListenSlot, zilliqaCfg.ChainId)
-		sdkMap[basedef.ZILLIQA_CROSSCHAIN_ID] = zilliqaSdk
-	}
-	{
-		fantomConfig := config.GetChainListenConfig(basedef.FANTOM_CROSSCHAIN_ID)
-		if fantomConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := fantomConfig.GetNodesUrl()
-		fantomSdk = chainsdk.NewEthereumSdkPro(urls, fantomConfig.ListenSlot, fantomConfig.ChainId)
-		sdkMap[basedef.FANTOM_CROSSCHAIN_ID] = fantomSdk
-	}
-	{
-		avaxConfig := config.GetChainListenConfig(basedef.AVAX_CROSSCHAIN_ID)
-		if avaxConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := avaxConfig.GetNodesUrl()
-		avaxSdk = chainsdk.NewEthereumSdkPro(urls, avaxConfig.ListenSlot, avaxConfig.ChainId)
-		sdkMap[basedef.AVAX_CROSSCHAIN_ID] = avaxSdk
-	}
-	{
-		optimisticConfig := config.GetChainListenConfig(basedef.OPTIMISTIC_CROSSCHAIN_ID)
-		if optimisticConfig == nil {
-			panic("chain is invalid")
-		}
-		urls := optimisticConfig.GetNodesUrl()
-		optimisticSdk = chainsdk.NewEthereumSdkPro(urls, optimisticConfig.ListenSlot, optimisticConfig.ChainId)
-		sdkMap[basedef.OPTIMISTIC_CROSSCHAIN_ID] = optimisticSdk
-	}
-	{
-		metisConfig := config.GetChainListenConfig(basedef.METIS_CROSSCHAIN_ID)
-		if metisConfig == nil {
-			panic("metis chain is invalid")
-		}
-		urls := metisConfig.GetNodesUrl()
-		metisSdk = chainsdk.NewEthereumSdkPro(urls, metisConfig.ListenSlot, metisConfig.ChainId)
-		sdkMap[basedef.METIS_CROSSCHAIN_ID] = metisSdk
-	}
-	{
-		bobaConfig := config.GetChainListenConfig(basedef.BOBA_CROSSCHAIN_ID)
-		if bobaConfig == nil {
-			panic("boba chain is invalid")
-		}
-		urls := bobaConfig.GetNodesUrl()
-		bobaSdk = chainsdk.NewEthereumSdkPro(urls, bobaConfig.ListenSlot, bobaConfig.ChainId)
-		sdkMap[basedef.BOBA_CROSSCHAIN_ID] = bobaSdk
-	}
-	if basedef.ENV == basedef.TESTNET {
-		{
-			rinkebyConfig := config.GetChainListenConfig(basedef.RINKEBY_CROSSCHAIN_ID)
-			if rinkebyConfig == nil {
-				panic("rinkeby chain is invalid")
+	sdkMap = make(map[uint64]ChainSdk, 0)
+	for _, chainConfig := range config.ChainListenConfig {
+		family := chainConfig.GetFamily()
+		factory, ok := sdkFactories[family]
+		if !ok {
+			// poly and o3 hold no tokens of their own
+			if family != basedef.FAMILY_POLY && family != basedef.FAMILY_O3 {
+				logs.Error("chain %d family %s has no sdk", chainConfig.ChainId, family)
 			}
-			urls := rinkebyConfig.GetNodesUrl()
-			rinkebySdk = chainsdk.NewEthereumSdkPro(urls, rinkebyConfig.ListenSlot, rinkebyConfig.ChainId)
-			sdkMap[basedef.RINKEBY_CROSSCHAIN_ID] = rinkebySdk
+			continue
 		}
-	}
-	{
-		chainConfig := config.GetChainListenConfig(basedef.OASIS_CROSSCHAIN_ID)
-		if chainConfig == nil {
-			panic("oasis chain is invalid")
+		if len(chainConfig.Nodes) == 0 {
+			logs.Error("Missing chain %d sdk nodes", chainConfig.ChainId)
+			continue
 		}
-		urls := chainConfig.GetNodesUrl()
-		oasisSdk = chainsdk.NewEthereumSdkPro(urls, chainConfig.ListenSlot, chainConfig.ChainId)
-		sdkMap[basedef.OASIS_CROSSCHAIN_ID] = oasisSdk
+		sdkMap[chainConfig.ChainId] = factory(chainConfig)
 	}
 }
 
 func GetBalance(chainId uint64, hash string) (*big.Int, error) {
-	maxBalance := big.NewInt(0)
-	maxFun := func(balance *big.Int) {
-		if balance.Cmp(maxBalance) > 0 {
-			maxBalance = balance
-		}
-	}
-	errMap := make(map[error]bool, 0)
-	if chainId == basedef.ETHEREUM_CROSSCHAIN_ID {
-		ethereumConfig := config.GetChainListenConfig(basedef.ETHEREUM_CROSSCHAIN_ID)
-		if ethereumConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range ethereumConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := ethereumSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.MATIC_CROSSCHAIN_ID {
-		maticConfig := config.GetChainListenConfig(basedef.MATIC_CROSSCHAIN_ID)
-		if maticConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range maticConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := maticSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.BSC_CROSSCHAIN_ID {
-		bscConfig := config.GetChainListenConfig(basedef.BSC_CROSSCHAIN_ID)
-		if bscConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range bscConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := bscSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.HECO_CROSSCHAIN_ID {
-		hecoConfig := config.GetChainListenConfig(basedef.HECO_CROSSCHAIN_ID)
-		if hecoConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range hecoConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := hecoSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.OK_CROSSCHAIN_ID {
-		okConfig := config.GetChainListenConfig(basedef.OK_CROSSCHAIN_ID)
-		if okConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range okConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := okSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.NEO_CROSSCHAIN_ID {
-		neoConfig := config.GetChainListenConfig(basedef.NEO_CROSSCHAIN_ID)
-		if neoConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range neoConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := neoSdk.Nep5Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.NEO3_CROSSCHAIN_ID {
-		neo3Config := config.GetChainListenConfig(basedef.NEO3_CROSSCHAIN_ID)
-		if neo3Config == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range neo3Config.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := neo3Sdk.Nep17Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.ONT_CROSSCHAIN_ID {
-		ontConfig := config.GetChainListenConfig(basedef.ONT_CROSSCHAIN_ID)
-		if ontConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range ontConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := ontologySdk.Oep4Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.ARBITRUM_CROSSCHAIN_ID {
-		arbitrumConfig := config.GetChainListenConfig(basedef.ARBITRUM_CROSSCHAIN_ID)
-		if arbitrumConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range arbitrumConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := arbitrumSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.XDAI_CROSSCHAIN_ID {
-		xdaiConfig := config.GetChainListenConfig(basedef.XDAI_CROSSCHAIN_ID)
-		if xdaiConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range xdaiConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := xdaiSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.ZILLIQA_CROSSCHAIN_ID {
-		zilliqaCfg := config.GetChainListenConfig(basedef.ZILLIQA_CROSSCHAIN_ID)
-		if zilliqaCfg == nil {
-			panic("zilliqa GetChainListenConfig chain is invalid")
-		}
-		for _, v := range zilliqaCfg.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := zilliqaSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.FANTOM_CROSSCHAIN_ID {
-		fantomConfig := config.GetChainListenConfig(basedef.FANTOM_CROSSCHAIN_ID)
-		if fantomConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range fantomConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := fantomSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.AVAX_CROSSCHAIN_ID {
-		avaxConfig := config.GetChainListenConfig(basedef.AVAX_CROSSCHAIN_ID)
-		if avaxConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range avaxConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := avaxSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.OPTIMISTIC_CROSSCHAIN_ID {
-		optimisticConfig := config.GetChainListenConfig(basedef.OPTIMISTIC_CROSSCHAIN_ID)
-		if optimisticConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range optimisticConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := optimisticSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.METIS_CROSSCHAIN_ID {
-		metisConfig := config.GetChainListenConfig(basedef.METIS_CROSSCHAIN_ID)
-		if metisConfig == nil {
-			panic("metis chain is invalid")
-		}
-		for _, v := range metisConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := metisSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
+	sdk, ok := sdkMap[chainId]
+	if !ok {
+		return new(big.Int).SetUint64(0), nil
 	}
-	if chainId == basedef.BOBA_CROSSCHAIN_ID {
-		bobaConfig := config.GetChainListenConfig(basedef.BOBA_CROSSCHAIN_ID)
-		if bobaConfig == nil {
-			panic("boba chain is invalid")
-		}
-		for _, v := range bobaConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := bobaSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
+	chainConfig := config.GetChainListenConfig(chainId)
+	if chainConfig == nil {
+		panic(fmt.Sprintf("chain %d is invalid", chainId))
 	}
-	if chainId == basedef.RINKEBY_CROSSCHAIN_ID {
-		rinkebyConfig := config.GetChainListenConfig(basedef.RINKEBY_CROSSCHAIN_ID)
-		if rinkebyConfig == nil {
-			panic("rinkeby chain is invalid")
-		}
-		for _, v := range rinkebyConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := rinkebySdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
-		}
-	}
-	if chainId == basedef.OASIS_CROSSCHAIN_ID {
-		chainConfig := config.GetChainListenConfig(basedef.OASIS_CROSSCHAIN_ID)
-		if chainConfig == nil {
-			panic("oasis chain is invalid")
+	maxBalance := big.NewInt(0)
+	errMap := make(map[error]bool, 0)
+	for _, v := range chainConfig.ProxyContract {
+		if len(strings.TrimSpace(v)) == 0 {
+			continue
 		}
-		for _, v := range chainConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) == 0 {
-				continue
-			}
-			balance, err := oasisSdk.Erc20Balance(hash, v)
-			maxFun(balance)
-			errMap[err] = true
+		balance, err := sdk.TokenBalance(hash, v)
+		if balance != nil && balance.Cmp(maxBalance) > 0 {
+			maxBalance = balance
 		}
+		errMap[err] = true
 	}
 	if maxBalance.Cmp(big.NewInt(0)) > 0 {
 		return maxBalance, nil
@@ -506,131 +193,15 @@ func GetBalance(chainId uint64, hash string) (*big.Int, error) {
 }
 
 func GetTotalSupply(chainId uint64, hash string) (*big.Int, error) {
-	if chainId == basedef.ETHEREUM_CROSSCHAIN_ID {
-		ethereumConfig := config.GetChainListenConfig(basedef.ETHEREUM_CROSSCHAIN_ID)
-		if ethereumConfig == nil {
-			panic("chain is invalid")
-		}
-		return ethereumSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.BSC_CROSSCHAIN_ID {
-		bscConfig := config.GetChainListenConfig(basedef.BSC_CROSSCHAIN_ID)
-		if bscConfig == nil {
-			panic("chain is invalid")
-		}
-		return bscSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.HECO_CROSSCHAIN_ID {
-		hecoConfig := config.GetChainListenConfig(basedef.HECO_CROSSCHAIN_ID)
-		if hecoConfig == nil {
-			panic("chain is invalid")
-		}
-		return hecoSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.OK_CROSSCHAIN_ID {
-		okConfig := config.GetChainListenConfig(basedef.OK_CROSSCHAIN_ID)
-		if okConfig == nil {
-			panic("chain is invalid")
-		}
-		return okSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.NEO_CROSSCHAIN_ID {
-		neoConfig := config.GetChainListenConfig(basedef.NEO_CROSSCHAIN_ID)
-		if neoConfig == nil {
-			panic("chain is invalid")
-		}
-		return neoSdk.Nep5TotalSupply(hash)
-	}
-	if chainId == basedef.NEO3_CROSSCHAIN_ID {
-		neo3Config := config.GetChainListenConfig(basedef.NEO3_CROSSCHAIN_ID)
-		if neo3Config == nil {
-			panic("chain is invalid")
-		}
-		return neo3Sdk.Nep17TotalSupply(hash)
-	}
-	if chainId == basedef.ONT_CROSSCHAIN_ID {
-		ontConfig := config.GetChainListenConfig(basedef.ONT_CROSSCHAIN_ID)
-		if ontConfig == nil {
-			panic("chain is invalid")
-		}
-		for _, v := range ontConfig.ProxyContract {
-			if len(strings.TrimSpace(v)) != 0 {
-				return ontologySdk.Oep4TotalSupply(hash, v)
-			}
-		}
-
-	}
-	if chainId == basedef.MATIC_CROSSCHAIN_ID {
-		maticConfig := config.GetChainListenConfig(basedef.MATIC_CROSSCHAIN_ID)
-		if maticConfig == nil {
-			panic("chain is invalid")
-		}
-		return maticSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.ARBITRUM_CROSSCHAIN_ID {
-		arbitrumConfig := config.GetChainListenConfig(basedef.ARBITRUM_CROSSCHAIN_ID)
-		if arbitrumConfig == nil {
-			panic("chain is invalid")
-		}
-		return arbitrumSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.XDAI_CROSSCHAIN_ID {
-		xdaiConfig := config.GetChainListenConfig(basedef.XDAI_CROSSCHAIN_ID)
-		if xdaiConfig == nil {
-			panic("chain is invalid")
-		}
-		return xdaiSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.FANTOM_CROSSCHAIN_ID {
-		fantomConfig := config.GetChainListenConfig(basedef.FANTOM_CROSSCHAIN_ID)
-		if fantomConfig == nil {
-			panic("chain is invalid")
-		}
-		return fantomSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.AVAX_CROSSCHAIN_ID {
-		avaxConfig := config.GetChainListenConfig(basedef.AVAX_CROSSCHAIN_ID)
-		if avaxConfig == nil {
-			panic("chain is invalid")
-		}
-		return avaxSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.OPTIMISTIC_CROSSCHAIN_ID {
-		optimisticConfig := config.GetChainListenConfig(basedef.OPTIMISTIC_CROSSCHAIN_ID)
-		if optimisticConfig == nil {
-			panic("chain is invalid")
-		}
-		return optimisticSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.METIS_CROSSCHAIN_ID {
-		metisConfig := config.GetChainListenConfig(basedef.METIS_CROSSCHAIN_ID)
-		if metisConfig == nil {
-			panic("metis chain GetTotalSupply invalid")
-		}
-		return metisSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.BOBA_CROSSCHAIN_ID {
-		bobaConfig := config.GetChainListenConfig(basedef.BOBA_CROSSCHAIN_ID)
-		if bobaConfig == nil {
-			panic("boba chain GetTotalSupply invalid")
-		}
-		return bobaSdk.Erc20TotalSupply(hash)
-	}
-	if chainId == basedef.RINKEBY_CROSSCHAIN_ID {
-		rinkebyConfig := config.GetChainListenConfig(basedef.RINKEBY_CROSSCHAIN_ID)
-		if rinkebyConfig == nil {
-			panic("rinkeby chain GetTotalSupply invalid")
-		}
-		return rinkebySdk.Erc20TotalSupply(hash)
+	sdk, ok := sdkMap[chainId]
+	if !ok {
+		return new(big.Int).SetUint64(0), nil
 	}
-	if chainId == basedef.OASIS_CROSSCHAIN_ID {
-		chainConfig := config.GetChainListenConfig(basedef.OASIS_CROSSCHAIN_ID)
-		if chainConfig == nil {
-			panic("oasis chain GetTotalSupply invalid")
-		}
-		return oasisSdk.Erc20TotalSupply(hash)
+	chainConfig := config.GetChainListenConfig(chainId)
+	if chainConfig == nil {
+		panic(fmt.Sprintf("chain %d is invalid", chainId))
 	}
-	return new(big.Int).SetUint64(0), nil
+	return sdk.TokenTotalSupply(hash, chainConfig.ProxyContract)
 }
 
 type ProxyBalance struct {
@@ -640,51 +211,16 @@ type ProxyBalance struct {
 }
 
 func GetProxyBalance(chainId uint64, hash string, proxy string) (*big.Int, error) {
-	switch chainId {
-	case basedef.ETHEREUM_CROSSCHAIN_ID:
-		return ethereumSdk.Erc20Balance(hash, proxy)
-	case basedef.MATIC_CROSSCHAIN_ID:
-		return maticSdk.Erc20Balance(hash, proxy)
-	case basedef.BSC_CROSSCHAIN_ID:
-		return bscSdk.Erc20Balance(hash, proxy)
-	case basedef.HECO_CROSSCHAIN_ID:
-		return hecoSdk.Erc20Balance(hash, proxy)
-	case basedef.OK_CROSSCHAIN_ID:
-		return okSdk.Erc20Balance(hash, proxy)
-	case basedef.NEO_CROSSCHAIN_ID:
-		return neoSdk.Nep5Balance(hash, proxy)
-	case basedef.NEO3_CROSSCHAIN_ID:
-		return neo3Sdk.Nep17Balance(hash, proxy)
-	case basedef.ONT_CROSSCHAIN_ID:
-		return ontologySdk.Oep4Balance(hash, proxy)
-	case basedef.ARBITRUM_CROSSCHAIN_ID:
-		return arbitrumSdk.Erc20Balance(hash, proxy)
-	case basedef.XDAI_CROSSCHAIN_ID:
-		return xdaiSdk.Erc20Balance(hash, proxy)
-	case basedef.ZILLIQA_CROSSCHAIN_ID:
-		return zilliqaSdk.Erc20Balance(hash, proxy)
-	case basedef.FANTOM_CROSSCHAIN_ID:
-		return fantomSdk.Erc20Balance(hash, proxy)
-	case basedef.AVAX_CROSSCHAIN_ID:
-		return avaxSdk.Erc20Balance(hash, proxy)
-	case basedef.OPTIMISTIC_CROSSCHAIN_ID:
-		return optimisticSdk.Erc20Balance(hash, proxy)
-	case basedef.METIS_CROSSCHAIN_ID:
-		return metisSdk.Erc20Balance(hash, proxy)
-	case basedef.BOBA_CROSSCHAIN_ID:
-		return bobaSdk.Erc20Balance(hash, proxy)
-	case basedef.RINKEBY_CROSSCHAIN_ID:
-		return rinkebySdk.Erc20Balance(hash, proxy)
-	case basedef.OASIS_CROSSCHAIN_ID:
-		return oasisSdk.Erc20Balance(hash, proxy)
-	default:
+	sdk, ok := sdkMap[chainId]
+	if !ok {
 		return new(big.Int).SetUint64(0), nil
 	}
+	return sdk.TokenBalance(hash, proxy)
 }
 
 func GetBoundLockProxy(lockProxies []string, srcTokenHash, DstTokenHash string, srcChainId, dstChainId uint64) (string, error) {
 	if sdk, exist := sdkMap[dstChainId]; exist {
-		if value, ok := sdk.(*chainsdk.EthereumSdkPro); ok {
+		if value, ok := sdk.(*ethereumChainSdk); ok {
 			return value.GetBoundLockProxy(lockProxies, srcTokenHash, DstTokenHash, srcChainId)
 		}
 	}
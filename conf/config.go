@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"poly-bridge/basedef"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/beego/beego/v2/core/logs"
@@ -28,7 +29,17 @@ import (
 )
 
 var GlobalConfig *Config
-var PolyProxy map[string]bool
+
+// polyProxy holds the map[string]bool of the proxy contracts, it is replaced
+// as a whole when the config is reloaded.
+var polyProxy atomic.Value
+
+// GetPolyProxy returns the upper case proxy contracts relayed by poly, the map
+// must not be modified.
+func GetPolyProxy() map[string]bool {
+	proxies, _ := polyProxy.Load().(map[string]bool)
+	return proxies
+}
 
 var (
 	ConfigPathFlag = cli.StringFlag{
@@ -44,11 +55,13 @@ func GetFlagName(flag cli.Flag) string {
 }
 
 type DBConfig struct {
-	URL      string
-	User     string
-	Password string
-	Scheme   string
-	Debug    bool
+	Driver      string // mysql, postgres or sqlite, mysql by default
+	URL         string // host:port, or the database file path for sqlite
+	User        string
+	Password    string
+	Scheme      string
+	Debug       bool
+	AutoMigrate bool // create or update the tables of models on connecting
 }
 
 type RedisConfig struct {
@@ -92,11 +105,18 @@ type ChainNodes struct {
 type ChainListenConfig struct {
 	ChainName          string
 	ChainId            uint64
+	Family             string // evm, neo, neo3, ontology, switcheo, zilliqa, o3 or poly, known chains have a default
 	ListenSlot         uint64
 	Defer              uint64
 	BatchSize          uint64
+	ReorgDepth         uint64 // blocks kept to detect chain reorganization, 64 by default
+	RequestsPerSecond  uint64 // request limit of each node, 0 means no limit
+	LogRange           uint64 // blocks queried by one eth_getLogs on evm chains, 0 scans block by block, less than ReorgDepth
+	Finality           string // instant, depth, finalized or l1, known chains have a default
+	FinalityDepth      uint64 // blocks on top of a transaction to be final with the depth finality, Defer by default
 	Nodes              []*Restful
 	ExtendNodes        []*Restful
+	WsNodes            []*Restful // websocket nodes to subscribe new blocks and events, polling only if empty
 	WrapperContract    []string
 	CCMContract        string
 	ProxyContract      []string
@@ -110,11 +130,19 @@ type ChainListenConfig struct {
 type HealthMonitorConfig struct {
 	ChainId        uint64
 	ChainName      string
+	Family         string
 	ChainNodes     *ChainNodes
 	CCMContract    string
 	RelayerAccount *RelayAccountConfig
 }
 
+func (cfg *HealthMonitorConfig) GetFamily() string {
+	if cfg.Family != "" {
+		return cfg.Family
+	}
+	return basedef.GetChainFamily(cfg.ChainId)
+}
+
 type RelayAccountConfig struct {
 	ChainName   string
 	ChainId     uint64
@@ -133,6 +161,34 @@ type RelayerConfig struct {
 	RelayAccountConfig []*RelayAccountConfig
 }
 
+func (cfg *ChainListenConfig) GetFamily() string {
+	if cfg.Family != "" {
+		return cfg.Family
+	}
+	return basedef.GetChainFamily(cfg.ChainId)
+}
+
+func (cfg *ChainListenConfig) GetFinality() string {
+	if cfg.Finality != "" {
+		return cfg.Finality
+	}
+	return basedef.GetChainFinality(cfg.ChainId)
+}
+
+func (cfg *ChainListenConfig) GetReorgDepth() uint64 {
+	if cfg.ReorgDepth != 0 {
+		return cfg.ReorgDepth
+	}
+	return basedef.DEFAULT_REORG_DEPTH
+}
+
+func (cfg *ChainListenConfig) GetFinalityDepth() uint64 {
+	if cfg.FinalityDepth != 0 {
+		return cfg.FinalityDepth
+	}
+	return cfg.Defer
+}
+
 func (cfg *ChainListenConfig) GetNodesUrl() []string {
 	urls := make([]string, 0)
 	for _, node := range cfg.Nodes {
@@ -149,6 +205,14 @@ func (cfg *ChainListenConfig) GetNodesKey() []string {
 	return keys
 }
 
+func (cfg *ChainListenConfig) GetWsNodesUrl() []string {
+	urls := make([]string, 0)
+	for _, node := range cfg.WsNodes {
+		urls = append(urls, node.Url)
+	}
+	return urls
+}
+
 func (cfg *ChainListenConfig) GetExtendNodesUrl() []string {
 	urls := make([]string, 0)
 	for _, node := range cfg.ExtendNodes {
@@ -168,6 +232,18 @@ func (cfg *ChainListenConfig) GetExtendNodesKey() []string {
 type CoinPriceListenConfig struct {
 	MarketName string
 	Nodes      []*Restful
+	Dex        *DexPriceConfig // prices from the pairs of an on chain dex, Nodes are the chain nodes
+}
+
+// DexPriceConfig prices the tokens by the uniswap v2 like pairs of a factory,
+// the names of the tokens of the market are their addresses on the chain.
+type DexPriceConfig struct {
+	ChainId      uint64
+	Factory      string
+	Stable       string   // usd stable coin priced 1, like USDT
+	Quotes       []string // tokens routed through to Stable when a token has no pair with it, like WETH
+	MinLiquidity float64  // min usd value of the reserve of the quote side of a pair, default 10000
+	TwapWindow   int64    // min seconds the prices are averaged over, default 1800
 }
 
 func (cfg *CoinPriceListenConfig) GetNodesUrl() []string {
@@ -189,11 +265,28 @@ func (cfg *CoinPriceListenConfig) GetNodesKey() []string {
 type FeeListenConfig struct {
 	ChainId       uint64
 	ChainName     string
+	Family        string
 	Nodes         []*Restful
 	ProxyFee      int64
 	MinFee        int64
 	GasLimit      int64
 	EthL1GasLimit int64
+	Eip1559       *Eip1559FeeConfig // estimate by eth_feeHistory, for the evm chains after the london fork
+}
+
+// Eip1559FeeConfig estimates the gas price of an evm chain by the base fees
+// and the priority fees of its latest blocks.
+type Eip1559FeeConfig struct {
+	HistoryBlocks    uint64  // blocks of eth_feeHistory, default 20
+	RewardPercentile float64 // percentile of the priority fees paid in a block, default 60
+	AheadBlocks      int     // blocks the base fee is projected ahead by its max increase, default 3
+}
+
+func (cfg *FeeListenConfig) GetFamily() string {
+	if cfg.Family != "" {
+		return cfg.Family
+	}
+	return basedef.GetChainFamily(cfg.ChainId)
 }
 
 func (cfg *FeeListenConfig) GetNodesUrl() []string {
@@ -225,6 +318,80 @@ type StatsConfig struct {
 	CensusAssetLinesInterval   int64 // CensusAssetLinesInterval interval in seconds
 }
 
+// ApiKeyConfig is a named client of the http api, Scopes are read, fee and
+// admin.
+type ApiKeyConfig struct {
+	Name   string
+	Key    string
+	Scopes []string
+	Rate   float64 // requests refilled per second, default 20
+	Burst  int64   // size of the token bucket, default 100
+	Quota  int64   // requests per day, 0 for no quota
+}
+
+type ApiAuthConfig struct {
+	Keys            []*ApiKeyConfig
+	AnonymousScopes []string // scopes of callers without a key, default read and fee
+	AnonymousRate   float64  // requests refilled per second for each anonymous ip, default 2
+	AnonymousBurst  int64    // default 20
+	TrustedProxies  []string // ips or cidrs of the proxies whose X-Forwarded-For gives the anonymous ip, empty to use the peer address
+}
+
+type ResponseCacheConfig struct {
+	TTL map[string]int64 // seconds the responses of a route are cached, routes not listed use their default, 0 disables the route
+}
+
+// FeeQuoteConfig enables the signed fee quotes of getfee, the check fee apis
+// accept a fee paying a quote in its validity.
+type FeeQuoteConfig struct {
+	Key       string // secret of the quote signatures
+	Validity  int64  // seconds a quote is valid, default 600
+	Retention int64  // seconds a quote is kept for the checks after it expires, default 86400
+}
+
+// PricePolicy is how the prices of the markets of a token basic are combined
+// into its price. An update breaking the policy is rejected and the last price
+// is kept.
+type PricePolicy struct {
+	Method       string             // mean (default), median or weighted_median
+	Weights      map[string]float64 // weights of the markets for weighted_median, 1 for the markets not listed
+	MaxDeviation float64            // max change from the last price as a ratio like 0.2, 0 is unbounded
+	MinSources   int                // min number of markets with a price, default 1
+	MaxAge       map[string]int64   // seconds the last price of a market is still used after it fails to update, 0 for the markets not listed
+}
+
+// PricePolicyConfig sets the price policies, Tokens overrides Default by
+// token basic name.
+type PricePolicyConfig struct {
+	Default *PricePolicy
+	Tokens  map[string]*PricePolicy
+}
+
+type WebhookConfig struct {
+	DeliverInterval  int64 // seconds between delivery rounds, default 5
+	StuckInterval    int64 // seconds between scans for wait and skip transactions, default 60
+	StuckWindow      int64 // only transactions of the last seconds are reported stuck, default 86400
+	MaxAttempts      int   // deliveries failing more times are moved to the dead letters, default 8
+	RetryInterval    int64 // seconds before the first retry, doubled on every attempt, default 10
+	MaxRetryInterval int64 // upper bound of the retry interval in seconds, default 3600
+	Timeout          int64 // seconds to wait for the receiver, default 10
+	MaxSubscriptions int64 // subscriptions of the same user, asset and server id, default 20
+	// AllowPrivateReceivers lets receivers be on private, loopback and link local
+	// addresses, only for deployments whose receivers are in the same network
+	AllowPrivateReceivers bool
+}
+
+func (cfg *WebhookConfig) GetMaxSubscriptions() int64 {
+	if cfg != nil && cfg.MaxSubscriptions != 0 {
+		return cfg.MaxSubscriptions
+	}
+	return basedef.DEFAULT_WEBHOOK_SUBSCRIPTIONS
+}
+
+func (cfg *WebhookConfig) GetAllowPrivateReceivers() bool {
+	return cfg != nil && cfg.AllowPrivateReceivers
+}
+
 type EventEffectConfig struct {
 	HowOld            int64
 	HowOld2           int64
@@ -236,6 +403,7 @@ type EventEffectConfig struct {
 type BotConfig struct {
 	DingUrl                      string
 	LargeTxDingUrl               string
+	PriceDingUrl                 string
 	NodeStatusDingUrl            string
 	RelayerAccountStatusDingUrl  string
 	CheckFrom                    int64
@@ -259,6 +427,13 @@ type HttpConfig struct {
 	Port    int
 }
 
+type GrpcConfig struct {
+	Address  string
+	Port     int
+	CertFile string // tls certificate of the server, plaintext when it is empty
+	KeyFile  string // private key of the tls certificate
+}
+
 type IPPortConfig struct {
 	WBTCIP string
 	USDTIP string
@@ -274,20 +449,27 @@ type Config struct {
 	LogFile               string
 	HttpConfig            *HttpConfig
 	MetricConfig          *HttpConfig
+	GrpcConfig            *GrpcConfig
 	ChainNodes            []*ChainNodes
 	ChainListenConfig     []*ChainListenConfig
 	CoinPriceUpdateSlot   int64
 	CoinPriceListenConfig []*CoinPriceListenConfig
+	PricePolicyConfig     *PricePolicyConfig
 	FeeUpdateSlot         int64
 	FeeListenConfig       []*FeeListenConfig
 	EventEffectConfig     *EventEffectConfig
 	StatsConfig           *StatsConfig
+	WebhookConfig         *WebhookConfig
+	ApiAuthConfig         *ApiAuthConfig
+	ResponseCacheConfig   *ResponseCacheConfig
+	FeeQuoteConfig        *FeeQuoteConfig
 	DBConfig              *DBConfig
 	BotConfig             *BotConfig
 	RedisConfig           *RedisConfig
 	IPPortConfig          *IPPortConfig
 	NftConfig             *NftConfig
 	RelayUrl              string
+	ShutdownTimeout       int64 // seconds to wait for services to drain on exit, default 30, the process exits with status 1 after it
 }
 
 func (cfg *Config) GetChainListenConfig(chainId uint64) *ChainListenConfig {
@@ -340,47 +522,60 @@ func NewConfig(filePath string) *Config {
 			listenConfig.Nodes = chainNode.Nodes
 			listenConfig.ExtendNodes = chainNode.ExtendNodes
 		}
+		// only the last block of a log range is recorded to detect reorgs, so
+		// one must be within the reorg depth
+		if reorgDepth := listenConfig.GetReorgDepth(); listenConfig.LogRange >= reorgDepth {
+			logs.Warn("NewServiceConfig: chain %d log range %d is not less than reorg depth %d, use %d",
+				listenConfig.ChainId, listenConfig.LogRange, reorgDepth, reorgDepth-1)
+			listenConfig.LogRange = reorgDepth - 1
+		}
 	}
 	for _, listenConfig := range config.FeeListenConfig {
 		if chainNode, ok := chainNodeMap[listenConfig.ChainId]; ok {
 			listenConfig.Nodes = chainNode.Nodes
 		}
+		if chainListenConfig := config.GetChainListenConfig(listenConfig.ChainId); chainListenConfig != nil && listenConfig.Family == "" {
+			listenConfig.Family = chainListenConfig.Family
+		}
 	}
 
+	proxies := newPolyProxy(config)
+	if len(proxies) == 0 {
+		// a reload keeps the running config
+		logs.Error("NewServiceConfig: failed, no proxy contract is configured")
+		return nil
+	}
+	proxies[""] = true
 	GlobalConfig = config
-	initPolyProxy()
+	polyProxy.Store(proxies)
+	logs.Info("init polyProxy:", proxies)
 	return config
 }
 
-func initPolyProxy() {
-	if len(PolyProxy) > 0 {
-		return
-	}
-	PolyProxy = make(map[string]bool, 0)
-	proxyConfigs := GlobalConfig.ChainListenConfig
+// newPolyProxy collects the proxy contracts of the config each time it is
+// loaded, so a reload picks up new proxy contracts.
+func newPolyProxy(config *Config) map[string]bool {
+	polyProxy := make(map[string]bool, 0)
+	proxyConfigs := config.ChainListenConfig
 	for _, v := range proxyConfigs {
 		//some chain only listen,don't need our relayer cross
 		if v.ChainId == basedef.SWITCHEO_CROSSCHAIN_ID || v.ChainId == basedef.ZILLIQA_CROSSCHAIN_ID {
 			continue
 		}
 		for _, proxy := range v.ProxyContract {
-			PolyProxy[strings.ToUpper(proxy)] = true
-			PolyProxy[strings.ToUpper(basedef.HexStringReverse(proxy))] = true
+			polyProxy[strings.ToUpper(proxy)] = true
+			polyProxy[strings.ToUpper(basedef.HexStringReverse(proxy))] = true
 		}
-		PolyProxy[strings.ToUpper(v.SwapContract)] = true
-		PolyProxy[strings.ToUpper(basedef.HexStringReverse(v.SwapContract))] = true
+		polyProxy[strings.ToUpper(v.SwapContract)] = true
+		polyProxy[strings.ToUpper(basedef.HexStringReverse(v.SwapContract))] = true
 		for _, contract := range v.NFTProxyContract {
-			PolyProxy[strings.ToUpper(contract)] = true
+			polyProxy[strings.ToUpper(contract)] = true
 		}
 		for _, contract := range v.NFTProxyContract {
-			PolyProxy[strings.ToUpper(basedef.HexStringReverse(contract))] = true
+			polyProxy[strings.ToUpper(basedef.HexStringReverse(contract))] = true
 		}
 	}
-	if len(PolyProxy) == 0 {
-		panic("init PolyProxy err,polyProxy is nil")
-	}
-	PolyProxy[""] = true
-	logs.Info("init polyProxy:", PolyProxy)
+	return polyProxy
 }
 
 type NftConfig struct {
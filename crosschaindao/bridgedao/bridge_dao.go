@@ -21,13 +21,14 @@ import (
 	"errors"
 	"fmt"
 	"github.com/beego/beego/v2/core/logs"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"math/big"
 	"poly-bridge/basedef"
+	"poly-bridge/cacheRedis"
 	"poly-bridge/conf"
 	serverconf "poly-bridge/conf"
+	"poly-bridge/database"
 	"poly-bridge/models"
 	"strings"
 	"time"
@@ -48,8 +49,7 @@ func NewBridgeDao(dbCfg *conf.DBConfig, backup bool) *BridgeDao {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -57,6 +57,12 @@ func NewBridgeDao(dbCfg *conf.DBConfig, backup bool) *BridgeDao {
 	return swapDao
 }
 
+// NewBridgeDaoWithDB returns the dao of a database opened already, so the
+// queries of the http and explorer services share their connections.
+func NewBridgeDaoWithDB(db *gorm.DB) *BridgeDao {
+	return &BridgeDao{db: db}
+}
+
 func (dao *BridgeDao) UpdateEvents(wrapperTransactions []*models.WrapperTransaction, srcTransactions []*models.SrcTransaction, polyTransactions []*models.PolyTransaction, dstTransactions []*models.DstTransaction) error {
 	if !dao.backup {
 		if wrapperTransactions != nil && len(wrapperTransactions) > 0 {
@@ -153,6 +159,43 @@ func (dao *BridgeDao) RemoveEvents(srcHashes []string, polyHashes []string, dstH
 	return nil
 }
 
+// GetEventHashes returns the hashes of the transactions of the chain indexed
+// above the height, the wrapper transactions are returned with the source ones.
+func (dao *BridgeDao) GetEventHashes(chainId uint64, height uint64) (srcHashes []string, polyHashes []string, dstHashes []string, err error) {
+	srcHashes, polyHashes, dstHashes = make([]string, 0), make([]string, 0), make([]string, 0)
+	wrapperHashes := make([]string, 0)
+	if err = dao.db.Model(&models.SrcTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("hash", &srcHashes).Error; err != nil {
+		return
+	}
+	if err = dao.db.Model(&models.WrapperTransaction{}).Where("src_chain_id = ? and block_height > ?", chainId, height).Pluck("hash", &wrapperHashes).Error; err != nil {
+		return
+	}
+	srcHashes = append(srcHashes, wrapperHashes...)
+	if err = dao.db.Model(&models.PolyTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("hash", &polyHashes).Error; err != nil {
+		return
+	}
+	err = dao.db.Model(&models.DstTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("hash", &dstHashes).Error
+	return
+}
+
+// UpdateConfirmations sets the confirmations of the transactions of the chain
+// in the blocks (start, finalized] which have none yet, latest is the chain
+// height.
+func (dao *BridgeDao) UpdateConfirmations(chainId uint64, start uint64, finalized uint64, latest uint64) error {
+	if dao.backup {
+		return nil
+	}
+	confirmations := gorm.Expr("? - height + 1", latest)
+	res := dao.db.Model(&models.SrcTransaction{}).Where("chain_id = ? and height > ? and height <= ? and confirmations = 0", chainId, start, finalized).
+		Update("confirmations", confirmations)
+	if res.Error != nil {
+		return res.Error
+	}
+	res = dao.db.Model(&models.DstTransaction{}).Where("chain_id = ? and height > ? and height <= ? and confirmations = 0", chainId, start, finalized).
+		Update("confirmations", confirmations)
+	return res.Error
+}
+
 func (dao *BridgeDao) GetChain(chainId uint64) (*models.Chain, error) {
 	chain := new(models.Chain)
 	res := dao.db.Where("chain_id = ?", chainId).First(chain)
@@ -228,6 +271,7 @@ func (dao *BridgeDao) AddTokens(tokens []*models.TokenBasic, tokenMaps []*models
 			return fmt.Errorf("add tokens map failed!")
 		}
 	}
+	cacheRedis.InvalidateResponses()
 	return nil
 }
 
@@ -260,10 +304,12 @@ func (dao *BridgeDao) RemoveTokenMaps(tokenMaps []*models.TokenMap) error {
 				tokenMap.SrcChainId, strings.ToLower(tokenMap.SrcTokenHash), tokenMap.DstChainId, strings.ToLower(tokenMap.DstTokenHash)).Delete(&models.TokenMap{})
 		*/
 	}
+	cacheRedis.InvalidateResponses()
 	return nil
 }
 
 func (dao *BridgeDao) RemoveTokens(tokens []string) error {
+	defer cacheRedis.InvalidateResponses()
 	for _, token := range tokens {
 		err := dao.RemoveToken(token)
 		if err != nil {
@@ -476,9 +522,12 @@ func (dao *BridgeDao) GetNewAssetSta() (*models.AssetStatistic, error) {
 		Error
 	return assetStatistic, err
 }
+
+// CalculateAssets sums the transfers of the token basic by token and hour, so
+// each sum can be valued at the price of its time.
 func (dao *BridgeDao) CalculateAssets(tokenBasicName string, lastId, nowId int64) ([]*models.AssetInfo, error) {
 	assetInfos := make([]*models.AssetInfo, 0)
-	err := dao.db.Debug().Raw("select CONVERT(sum(amount), DECIMAL(37, 0)) as amount, count(*) as txnum, b.token_basic_name, b.precision, c.price  from src_transfers a inner join tokens b on a.chain_id = b.chain_id and a.asset = b.hash left join token_basics c on c.name = b.token_basic_name where b.token_basic_name = ? and a.id > ? and a.id <= ? group by b.chain_id,b.`hash`", tokenBasicName, lastId, nowId).
+	err := dao.db.Debug().Raw("select CONVERT(sum(amount), DECIMAL(37, 0)) as amount, count(*) as txnum, min(a.time) as time, b.token_basic_name, b.precision, c.price  from src_transfers a inner join tokens b on a.chain_id = b.chain_id and a.asset = b.hash left join token_basics c on c.name = b.token_basic_name where b.token_basic_name = ? and a.id > ? and a.id <= ? group by b.chain_id,b.`hash`,a.time - a.time % 3600", tokenBasicName, lastId, nowId).
 		Find(&assetInfos).Error
 	return assetInfos, err
 }
@@ -524,6 +573,56 @@ func (dao *BridgeDao) GetTokenBasicByHash(chainId uint64, hash string) (*models.
 	return token, err
 }
 
+// GetTokenPriceAt returns the price of the token basic at t, ok is false when
+// it has no price kept.
+func (dao *BridgeDao) GetTokenPriceAt(name string, t int64) (price int64, ok bool, err error) {
+	histories, err := dao.GetTokenPriceHistory(name, t, t)
+	if err != nil {
+		return 0, false, err
+	}
+	price, ok = models.TokenPriceAt(histories, t)
+	return price, ok, nil
+}
+
+// GetTokenPriceHistory returns the prices of the token basic from from to to
+// sorted by time, with the last one before from and the first one after to so
+// the prices at both ends can be interpolated.
+func (dao *BridgeDao) GetTokenPriceHistory(name string, from, to int64) ([]*models.TokenPriceHistory, error) {
+	before := make([]*models.TokenPriceHistory, 0, 1)
+	err := dao.db.Where("token_basic_name = ? and time < ?", name, from).Order("time desc").Limit(1).Find(&before).Error
+	if err != nil {
+		return nil, err
+	}
+	histories := make([]*models.TokenPriceHistory, 0)
+	err = dao.db.Where("token_basic_name = ? and time >= ? and time <= ?", name, from, to).Order("time").Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+	after := make([]*models.TokenPriceHistory, 0, 1)
+	err = dao.db.Where("token_basic_name = ? and time > ?", name, to).Order("time").Limit(1).Find(&after).Error
+	if err != nil {
+		return nil, err
+	}
+	return append(append(before, histories...), after...), nil
+}
+
+// GetTokenPriceSamples returns the prices of the token basics from from to
+// to, see GetTokenPriceHistory.
+func (dao *BridgeDao) GetTokenPriceSamples(names []string, from, to int64) (models.TokenPriceSamples, error) {
+	samples := make(models.TokenPriceSamples)
+	for _, name := range names {
+		if _, ok := samples[name]; ok {
+			continue
+		}
+		histories, err := dao.GetTokenPriceHistory(name, from, to)
+		if err != nil {
+			return nil, err
+		}
+		samples[name] = histories
+	}
+	return samples, nil
+}
+
 func (dao *BridgeDao) GetDstTransactionByHash(hash string) (*models.DstTransaction, error) {
 	dstTransaction := new(models.DstTransaction)
 	res := dao.db.Where("hash = ?", hash).First(dstTransaction)
@@ -588,7 +687,7 @@ func (dao *BridgeDao) FilterMissingWrapperTransactions() ([]*models.SrcTransacti
 	ignoreDstChainIds := []uint64{basedef.SWITCHEO_CROSSCHAIN_ID}
 
 	var polyProxies []string
-	for k, _ := range conf.PolyProxy {
+	for k, _ := range conf.GetPolyProxy() {
 		polyProxies = append(polyProxies, k)
 	}
 
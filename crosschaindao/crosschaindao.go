@@ -31,10 +31,13 @@ import (
 type CrossChainDao interface {
 	UpdateEvents(wrapperTransactions []*models.WrapperTransaction, srcTransactions []*models.SrcTransaction, polyTransactions []*models.PolyTransaction, dstTransactions []*models.DstTransaction) error
 	RemoveEvents(srcHashes []string, polyHashes []string, dstHashes []string) error
+	GetEventHashes(chainId uint64, height uint64) (srcHashes []string, polyHashes []string, dstHashes []string, err error)
 	GetChain(chainId uint64) (*models.Chain, error)
 	GetTokenBasicByHash(chainId uint64, hash string) (*models.Token, error)
+	GetTokenPriceAt(name string, t int64) (int64, bool, error)
 	GetDstTransactionByHash(hash string) (*models.DstTransaction, error)
 	UpdateChain(chain *models.Chain) error
+	UpdateConfirmations(chainId uint64, start uint64, finalized uint64, latest uint64) error
 	AddChains(chain []*models.Chain, chainFees []*models.ChainFee) error
 	AddTokens(tokens []*models.TokenBasic, tokenMaps []*models.TokenMap, servercfg *serverconf.Config) error
 	RemoveTokens(tokens []string) error
@@ -20,12 +20,12 @@ package explorerdao
 import (
 	"encoding/json"
 	"fmt"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"poly-bridge/basedef"
 	"poly-bridge/conf"
 	serverconf "poly-bridge/conf"
+	"poly-bridge/database"
 	"poly-bridge/models"
 )
 
@@ -170,8 +170,7 @@ func NewExplorerDao(dbCfg *conf.DBConfig, backup bool) *ExplorerDao {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -259,6 +258,24 @@ func (dao *ExplorerDao) RemoveEvents(srcHashes []string, polyHashes []string, ds
 	return nil
 }
 
+// GetEventHashes returns the hashes of the transactions of the chain indexed
+// above the height.
+func (dao *ExplorerDao) GetEventHashes(chainId uint64, height uint64) (srcHashes []string, polyHashes []string, dstHashes []string, err error) {
+	srcHashes, polyHashes, dstHashes = make([]string, 0), make([]string, 0), make([]string, 0)
+	if err = dao.db.Model(&SrcTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("txhash", &srcHashes).Error; err != nil {
+		return
+	}
+	if err = dao.db.Model(&PolyTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("txhash", &polyHashes).Error; err != nil {
+		return
+	}
+	err = dao.db.Model(&DstTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("txhash", &dstHashes).Error
+	return
+}
+
+func (dao *ExplorerDao) UpdateConfirmations(chainId uint64, start uint64, finalized uint64, latest uint64) error {
+	return nil
+}
+
 func (dao *ExplorerDao) GetChain(chainId uint64) (*models.Chain, error) {
 	chain := new(Chain)
 	res := dao.db.Where("id = ?", chainId).First(chain)
@@ -401,6 +418,10 @@ func (dao *ExplorerDao) GetTokenBasicByHash(chainId uint64, hash string) (*model
 	return nil, nil
 }
 
+func (dao *ExplorerDao) GetTokenPriceAt(name string, t int64) (int64, bool, error) {
+	return 0, false, nil
+}
+
 func (dao *ExplorerDao) GetDstTransactionByHash(hash string) (*models.DstTransaction, error) {
 	return nil, nil
 }
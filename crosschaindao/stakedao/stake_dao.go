@@ -90,10 +90,22 @@ func (dao *StakeDao) RemoveEvents(srcHashes []string, polyHashes []string, dstHa
 	return nil
 }
 
+func (dao *StakeDao) GetEventHashes(chainId uint64, height uint64) (srcHashes []string, polyHashes []string, dstHashes []string, err error) {
+	return nil, nil, nil, nil
+}
+
+func (dao *StakeDao) UpdateConfirmations(chainId uint64, start uint64, finalized uint64, latest uint64) error {
+	return nil
+}
+
 func (dao *StakeDao) GetTokenBasicByHash(chainId uint64, hash string) (*models.Token, error) {
 	return nil, nil
 }
 
+func (dao *StakeDao) GetTokenPriceAt(name string, t int64) (int64, bool, error) {
+	return 0, false, nil
+}
+
 func (dao *StakeDao) GetDstTransactionByHash(hash string) (*models.DstTransaction, error) {
 	return nil, nil
 }
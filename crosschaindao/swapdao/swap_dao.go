@@ -19,7 +19,6 @@ package swapdao
 
 import (
 	"fmt"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"math/big"
@@ -27,6 +26,7 @@ import (
 	"poly-bridge/coinpricelisten/coinmarketcap"
 	"poly-bridge/conf"
 	serverconf "poly-bridge/conf"
+	"poly-bridge/database"
 	"poly-bridge/models"
 	"strings"
 	"time"
@@ -47,8 +47,7 @@ func NewSwapDao(dbCfg *conf.DBConfig, backup bool) *SwapDao {
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -127,6 +126,43 @@ func (dao *SwapDao) RemoveEvents(srcHashes []string, polyHashes []string, dstHas
 	return nil
 }
 
+// GetEventHashes returns the hashes of the transactions of the chain indexed
+// above the height, the wrapper transactions are returned with the source ones.
+func (dao *SwapDao) GetEventHashes(chainId uint64, height uint64) (srcHashes []string, polyHashes []string, dstHashes []string, err error) {
+	srcHashes, polyHashes, dstHashes = make([]string, 0), make([]string, 0), make([]string, 0)
+	wrapperHashes := make([]string, 0)
+	if err = dao.db.Model(&models.SrcTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("hash", &srcHashes).Error; err != nil {
+		return
+	}
+	if err = dao.db.Model(&models.WrapperTransaction{}).Where("src_chain_id = ? and block_height > ?", chainId, height).Pluck("hash", &wrapperHashes).Error; err != nil {
+		return
+	}
+	srcHashes = append(srcHashes, wrapperHashes...)
+	if err = dao.db.Model(&models.PolyTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("hash", &polyHashes).Error; err != nil {
+		return
+	}
+	err = dao.db.Model(&models.DstTransaction{}).Where("chain_id = ? and height > ?", chainId, height).Pluck("hash", &dstHashes).Error
+	return
+}
+
+// UpdateConfirmations sets the confirmations of the transactions of the chain
+// in the blocks (start, finalized] which have none yet, latest is the chain
+// height.
+func (dao *SwapDao) UpdateConfirmations(chainId uint64, start uint64, finalized uint64, latest uint64) error {
+	if dao.backup {
+		return nil
+	}
+	confirmations := gorm.Expr("? - height + 1", latest)
+	res := dao.db.Model(&models.SrcTransaction{}).Where("chain_id = ? and height > ? and height <= ? and confirmations = 0", chainId, start, finalized).
+		Update("confirmations", confirmations)
+	if res.Error != nil {
+		return res.Error
+	}
+	res = dao.db.Model(&models.DstTransaction{}).Where("chain_id = ? and height > ? and height <= ? and confirmations = 0", chainId, start, finalized).
+		Update("confirmations", confirmations)
+	return res.Error
+}
+
 func (dao *SwapDao) GetChain(chainId uint64) (*models.Chain, error) {
 	chain := new(models.Chain)
 	res := dao.db.Where("chain_id = ?", chainId).First(chain)
@@ -339,6 +375,10 @@ func (dao *SwapDao) GetTokenBasicByHash(chainId uint64, hash string) (*models.To
 	return nil, nil
 }
 
+func (dao *SwapDao) GetTokenPriceAt(name string, t int64) (int64, bool, error) {
+	return 0, false, nil
+}
+
 func (dao *SwapDao) GetDstTransactionByHash(hash string) (*models.DstTransaction, error) {
 	return nil, nil
 }
@@ -27,9 +27,9 @@ import (
 	"time"
 
 	"github.com/beego/beego/v2/core/logs"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"poly-bridge/database"
 )
 
 var checkTime int = 0
@@ -57,8 +57,7 @@ func NewBridgeEffect(cfg *conf.EventEffectConfig, dbCfg *conf.DBConfig, redisCfg
 	if dbCfg.Debug == true {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	db, err := gorm.Open(mysql.Open(dbCfg.User+":"+dbCfg.Password+"@tcp("+dbCfg.URL+")/"+
-		dbCfg.Scheme+"?charset=utf8"), &gorm.Config{Logger: Logger})
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -200,9 +199,11 @@ func (eff *BridgeEffect) updateStatus() error {
 	for {
 		wrapperPolyDstRelations := make([]*models.SrcPolyDstRelation, 0)
 		wrapperTransactions := make([]*models.WrapperTransaction, 0)
+		statusEvents := make([]*models.TransactionStatusEvent, 0)
 		eff.db.Table("wrapper_transactions").Where("wrapper_transactions.status != ? and wrapper_transactions.time > 1622476800", basedef.STATE_FINISHED).Select("wrapper_transactions.hash as src_hash, poly_transactions.hash as poly_hash, dst_transactions.hash as dst_hash").Joins("left join poly_transactions on wrapper_transactions.hash = poly_transactions.src_hash").Joins("left join dst_transactions on poly_transactions.hash = dst_transactions.poly_hash").Preload("WrapperTransaction").Preload("DstTransaction").Limit(batch).Offset(batch * index).Order("wrapper_transactions.time desc").Find(&wrapperPolyDstRelations)
 		for _, wrapperPolyDstRelation := range wrapperPolyDstRelations {
 			wrapperTransaction := wrapperPolyDstRelation.WrapperTransaction
+			oldStatus := wrapperTransaction.Status
 			pending := wrapperTransaction.Status == basedef.STATE_SKIP || wrapperTransaction.Status == basedef.STATE_WAIT
 			if wrapperPolyDstRelation.PolyHash == "" {
 				chain, ok := id2Chains[wrapperPolyDstRelation.WrapperTransaction.SrcChainId]
@@ -231,10 +232,28 @@ func (eff *BridgeEffect) updateStatus() error {
 			}
 			if !pending || wrapperTransaction.Status == basedef.STATE_FINISHED {
 				wrapperTransactions = append(wrapperTransactions, wrapperTransaction)
+				if wrapperTransaction.Status != oldStatus {
+					statusEvents = append(statusEvents, &models.TransactionStatusEvent{
+						Hash:       wrapperTransaction.Hash,
+						PolyHash:   wrapperPolyDstRelation.PolyHash,
+						DstHash:    wrapperPolyDstRelation.DstHash,
+						User:       wrapperTransaction.User,
+						DstUser:    wrapperTransaction.DstUser,
+						SrcChainId: wrapperTransaction.SrcChainId,
+						DstChainId: wrapperTransaction.DstChainId,
+						Status:     wrapperTransaction.Status,
+						Time:       uint64(time.Now().Unix()),
+					})
+				}
 			}
 		}
 		if len(wrapperTransactions) > 0 {
-			eff.db.Save(wrapperTransactions)
+			res := eff.db.Save(wrapperTransactions)
+			if res.Error == nil && len(statusEvents) > 0 {
+				if err := eff.redis.PublishTransactionStatus(statusEvents); err != nil {
+					logs.Error("publish transaction status err: %v", err)
+				}
+			}
 		}
 		if len(wrapperPolyDstRelations) == 0 {
 			break
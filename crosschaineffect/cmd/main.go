@@ -18,6 +18,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/beego/beego/v2/core/logs"
@@ -106,7 +107,7 @@ func startServer(ctx *cli.Context) {
 		logs.Info("%s\n", string(conf))
 	}
 	common.SetupChainsSDK(config)
-	crosschaineffect.StartCrossChainEffect(config.Server, config.EventEffectConfig, config.DBConfig, config.RedisConfig)
+	crosschaineffect.StartCrossChainEffect(context.Background(), config.Server, config.EventEffectConfig, config.DBConfig, config.RedisConfig)
 }
 
 func waitSignal() os.Signal {
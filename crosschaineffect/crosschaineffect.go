@@ -18,6 +18,7 @@
 package crosschaineffect
 
 import (
+	"context"
 	"github.com/beego/beego/v2/core/logs"
 	"poly-bridge/basedef"
 	"poly-bridge/conf"
@@ -25,6 +26,7 @@ import (
 	"poly-bridge/crosschaineffect/explorereffect"
 	"poly-bridge/crosschaineffect/swapeffect"
 	"runtime/debug"
+	"sync"
 	"time"
 )
 
@@ -36,18 +38,19 @@ type Effect interface {
 
 var crossChainEffect *CrossChainEffect
 
-func StartCrossChainEffect(server string, effCfg *conf.EventEffectConfig, dbCfg *conf.DBConfig, redisCfg *conf.RedisConfig) {
+func StartCrossChainEffect(ctx context.Context, server string, effCfg *conf.EventEffectConfig, dbCfg *conf.DBConfig, redisCfg *conf.RedisConfig) {
 	effect := NewEffect(server, effCfg, dbCfg, redisCfg)
 	if effect == nil {
 		panic("effect is not valid")
 	}
 	crossChainEffect = NewCrossChainEffect(effect)
-	crossChainEffect.Start()
+	crossChainEffect.Start(ctx)
 }
 
 func StopCrossChainEffect() {
 	if crossChainEffect != nil {
 		crossChainEffect.Stop()
+		crossChainEffect = nil
 	}
 }
 
@@ -65,35 +68,46 @@ func NewEffect(server string, effCfg *conf.EventEffectConfig, dbCfg *conf.DBConf
 
 type CrossChainEffect struct {
 	effect Effect
-	exit   chan bool
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 func NewCrossChainEffect(monitor Effect) *CrossChainEffect {
 	crossChainMonitor := &CrossChainEffect{
 		effect: monitor,
-		exit:   make(chan bool, 0),
 	}
 	return crossChainMonitor
 }
 
-func (eff *CrossChainEffect) Start() {
+func (eff *CrossChainEffect) Start(ctx context.Context) {
 	logs.Info("start cross chain effect.")
+	eff.ctx, eff.cancel = context.WithCancel(ctx)
+	eff.wg.Add(1)
 	go eff.Check()
 }
 
 func (eff *CrossChainEffect) Stop() {
-	eff.exit <- true
+	if eff.cancel == nil {
+		return
+	}
+	eff.cancel()
+	eff.wg.Wait()
 	logs.Info("stop cross chain effect.")
 }
 
 func (eff *CrossChainEffect) Check() {
+	defer eff.wg.Done()
 	for {
 		exit := eff.check()
 		if exit {
-			close(eff.exit)
 			break
 		}
-		time.Sleep(time.Second * 5)
+		select {
+		case <-eff.ctx.Done():
+			return
+		case <-time.After(time.Second * 5):
+		}
 	}
 }
 
@@ -106,6 +120,7 @@ func (eff *CrossChainEffect) check() (exit bool) {
 	}()
 	logs.Debug("cross chain effect, server: %s......", eff.effect.Name())
 	ticker := time.NewTicker(time.Second * time.Duration(eff.effect.GetEffectSlot()))
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
@@ -113,7 +128,7 @@ func (eff *CrossChainEffect) check() (exit bool) {
 			if err != nil {
 				logs.Error("cross chain effect err: %v", err)
 			}
-		case <-eff.exit:
+		case <-eff.ctx.Done():
 			logs.Info("cross chain effect exit, server: %s......", eff.effect.Name())
 			return true
 		}
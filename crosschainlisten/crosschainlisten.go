@@ -18,12 +18,13 @@
 package crosschainlisten
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"poly-bridge/cacheRedis"
 	"poly-bridge/common"
-	"poly-bridge/crosschainlisten/zilliqalisten"
 	"poly-bridge/utils/decimal"
+	"reflect"
 	"runtime/debug"
 	"strconv"
 	"strings"
@@ -34,43 +35,104 @@ import (
 	"poly-bridge/basedef"
 	"poly-bridge/conf"
 	"poly-bridge/crosschaindao"
-	"poly-bridge/crosschainlisten/ethereumlisten"
-	"poly-bridge/crosschainlisten/neo3listen"
-	"poly-bridge/crosschainlisten/neolisten"
-	"poly-bridge/crosschainlisten/o3listen"
-	"poly-bridge/crosschainlisten/ontologylisten"
-	"poly-bridge/crosschainlisten/polylisten"
-	"poly-bridge/crosschainlisten/switcheolisten"
 	"poly-bridge/models"
 
 	"github.com/beego/beego/v2/core/logs"
 )
 
-var chainListens = make([]*CrossChainListen, 0)
+var (
+	chainListens       = make(map[uint64]*CrossChainListen)
+	chainListenConfigs = make(map[uint64]*conf.ChainListenConfig)
+	chainListenCtx     context.Context
+	chainListenDao     crosschaindao.CrossChainDao
+	chainListenMutex   sync.Mutex
+)
 
-func StartCrossChainListen(config *conf.Config) {
+func StartCrossChainListen(ctx context.Context, config *conf.Config) {
 	dao := crosschaindao.NewCrossChainDao(config.Server, config.Backup, config.DBConfig)
 	if dao == nil {
 		panic("server is not valid")
 	}
+	chainListenMutex.Lock()
+	defer chainListenMutex.Unlock()
+	chainListenCtx = ctx
+	chainListenDao = dao
+	for _, cfg := range config.ChainListenConfig {
+		startChainListen(cfg, config)
+	}
+}
+
+// ReloadCrossChainListen compares the chain listen configs with the running
+// ones. Listens of removed or changed chains are stopped, and changed or new
+// chains are started again from the height stored in the database.
+func ReloadCrossChainListen(config *conf.Config) {
+	chainListenMutex.Lock()
+	defer chainListenMutex.Unlock()
+	if chainListenDao == nil {
+		logs.Error("reload cross chain listen before it is started")
+		return
+	}
+	newConfigs := make(map[uint64]*conf.ChainListenConfig)
 	for _, cfg := range config.ChainListenConfig {
-		chainHandle := NewChainHandle(cfg)
-		if chainHandle == nil {
-			logs.Error("chain %d handler is invalid", cfg.ChainId)
+		newConfigs[cfg.ChainId] = cfg
+	}
+	stopped := make([]*CrossChainListen, 0)
+	for chainId, cfg := range chainListenConfigs {
+		if newCfg, ok := newConfigs[chainId]; ok && reflect.DeepEqual(cfg, newCfg) {
 			continue
 		}
-		chainListen := NewCrossChainListen(chainHandle, dao, config)
-		chainListen.Start()
-		chainListens = append(chainListens, chainListen)
+		logs.Info("chain %d listen config is changed or removed", chainId)
+		stopped = append(stopped, chainListens[chainId])
+		delete(chainListens, chainId)
+		delete(chainListenConfigs, chainId)
 	}
+	stopChainListens(stopped)
+	for _, cfg := range config.ChainListenConfig {
+		if _, ok := chainListenConfigs[cfg.ChainId]; !ok {
+			startChainListen(cfg, config)
+		}
+	}
+}
+
+func startChainListen(cfg *conf.ChainListenConfig, config *conf.Config) {
+	chainHandle := NewChainHandle(cfg)
+	if chainHandle == nil {
+		logs.Error("chain %d handler is invalid", cfg.ChainId)
+		return
+	}
+	chainListen := NewCrossChainListen(chainHandle, chainListenDao, config)
+	chainListen.Start(chainListenCtx)
+	chainListens[cfg.ChainId] = chainListen
+	chainListenConfigs[cfg.ChainId] = cfg
 }
 
+// StopCrossChainListen stops all chain listens at the same time and waits
+// for the blocks in flight to be saved.
 func StopCrossChainListen() {
+	chainListenMutex.Lock()
+	defer chainListenMutex.Unlock()
+	stopped := make([]*CrossChainListen, 0, len(chainListens))
 	for _, chainListen := range chainListens {
+		stopped = append(stopped, chainListen)
+	}
+	stopChainListens(stopped)
+	chainListens = make(map[uint64]*CrossChainListen)
+	chainListenConfigs = make(map[uint64]*conf.ChainListenConfig)
+	chainListenDao = nil
+}
+
+func stopChainListens(listens []*CrossChainListen) {
+	wg := new(sync.WaitGroup)
+	for _, chainListen := range listens {
 		if chainListen != nil {
-			chainListen.Stop()
+			wg.Add(1)
+			go func(chainListen *CrossChainListen) {
+				defer wg.Done()
+				chainListen.Stop()
+			}(chainListen)
 		}
 	}
+	wg.Wait()
 }
 
 type ChainHandle interface {
@@ -85,47 +147,36 @@ type ChainHandle interface {
 }
 
 func NewChainHandle(chainListenConfig *conf.ChainListenConfig) ChainHandle {
-	switch chainListenConfig.ChainId {
-	case basedef.POLY_CROSSCHAIN_ID:
-		return polylisten.NewPolyChainListen(chainListenConfig)
-	case basedef.ETHEREUM_CROSSCHAIN_ID, basedef.BSC_CROSSCHAIN_ID, basedef.PLT_CROSSCHAIN_ID, basedef.OK_CROSSCHAIN_ID,
-		basedef.HECO_CROSSCHAIN_ID, basedef.MATIC_CROSSCHAIN_ID, basedef.ARBITRUM_CROSSCHAIN_ID, basedef.XDAI_CROSSCHAIN_ID,
-		basedef.FANTOM_CROSSCHAIN_ID, basedef.AVAX_CROSSCHAIN_ID, basedef.OPTIMISTIC_CROSSCHAIN_ID, basedef.METIS_CROSSCHAIN_ID,
-		basedef.BOBA_CROSSCHAIN_ID, basedef.RINKEBY_CROSSCHAIN_ID, basedef.OASIS_CROSSCHAIN_ID:
-		return ethereumlisten.NewEthereumChainListen(chainListenConfig)
-	case basedef.NEO_CROSSCHAIN_ID:
-		return neolisten.NewNeoChainListen(chainListenConfig)
-	case basedef.ONT_CROSSCHAIN_ID:
-		return ontologylisten.NewOntologyChainListen(chainListenConfig)
-	case basedef.O3_CROSSCHAIN_ID:
-		return o3listen.NewO3ChainListen(chainListenConfig)
-	case basedef.SWITCHEO_CROSSCHAIN_ID:
-		return switcheolisten.NewSwitcheoChainListen(chainListenConfig)
-	case basedef.NEO3_CROSSCHAIN_ID:
-		return neo3listen.NewNeo3ChainListen(chainListenConfig)
-	case basedef.ZILLIQA_CROSSCHAIN_ID:
-		return zilliqalisten.NewZilliqaChainListen(chainListenConfig)
-
-	default:
+	factory, ok := chainHandleFactories[chainListenConfig.GetFamily()]
+	if !ok {
 		return nil
 	}
+	return factory(chainListenConfig)
 }
 
 type CrossChainListen struct {
 	handle  ChainHandle
 	db      crosschaindao.CrossChainDao
-	exit    chan bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
 	height  uint64
 	config  *conf.Config
 	dingMux sync.Mutex
+	blocks  *blockTracker
+	// finalityFallback is set while the node rejects the l1 finality
+	finalityFallback bool
 }
 
 func NewCrossChainListen(handle ChainHandle, db crosschaindao.CrossChainDao, config *conf.Config) *CrossChainListen {
 	crossChainListen := &CrossChainListen{
 		handle: handle,
 		db:     db,
-		exit:   make(chan bool, 0),
 		config: config,
+		blocks: newBlockTracker(0),
+	}
+	if listenConfig := config.GetChainListenConfig(handle.GetChainId()); listenConfig != nil {
+		crossChainListen.blocks = newBlockTracker(listenConfig.GetReorgDepth())
 	}
 	return crossChainListen
 }
@@ -134,27 +185,38 @@ func (ccl *CrossChainListen) SetHeight(height uint64) {
 	ccl.height = height
 }
 
-func (ccl *CrossChainListen) Start() {
+func (ccl *CrossChainListen) Start(ctx context.Context) {
 	if ccl.config.Backup && ccl.handle.GetChainId() == basedef.POLY_CROSSCHAIN_ID {
 		return
 	}
 	logs.Info("start cross chain listen: %s", ccl.handle.GetChainName())
+	ccl.ctx, ccl.cancel = context.WithCancel(ctx)
+	ccl.wg.Add(1)
 	go ccl.ListenChain()
 }
 
+// Stop cancels the listen and waits until the blocks in flight are saved.
 func (ccl *CrossChainListen) Stop() {
-	ccl.exit <- true
+	if ccl.cancel == nil {
+		return
+	}
+	ccl.cancel()
+	ccl.wg.Wait()
 	logs.Info("stop cross chain listen: %s", ccl.handle.GetChainName())
 }
 
 func (ccl *CrossChainListen) ListenChain() {
+	defer ccl.wg.Done()
 	for {
 		exit := ccl.listenChain()
 		if exit {
-			close(ccl.exit)
 			break
 		}
-		time.Sleep(time.Second * 5)
+		select {
+		case <-ccl.ctx.Done():
+			return
+		case <-time.After(time.Second * 5):
+		}
 	}
 }
 
@@ -192,101 +254,101 @@ func (ccl *CrossChainListen) listenChain() (exit bool) {
 	}
 	if ccl.config.Backup {
 		chain.Height -= ccl.handle.GetDefer()
+	} else {
+		ccl.seedBlocks(chain.Height)
 	}
 	logs.Info("cross chain listen, chain: %s, dao: %s......", ccl.handle.GetChainName(), ccl.db.Name())
 	ticker := time.NewTicker(time.Second * time.Duration(ccl.handle.GetChainListenSlot()))
+	defer ticker.Stop()
+	ctx, cancel := context.WithCancel(ccl.ctx)
+	defer cancel()
+	subscribeHandle, subscribe := ccl.handle.(ChainSubscribeHandle)
+	subscribe = subscribe && !ccl.config.Backup
+	var heights <-chan uint64
+	if subscribe {
+		heights = ccl.subscribe(ctx, subscribeHandle)
+	}
 	for {
 		select {
 		case <-ticker.C:
-			if ccl.config.Backup {
-				dbchain, err := ccl.db.GetChain(chain.ChainId)
-				if err != nil {
-					continue
-				}
-				height = dbchain.Height
-				if chain.Height >= height-ccl.handle.GetDefer() {
-					continue
-				}
-				logs.Info("backup ListenChain - chain %s db height is %d, listen height: %d", ccl.handle.GetChainName(), height, chain.Height)
-			} else {
-				height, err = ccl.handle.GetLatestHeight()
-				if err != nil || height == 0 || height == math.MaxUint64 {
-					logs.Error("listenChain - cannot get chain %s height, err: %s", ccl.handle.GetChainName(), err)
-					continue
-				}
-				extendHeight, err := ccl.handle.GetExtendLatestHeight()
-				if err != nil || extendHeight == 0 {
-					logs.Error("ListenChain - cannot get chain %s extend height, err: %s", ccl.handle.GetChainName(), err)
-				} else if extendHeight >= height+21 {
-					logs.Error("ListenChain - chain %s node is too slow, node height: %d, really height: %d", ccl.handle.GetChainName(), height, extendHeight)
-				}
-				metrics.Record(height, "%v.lastest_height", chain.ChainId)
-				metrics.Record(extendHeight, "%v.watch_height", chain.ChainId)
-				metrics.Record(chain.Height, "%v.height", chain.ChainId)
-				if chain.Height >= height-ccl.handle.GetDefer() {
-					continue
-				}
-				logs.Info("ListenChain - chain %s latest height is %d, listen height: %d", ccl.handle.GetChainName(), height, chain.Height)
+			if subscribe && heights == nil {
+				heights = ccl.subscribe(ctx, subscribeHandle)
 			}
-			for chain.Height < height-ccl.handle.GetDefer() {
-				batchSize := ccl.handle.GetBatchSize()
-				if batchSize == 0 {
-					batchSize = 1
-				}
-				if batchSize > height-chain.Height-ccl.handle.GetDefer() {
-					batchSize = height - chain.Height - ccl.handle.GetDefer()
-				}
-
-				ch := make(chan bool, batchSize)
-				for i := uint64(1); i <= batchSize; i++ {
-					go func(height uint64) {
-						wrapperTransactions, srcTransactions, polyTransactions, dstTransactions, err := ccl.HandleNewBlock(height)
-						if err != nil {
-							logs.Error("HandleNewBlock chain：%s, height: %d err: %v", ccl.handle.GetChainName(), height, err)
-							ch <- false
-							return
-						}
-						logs.Info("HandleNewBlock [chainName: %s, height: %d]. "+
-							"len(wrapperTransactions)=%d, len(srcTransactions)=%d, len(polyTransactions)=%d, len(dstTransactions)=%d",
-							chain.Name, height, len(wrapperTransactions), len(srcTransactions), len(polyTransactions), len(dstTransactions))
-						err = ccl.db.UpdateEvents(wrapperTransactions, srcTransactions, polyTransactions, dstTransactions)
-						if err != nil {
-							logs.Error("UpdateEvents on block %d err: %v", height, err)
-							ch <- false
-						} else {
-							if !ccl.config.Backup {
-								go ccl.checkLargeTransaction(srcTransactions)
-							}
-							ch <- true
-						}
-
-					}(chain.Height + i)
-				}
-				allTaskSuccess := true
-				for j := 0; j < int(batchSize); j++ {
-					ok := <-ch
-					if !ok {
-						allTaskSuccess = false
-					}
-				}
-				close(ch)
-				if !allTaskSuccess {
-					break
-				}
-
-				chain.Height += batchSize
-				if err := ccl.db.UpdateChain(chain); err != nil {
-					logs.Error("UpdateChain [chainId:%d, height:%d] err %v", chain.ChainId, chain.Height, err)
-					chain.Height -= batchSize
-				}
+			ccl.listenHeight(chain, true)
+		case height, ok := <-heights:
+			if !ok {
+				logs.Warn("ListenChain - chain %s subscription closed, resubscribe in %ds", ccl.handle.GetChainName(), ccl.handle.GetChainListenSlot())
+				heights = nil
+				continue
 			}
-		case <-ccl.exit:
+			logs.Debug("ListenChain - chain %s new height %d", ccl.handle.GetChainName(), height)
+			ccl.listenHeight(chain, false)
+		case <-ccl.ctx.Done():
 			logs.Info("cross chain listen exit, chain: %s, dao: %s......", ccl.handle.GetChainName(), ccl.db.Name())
 			return true
 		}
 	}
 }
 
+// listenHeight handles the blocks up to the latest height less the defer. The
+// extend nodes are only checked when polling, not on every pushed block.
+func (ccl *CrossChainListen) listenHeight(chain *models.Chain, poll bool) {
+	var height uint64
+	if ccl.config.Backup {
+		dbchain, err := ccl.db.GetChain(chain.ChainId)
+		if err != nil {
+			return
+		}
+		height = dbchain.Height
+		if chain.Height >= height-ccl.handle.GetDefer() {
+			return
+		}
+		logs.Info("backup ListenChain - chain %s db height is %d, listen height: %d", ccl.handle.GetChainName(), height, chain.Height)
+	} else {
+		var err error
+		height, err = ccl.handle.GetLatestHeight()
+		if err != nil || height == 0 || height == math.MaxUint64 {
+			logs.Error("listenChain - cannot get chain %s height, err: %s", ccl.handle.GetChainName(), err)
+			return
+		}
+		if poll {
+			extendHeight, err := ccl.handle.GetExtendLatestHeight()
+			if err != nil || extendHeight == 0 {
+				logs.Error("ListenChain - cannot get chain %s extend height, err: %s", ccl.handle.GetChainName(), err)
+			} else if extendHeight >= height+21 {
+				logs.Error("ListenChain - chain %s node is too slow, node height: %d, really height: %d", ccl.handle.GetChainName(), height, extendHeight)
+			}
+			metrics.Record(extendHeight, "%v.watch_height", chain.ChainId)
+		}
+		metrics.Record(height, "%v.lastest_height", chain.ChainId)
+		metrics.Record(chain.Height, "%v.height", chain.ChainId)
+		if chain.Height >= height-ccl.handle.GetDefer() {
+			ccl.updateFinality(chain, height)
+			return
+		}
+		logs.Info("ListenChain - chain %s latest height is %d, listen height: %d", ccl.handle.GetChainName(), height, chain.Height)
+		forkHeight, err := ccl.checkReorg(chain.Height)
+		if err != nil {
+			logs.Error("ListenChain - chain %s check reorg err: %v", ccl.handle.GetChainName(), err)
+			return
+		}
+		if forkHeight < chain.Height {
+			chain.Height = forkHeight
+			if err := ccl.db.UpdateChain(chain); err != nil {
+				logs.Error("UpdateChain [chainId:%d, height:%d] err %v", chain.ChainId, chain.Height, err)
+			}
+		}
+	}
+	if chain.Height < height-ccl.handle.GetDefer() {
+		if err := ccl.handleBlocks(ccl.ctx, chain, height-ccl.handle.GetDefer()); err != nil && ccl.ctx.Err() == nil {
+			logs.Error("ListenChain - chain %s stopped at height %d, err: %v", ccl.handle.GetChainName(), chain.Height, err)
+		}
+	}
+	if !ccl.config.Backup {
+		ccl.updateFinality(chain, height)
+	}
+}
+
 func (ccl *CrossChainListen) checkLargeTransaction(srcTransactions []*models.SrcTransaction) {
 	ccl.dingMux.Lock()
 	defer ccl.dingMux.Unlock()
@@ -305,9 +367,16 @@ func (ccl *CrossChainListen) checkLargeTransaction(srcTransactions []*models.Src
 			if v.SrcTransfer != nil {
 				token, err := ccl.db.GetTokenBasicByHash(v.SrcTransfer.ChainId, v.SrcTransfer.Asset)
 				if err == nil {
+					// a transfer indexed late is valued at the price of its time
+					price := token.TokenBasic.Price
+					if historyPrice, ok, err := ccl.db.GetTokenPriceAt(token.TokenBasicName, int64(v.SrcTransfer.Time)); err != nil {
+						logs.Error("get price of %s at %d err: %v", token.TokenBasicName, v.SrcTransfer.Time, err)
+					} else if ok {
+						price = historyPrice
+					}
 					amount := decimal.NewFromBigInt(&v.SrcTransfer.Amount.Int, 0).
 						Div(decimal.NewFromInt(basedef.Int64FromFigure(int(token.Precision)))).
-						Mul(decimal.NewFromInt(token.TokenBasic.Price)).
+						Mul(decimal.NewFromInt(price)).
 						Div(decimal.NewFromInt(100000000))
 
 					if amount.Cmp(decimal.NewFromInt(ccl.config.LargeTxAmount)) >= 0 {
@@ -18,6 +18,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/beego/beego/v2/core/logs"
@@ -138,7 +139,7 @@ func startServer(ctx *cli.Context) {
 	}
 	chainListen = crosschainlisten.NewCrossChainListen(chainHandler, db, config)
 	chainListen.SetHeight(height)
-	chainListen.Start()
+	chainListen.Start(context.Background())
 }
 
 func waitSignal() os.Signal {
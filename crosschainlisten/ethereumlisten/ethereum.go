@@ -50,8 +50,9 @@ const (
 )
 
 type EthereumChainListen struct {
-	ethCfg *conf.ChainListenConfig
-	ethSdk *chainsdk.EthereumSdkPro
+	ethCfg      *conf.ChainListenConfig
+	ethSdk      *chainsdk.EthereumSdkPro
+	headerTimes *headerTimeCache
 }
 
 func NewEthereumChainListen(cfg *conf.ChainListenConfig) *EthereumChainListen {
@@ -59,8 +60,9 @@ func NewEthereumChainListen(cfg *conf.ChainListenConfig) *EthereumChainListen {
 	ethListen.ethCfg = cfg
 	//
 	urls := cfg.GetNodesUrl()
-	sdk := chainsdk.NewEthereumSdkPro(urls, cfg.ListenSlot, cfg.ChainId)
+	sdk := chainsdk.NewEthereumSdkProWithRateLimit(urls, cfg.ListenSlot, cfg.ChainId, cfg.RequestsPerSecond)
 	ethListen.ethSdk = sdk
+	ethListen.headerTimes = newHeaderTimeCache()
 	return ethListen
 }
 
@@ -88,6 +90,36 @@ func (this *EthereumChainListen) GetBatchSize() uint64 {
 	return this.ethCfg.BatchSize
 }
 
+func (this *EthereumChainListen) GetBlockHash(height uint64) (string, string, error) {
+	header, err := this.ethSdk.GetHeaderByNumber(height)
+	if err != nil {
+		return "", "", err
+	}
+	if header == nil {
+		return "", "", fmt.Errorf("there is no ethereum block on height: %d!", height)
+	}
+	return header.Hash().String(), header.ParentHash.String(), nil
+}
+
+// GetFinalizedHeight returns the finalized block of the node. Rollups mark the
+// blocks whose batch is included on L1 as safe, which is used for l1 finality.
+func (this *EthereumChainListen) GetFinalizedHeight(finality string) (uint64, error) {
+	var tag string
+	switch finality {
+	case basedef.FINALITY_FINALIZED:
+		tag = "finalized"
+	case basedef.FINALITY_L1:
+		tag = "safe"
+	default:
+		return 0, fmt.Errorf("finality %s is not supported", finality)
+	}
+	header, err := this.ethSdk.GetHeaderByTag(tag)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
 func (this *EthereumChainListen) getPLTUnlock(tx common.Hash) *models.ProxyUnlockEvent {
 	address, asset, amount, err := this.GetPaletteLockProxyUnlockEvent(tx)
 	if err != nil {
@@ -132,12 +164,6 @@ func (this *EthereumChainListen) HandleNewBlock(height uint64) ([]*models.Wrappe
 	wrapperTransactions = append(wrapperTransactions, erc20WrapperTransactions...)
 	wrapperTransactions = append(wrapperTransactions, nftWrapperTransactions...)
 
-	for _, item := range wrapperTransactions {
-		logs.Info("(wrapper) from chain: %s, height: %d, txhash: %s", this.GetChainName(), item.BlockHeight, item.Hash)
-		item.Time = blockTimer[item.BlockHeight]
-		item.SrcChainId = this.GetChainId()
-		item.Status = basedef.STATE_SOURCE_DONE
-	}
 	eccmLockEvents, eccmUnLockEvents, err := this.getECCMEventByBlockNumber(this.ethCfg.CCMContract, startHeight, endHeight)
 	if err != nil {
 		return nil, nil, nil, nil, 0, 0, err
@@ -165,7 +191,30 @@ func (this *EthereumChainListen) HandleNewBlock(height uint64) ([]*models.Wrappe
 	}
 	proxyLockEvents = append(proxyLockEvents, swapLockEvents...)
 
-	//
+	wrapperTransactions, srcTransactions, dstTransactions := this.assembleTransactions(blockTimer, wrapperTransactions, eccmLockEvents, eccmUnLockEvents, proxyLockEvents, proxyUnlockEvents, swapEvents)
+	return wrapperTransactions, srcTransactions, nil, dstTransactions, len(proxyLockEvents), len(proxyUnlockEvents), nil
+}
+
+// assembleTransactions matches the proxy and swap events to the eccm events of
+// the same transaction, blockTimer holds the time of the blocks of the events.
+func (this *EthereumChainListen) assembleTransactions(
+	blockTimer map[uint64]uint64,
+	wrapperTransactions []*models.WrapperTransaction,
+	eccmLockEvents []*models.ECCMLockEvent,
+	eccmUnLockEvents []*models.ECCMUnlockEvent,
+	proxyLockEvents []*models.ProxyLockEvent,
+	proxyUnlockEvents []*models.ProxyUnlockEvent,
+	swapEvents []*models.SwapLockEvent) (
+	[]*models.WrapperTransaction,
+	[]*models.SrcTransaction,
+	[]*models.DstTransaction,
+) {
+	for _, item := range wrapperTransactions {
+		logs.Info("(wrapper) from chain: %s, height: %d, txhash: %s", this.GetChainName(), item.BlockHeight, item.Hash)
+		item.Time = blockTimer[item.BlockHeight]
+		item.SrcChainId = this.GetChainId()
+		item.Status = basedef.STATE_SOURCE_DONE
+	}
 	srcTransactions := make([]*models.SrcTransaction, 0)
 	dstTransactions := make([]*models.DstTransaction, 0)
 	for _, lockEvent := range eccmLockEvents {
@@ -299,7 +348,7 @@ func (this *EthereumChainListen) HandleNewBlock(height uint64) ([]*models.Wrappe
 			//}
 		}
 	}
-	return wrapperTransactions, srcTransactions, nil, dstTransactions, len(proxyLockEvents), len(proxyUnlockEvents), nil
+	return wrapperTransactions, srcTransactions, dstTransactions
 }
 
 func (this *EthereumChainListen) getWrapperEventByBlockNumber(contractAddrs []string, startHeight uint64, endHeight uint64) ([]*models.WrapperTransaction, error) {
@@ -342,43 +391,32 @@ func (this *EthereumChainListen) getWrapperEventByBlockNumber1(contractAddr stri
 		return nil, fmt.Errorf("GetSmartContractEventByBlock, filter lock events :%s", err.Error())
 	}
 	for lockEvents.Next() {
-		evt := lockEvents.Event
-		wrapperTransactions = append(wrapperTransactions, &models.WrapperTransaction{
-			Hash:         evt.Raw.TxHash.String()[2:],
-			User:         models.FormatString(strings.ToLower(evt.Sender.String()[2:])),
-			DstChainId:   evt.ToChainId,
-			DstUser:      models.FormatString(hex.EncodeToString(evt.ToAddress)),
-			FeeTokenHash: models.FormatString(strings.ToLower(evt.FromAsset.String()[2:])),
-			FeeAmount:    models.NewBigInt(evt.Fee),
-			ServerId:     evt.Id.Uint64(),
-			BlockHeight:  evt.Raw.BlockNumber,
-		})
+		wrapperTransactions = append(wrapperTransactions, erc20WrapLockEvent2WrapTx(lockEvents.Event))
 	}
 	speedupEvents, err := wrapperContract.FilterPolyWrapperSpeedUp(opt, nil, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("GetSmartContractEventByBlock, filter lock events :%s", err.Error())
 	}
 	for speedupEvents.Next() {
-		evt := speedupEvents.Event
-		wrapperTransactions = append(wrapperTransactions, &models.WrapperTransaction{
-			Hash:         evt.TxHash.String(),
-			User:         models.FormatString(evt.Sender.String()),
-			FeeTokenHash: models.FormatString(evt.FromAsset.String()),
-			FeeAmount:    models.NewBigInt(evt.Efee),
-		})
+		wrapperTransactions = append(wrapperTransactions, erc20WrapSpeedUpEvent2WrapTx(speedupEvents.Event))
 	}
 	if index != 0 {
 		for _, tx := range wrapperTransactions {
-			if this.GetChainId() == basedef.METIS_CROSSCHAIN_ID {
-				tx.FeeTokenHash = "deaddeaddeaddeaddeaddeaddeaddeaddead0000"
-			} else {
-				tx.FeeTokenHash = "0000000000000000000000000000000000000000"
-			}
+			tx.FeeTokenHash = this.nativeFeeTokenHash()
 		}
 	}
 	return wrapperTransactions, nil
 }
 
+// nativeFeeTokenHash is the fee token of the wrappers after the first one,
+// which take the fee in the native token of the chain.
+func (this *EthereumChainListen) nativeFeeTokenHash() string {
+	if this.GetChainId() == basedef.METIS_CROSSCHAIN_ID {
+		return "deaddeaddeaddeaddeaddeaddeaddeaddead0000"
+	}
+	return "0000000000000000000000000000000000000000"
+}
+
 func (this *EthereumChainListen) getECCMEventByBlockNumber(contractAddr string, startHeight uint64, endHeight uint64) ([]*models.ECCMLockEvent, []*models.ECCMUnlockEvent, error) {
 	eccmContractAddress := common.HexToAddress(contractAddr)
 	client := this.ethSdk.GetClient()
@@ -404,17 +442,7 @@ func (this *EthereumChainListen) getECCMEventByBlockNumber(contractAddr string,
 	for crossChainEvents.Next() {
 		evt := crossChainEvents.Event
 		Fee := this.GetConsumeGas(evt.Raw.TxHash)
-		eccmLockEvents = append(eccmLockEvents, &models.ECCMLockEvent{
-			Method:   _eth_crosschainlock,
-			Txid:     hex.EncodeToString(evt.TxId),
-			TxHash:   evt.Raw.TxHash.String()[2:],
-			User:     strings.ToLower(evt.Sender.String()[2:]),
-			Tchain:   uint32(evt.ToChainId),
-			Contract: strings.ToLower(evt.ProxyOrAssetContract.String()[2:]),
-			Value:    evt.Rawdata,
-			Height:   evt.Raw.BlockNumber,
-			Fee:      Fee,
-		})
+		eccmLockEvents = append(eccmLockEvents, crossChainEvent2ProxyLockEvent(evt, Fee))
 	}
 	// ethereum unlock events from given block
 	eccmUnlockEvents := make([]*models.ECCMUnlockEvent, 0)
@@ -426,15 +454,7 @@ func (this *EthereumChainListen) getECCMEventByBlockNumber(contractAddr string,
 	for executeTxEvent.Next() {
 		evt := executeTxEvent.Event
 		Fee := this.GetConsumeGas(evt.Raw.TxHash)
-		eccmUnlockEvents = append(eccmUnlockEvents, &models.ECCMUnlockEvent{
-			Method:   _eth_crosschainunlock,
-			TxHash:   evt.Raw.TxHash.String()[2:],
-			RTxHash:  basedef.HexStringReverse(hex.EncodeToString(evt.CrossChainTxHash)),
-			Contract: hex.EncodeToString(evt.ToContract),
-			FChainId: uint32(evt.FromChainID),
-			Height:   evt.Raw.BlockNumber,
-			Fee:      Fee,
-		})
+		eccmUnlockEvents = append(eccmUnlockEvents, verifyAndExecuteEvent2ProxyUnlockEvent(evt, Fee))
 	}
 	return eccmLockEvents, eccmUnlockEvents, nil
 }
@@ -477,18 +497,7 @@ func (this *EthereumChainListen) getProxyEventByBlockNumber1(contractAddr string
 		return nil, nil, fmt.Errorf("GetSmartContractEventByBlock, filter lock events :%s", err.Error())
 	}
 	for lockEvents.Next() {
-		evt := lockEvents.Event
-		proxyLockEvents = append(proxyLockEvents, &models.ProxyLockEvent{
-			BlockNumber:   evt.Raw.BlockNumber,
-			Method:        _eth_lock,
-			TxHash:        evt.Raw.TxHash.String()[2:],
-			FromAddress:   evt.FromAddress.String()[2:],
-			FromAssetHash: strings.ToLower(evt.FromAssetHash.String()[2:]),
-			ToChainId:     uint32(evt.ToChainId),
-			ToAssetHash:   hex.EncodeToString(evt.ToAssetHash),
-			ToAddress:     hex.EncodeToString(evt.ToAddress),
-			Amount:        evt.Amount,
-		})
+		proxyLockEvents = append(proxyLockEvents, convertErc20LockProxyEvent(lockEvents.Event))
 	}
 
 	// ethereum unlock events from given block
@@ -498,15 +507,7 @@ func (this *EthereumChainListen) getProxyEventByBlockNumber1(contractAddr string
 		return nil, nil, fmt.Errorf("GetSmartContractEventByBlock, filter unlock events :%s", err.Error())
 	}
 	for unlockEvents.Next() {
-		evt := unlockEvents.Event
-		proxyUnlockEvents = append(proxyUnlockEvents, &models.ProxyUnlockEvent{
-			BlockNumber: evt.Raw.BlockNumber,
-			Method:      _eth_unlock,
-			TxHash:      evt.Raw.TxHash.String()[2:],
-			ToAssetHash: strings.ToLower(evt.ToAssetHash.String()[2:]),
-			ToAddress:   strings.ToLower(evt.ToAddress.String()[2:]),
-			Amount:      evt.Amount,
-		})
+		proxyUnlockEvents = append(proxyUnlockEvents, convertErc20UnlockProxyEvent(unlockEvents.Event))
 	}
 	return proxyLockEvents, proxyUnlockEvents, nil
 }
@@ -604,21 +605,7 @@ func (this *EthereumChainListen) getSwapEventByBlockNumber(contractAddr string,
 			return nil, nil, fmt.Errorf("getSwapEventByBlockNumber, filter lock events :%s", err.Error())
 		}
 		for lockEvents.Next() {
-			evt := lockEvents.Event
-			swapLockEvents = append(swapLockEvents, &models.SwapLockEvent{
-				BlockNumber:   evt.Raw.BlockNumber,
-				Type:          basedef.SWAP_ADDLIQUIDITY,
-				TxHash:        evt.Raw.TxHash.String()[2:],
-				FromAssetHash: strings.ToLower(evt.FromAssetHash.String()[2:]),
-				FromAddress:   strings.ToLower(evt.FromAddress.String()[2:]),
-				ToChainId:     evt.ToChainId,
-				ToPoolId:      evt.ToPoolId,
-				ToAddress:     hex.EncodeToString(evt.ToAddress),
-				Amount:        evt.Amount,
-				FeeAssetHash:  "0000000000000000000000000000000000000000",
-				Fee:           evt.Fee,
-				ServerId:      evt.Id,
-			})
+			swapLockEvents = append(swapLockEvents, convertSwapEvent((*swapper_abi.SwapperSwapEvent)(lockEvents.Event), basedef.SWAP_ADDLIQUIDITY))
 		}
 	}
 	{
@@ -627,21 +614,7 @@ func (this *EthereumChainListen) getSwapEventByBlockNumber(contractAddr string,
 			return nil, nil, fmt.Errorf("getSwapEventByBlockNumber, filter lock events :%s", err.Error())
 		}
 		for lockEvents.Next() {
-			evt := lockEvents.Event
-			swapLockEvents = append(swapLockEvents, &models.SwapLockEvent{
-				BlockNumber:   evt.Raw.BlockNumber,
-				Type:          basedef.SWAP_REMOVELIQUIDITY,
-				TxHash:        evt.Raw.TxHash.String()[2:],
-				FromAssetHash: strings.ToLower(evt.FromAssetHash.String()[2:]),
-				FromAddress:   strings.ToLower(evt.FromAddress.String()[2:]),
-				ToChainId:     evt.ToChainId,
-				ToPoolId:      evt.ToPoolId,
-				ToAddress:     hex.EncodeToString(evt.ToAddress),
-				Amount:        evt.Amount,
-				FeeAssetHash:  "0000000000000000000000000000000000000000",
-				Fee:           evt.Fee,
-				ServerId:      evt.Id,
-			})
+			swapLockEvents = append(swapLockEvents, convertSwapEvent((*swapper_abi.SwapperSwapEvent)(lockEvents.Event), basedef.SWAP_REMOVELIQUIDITY))
 		}
 	}
 	{
@@ -650,21 +623,7 @@ func (this *EthereumChainListen) getSwapEventByBlockNumber(contractAddr string,
 			return nil, nil, fmt.Errorf("getSwapEventByBlockNumber, filter lock events :%s", err.Error())
 		}
 		for lockEvents.Next() {
-			evt := lockEvents.Event
-			swapLockEvents = append(swapLockEvents, &models.SwapLockEvent{
-				BlockNumber:   evt.Raw.BlockNumber,
-				Type:          basedef.SWAP_SWAP,
-				TxHash:        evt.Raw.TxHash.String()[2:],
-				FromAssetHash: strings.ToLower(evt.FromAssetHash.String()[2:]),
-				FromAddress:   strings.ToLower(evt.FromAddress.String()[2:]),
-				ToChainId:     evt.ToChainId,
-				ToPoolId:      evt.ToPoolId,
-				ToAddress:     hex.EncodeToString(evt.ToAddress),
-				Amount:        evt.Amount,
-				FeeAssetHash:  "0000000000000000000000000000000000000000",
-				Fee:           evt.Fee,
-				ServerId:      evt.Id,
-			})
+			swapLockEvents = append(swapLockEvents, convertSwapEvent(lockEvents.Event, basedef.SWAP_SWAP))
 		}
 	}
 	proxyLockEvents := make([]*models.ProxyLockEvent, 0)
@@ -673,18 +632,7 @@ func (this *EthereumChainListen) getSwapEventByBlockNumber(contractAddr string,
 		return nil, nil, fmt.Errorf("GetSmartContractEventByBlock, filter lock events :%s", err.Error())
 	}
 	for lockEvents.Next() {
-		evt := lockEvents.Event
-		proxyLockEvents = append(proxyLockEvents, &models.ProxyLockEvent{
-			BlockNumber:   evt.Raw.BlockNumber,
-			Method:        _eth_lock,
-			TxHash:        evt.Raw.TxHash.String()[2:],
-			FromAddress:   evt.FromAddress.String()[2:],
-			FromAssetHash: strings.ToLower(evt.FromAssetHash.String()[2:]),
-			ToChainId:     uint32(evt.ToChainId),
-			ToAssetHash:   hex.EncodeToString(evt.ToAssetHash),
-			ToAddress:     hex.EncodeToString(evt.ToAddress),
-			Amount:        evt.Amount,
-		})
+		proxyLockEvents = append(proxyLockEvents, convertSwapperLockEvent(lockEvents.Event))
 	}
 	return proxyLockEvents, swapLockEvents, nil
 }
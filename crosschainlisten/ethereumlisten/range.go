@@ -0,0 +1,361 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ethereumlisten
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"poly-bridge/basedef"
+	"poly-bridge/go_abi/eccm_abi"
+	"poly-bridge/go_abi/lock_proxy_abi"
+	nftlp "poly-bridge/go_abi/nft_lock_proxy_abi"
+	nftwp "poly-bridge/go_abi/nft_wrap_abi"
+	"poly-bridge/go_abi/swapper_abi"
+	"poly-bridge/go_abi/wrapper_abi"
+	"poly-bridge/models"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const headerTimeCacheSize = 4096
+
+const (
+	contractWrapper = iota
+	contractNFTWrapper
+	contractECCM
+	contractProxy
+	contractNFTProxy
+	contractSwapper
+)
+
+// logContract is the kind of a configured contract, index is its position in
+// the wrapper list as the wrappers after the first take the native fee token.
+type logContract struct {
+	kind  int
+	index int
+}
+
+var (
+	wrapperLockId                 = eventId(wrapper_abi.PolyWrapperABI, "PolyWrapperLock")
+	wrapperSpeedUpId              = eventId(wrapper_abi.PolyWrapperABI, "PolyWrapperSpeedUp")
+	nftWrapperLockId              = eventId(nftwp.PolyNFTWrapperABI, "PolyWrapperLock")
+	nftWrapperSpeedUpId           = eventId(nftwp.PolyNFTWrapperABI, "PolyWrapperSpeedUp")
+	crossChainEventId             = eventId(eccm_abi.EthCrossChainManagerABI, "CrossChainEvent")
+	verifyAndExecuteTxEventId     = eventId(eccm_abi.EthCrossChainManagerABI, "VerifyHeaderAndExecuteTxEvent")
+	proxyLockEventId              = eventId(lock_proxy_abi.LockProxyABI, "LockEvent")
+	proxyUnlockEventId            = eventId(lock_proxy_abi.LockProxyABI, "UnlockEvent")
+	nftProxyLockEventId           = eventId(nftlp.PolyNFTLockProxyABI, "LockEvent")
+	nftProxyUnlockEventId         = eventId(nftlp.PolyNFTLockProxyABI, "UnlockEvent")
+	swapperAddLiquidityEventId    = eventId(swapper_abi.SwapperABI, "AddLiquidityEvent")
+	swapperRemoveLiquidityEventId = eventId(swapper_abi.SwapperABI, "RemoveLiquidityEvent")
+	swapperSwapEventId            = eventId(swapper_abi.SwapperABI, "SwapEvent")
+	swapperLockEventId            = eventId(swapper_abi.SwapperABI, "LockEvent")
+
+	logTopics = [][]common.Hash{{
+		wrapperLockId, wrapperSpeedUpId, nftWrapperLockId, nftWrapperSpeedUpId,
+		crossChainEventId, verifyAndExecuteTxEventId,
+		proxyLockEventId, proxyUnlockEventId, nftProxyLockEventId, nftProxyUnlockEventId,
+		swapperAddLiquidityEventId, swapperRemoveLiquidityEventId, swapperSwapEventId, swapperLockEventId,
+	}}
+
+	// the filterers only unpack logs, so they are not bound to a contract
+	wrapperFilterer, _    = wrapper_abi.NewPolyWrapperFilterer(common.Address{}, nil)
+	nftWrapperFilterer, _ = nftwp.NewPolyNFTWrapperFilterer(common.Address{}, nil)
+	eccmFilterer, _       = eccm_abi.NewEthCrossChainManagerFilterer(common.Address{}, nil)
+	proxyFilterer, _      = lock_proxy_abi.NewLockProxyFilterer(common.Address{}, nil)
+	nftProxyFilterer, _   = nftlp.NewPolyNFTLockProxyFilterer(common.Address{}, nil)
+	swapperFilterer, _    = swapper_abi.NewSwapperFilterer(common.Address{}, nil)
+)
+
+func eventId(contractAbi string, name string) common.Hash {
+	parsed, err := abi.JSON(strings.NewReader(contractAbi))
+	if err != nil {
+		panic(fmt.Sprintf("parse abi of event %s err: %v", name, err))
+	}
+	event, ok := parsed.Events[name]
+	if !ok {
+		panic(fmt.Sprintf("missing abi of event %s", name))
+	}
+	return event.ID
+}
+
+// headerTimeCache keeps the time of the blocks which had events, so a window
+// retried after an error does not fetch the same headers again.
+type headerTimeCache struct {
+	times map[uint64]uint64
+	mutex sync.Mutex
+}
+
+func newHeaderTimeCache() *headerTimeCache {
+	return &headerTimeCache{times: make(map[uint64]uint64)}
+}
+
+func (cache *headerTimeCache) get(height uint64) (uint64, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	tt, ok := cache.times[height]
+	return tt, ok
+}
+
+func (cache *headerTimeCache) set(height uint64, tt uint64) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.times[height] = tt
+	if len(cache.times) <= headerTimeCacheSize {
+		return
+	}
+	for h := range cache.times {
+		if h+headerTimeCacheSize < height {
+			delete(cache.times, h)
+		}
+	}
+}
+
+func (this *EthereumChainListen) GetRangeSize() uint64 {
+	return this.ethCfg.LogRange
+}
+
+// logContracts maps the configured contracts to their kind.
+func (this *EthereumChainListen) logContracts() map[common.Address]logContract {
+	contracts := make(map[common.Address]logContract)
+	add := func(contract string, kind int, index int) {
+		if !isContract(strings.TrimPrefix(strings.TrimSpace(contract), "0x")) {
+			return
+		}
+		contracts[common.HexToAddress(contract)] = logContract{kind: kind, index: index}
+	}
+	for i, contract := range this.ethCfg.WrapperContract {
+		add(contract, contractWrapper, i)
+	}
+	for _, contract := range this.ethCfg.NFTWrapperContract {
+		add(contract, contractNFTWrapper, 0)
+	}
+	add(this.ethCfg.CCMContract, contractECCM, 0)
+	for _, contract := range this.ethCfg.ProxyContract {
+		add(contract, contractProxy, 0)
+	}
+	for _, contract := range this.ethCfg.NFTProxyContract {
+		add(contract, contractNFTProxy, 0)
+	}
+	add(this.ethCfg.SwapContract, contractSwapper, 0)
+	return contracts
+}
+
+func logAddresses(contracts map[common.Address]logContract) []common.Address {
+	addresses := make([]common.Address, 0, len(contracts))
+	for address := range contracts {
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// HandleBlockRange gets the events of all the configured contracts in
+// [startHeight, endHeight] with one eth_getLogs and assembles the transactions
+// the same way as HandleNewBlock.
+func (this *EthereumChainListen) HandleBlockRange(startHeight, endHeight uint64) ([]*models.WrapperTransaction, []*models.SrcTransaction, []*models.PolyTransaction, []*models.DstTransaction, int, int, error) {
+	contracts := this.logContracts()
+	addresses := logAddresses(contracts)
+	if len(addresses) == 0 {
+		return nil, nil, nil, nil, 0, 0, nil
+	}
+	client := this.ethSdk.GetClient()
+	if client == nil {
+		return nil, nil, nil, nil, 0, 0, fmt.Errorf("HandleBlockRange GetClient error: nil")
+	}
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startHeight),
+		ToBlock:   new(big.Int).SetUint64(endHeight),
+		Addresses: addresses,
+		Topics:    logTopics,
+	}
+	rawLogs, err := client.FilterLogs(context.Background(), query)
+	if err != nil {
+		this.ethSdk.SetClientHeightZero(client)
+		return nil, nil, nil, nil, 0, 0, fmt.Errorf("HandleBlockRange, filter logs of %d-%d :%s", startHeight, endHeight, err.Error())
+	}
+
+	wrapperTransactions := make([]*models.WrapperTransaction, 0)
+	eccmLockEvents, eccmUnLockEvents := make([]*models.ECCMLockEvent, 0), make([]*models.ECCMUnlockEvent, 0)
+	proxyLockEvents, proxyUnlockEvents := make([]*models.ProxyLockEvent, 0), make([]*models.ProxyUnlockEvent, 0)
+	swapEvents := make([]*models.SwapLockEvent, 0)
+	for _, log := range rawLogs {
+		if log.Removed || len(log.Topics) == 0 {
+			continue
+		}
+		contract, ok := contracts[log.Address]
+		if !ok {
+			continue
+		}
+		err := func() error {
+			switch contract.kind {
+			case contractWrapper:
+				var wrapperTransaction *models.WrapperTransaction
+				switch log.Topics[0] {
+				case wrapperLockId:
+					evt, err := wrapperFilterer.ParsePolyWrapperLock(log)
+					if err != nil {
+						return err
+					}
+					wrapperTransaction = erc20WrapLockEvent2WrapTx(evt)
+				case wrapperSpeedUpId:
+					evt, err := wrapperFilterer.ParsePolyWrapperSpeedUp(log)
+					if err != nil {
+						return err
+					}
+					wrapperTransaction = erc20WrapSpeedUpEvent2WrapTx(evt)
+				default:
+					return nil
+				}
+				if contract.index != 0 {
+					wrapperTransaction.FeeTokenHash = this.nativeFeeTokenHash()
+				}
+				wrapperTransactions = append(wrapperTransactions, wrapperTransaction)
+			case contractNFTWrapper:
+				switch log.Topics[0] {
+				case nftWrapperLockId:
+					evt, err := nftWrapperFilterer.ParsePolyWrapperLock(log)
+					if err != nil {
+						return err
+					}
+					wrapperTransactions = append(wrapperTransactions, wrapLockEvent2WrapTx(evt))
+				case nftWrapperSpeedUpId:
+					evt, err := nftWrapperFilterer.ParsePolyWrapperSpeedUp(log)
+					if err != nil {
+						return err
+					}
+					wrapperTransactions = append(wrapperTransactions, wrapSpeedUpEvent2WrapTx(evt))
+				}
+			case contractECCM:
+				switch log.Topics[0] {
+				case crossChainEventId:
+					evt, err := eccmFilterer.ParseCrossChainEvent(log)
+					if err != nil {
+						return err
+					}
+					eccmLockEvents = append(eccmLockEvents, crossChainEvent2ProxyLockEvent(evt, this.GetConsumeGas(log.TxHash)))
+				case verifyAndExecuteTxEventId:
+					evt, err := eccmFilterer.ParseVerifyHeaderAndExecuteTxEvent(log)
+					if err != nil {
+						return err
+					}
+					eccmUnLockEvents = append(eccmUnLockEvents, verifyAndExecuteEvent2ProxyUnlockEvent(evt, this.GetConsumeGas(log.TxHash)))
+				}
+			case contractProxy:
+				switch log.Topics[0] {
+				case proxyLockEventId:
+					evt, err := proxyFilterer.ParseLockEvent(log)
+					if err != nil {
+						return err
+					}
+					proxyLockEvents = append(proxyLockEvents, convertErc20LockProxyEvent(evt))
+				case proxyUnlockEventId:
+					evt, err := proxyFilterer.ParseUnlockEvent(log)
+					if err != nil {
+						return err
+					}
+					proxyUnlockEvents = append(proxyUnlockEvents, convertErc20UnlockProxyEvent(evt))
+				}
+			case contractNFTProxy:
+				switch log.Topics[0] {
+				case nftProxyLockEventId:
+					evt, err := nftProxyFilterer.ParseLockEvent(log)
+					if err != nil {
+						return err
+					}
+					proxyLockEvents = append(proxyLockEvents, convertLockProxyEvent(evt))
+				case nftProxyUnlockEventId:
+					evt, err := nftProxyFilterer.ParseUnlockEvent(log)
+					if err != nil {
+						return err
+					}
+					proxyUnlockEvents = append(proxyUnlockEvents, convertUnlockProxyEvent(evt))
+				}
+			case contractSwapper:
+				switch log.Topics[0] {
+				case swapperAddLiquidityEventId:
+					evt, err := swapperFilterer.ParseAddLiquidityEvent(log)
+					if err != nil {
+						return err
+					}
+					swapEvents = append(swapEvents, convertSwapEvent((*swapper_abi.SwapperSwapEvent)(evt), basedef.SWAP_ADDLIQUIDITY))
+				case swapperRemoveLiquidityEventId:
+					evt, err := swapperFilterer.ParseRemoveLiquidityEvent(log)
+					if err != nil {
+						return err
+					}
+					swapEvents = append(swapEvents, convertSwapEvent((*swapper_abi.SwapperSwapEvent)(evt), basedef.SWAP_REMOVELIQUIDITY))
+				case swapperSwapEventId:
+					evt, err := swapperFilterer.ParseSwapEvent(log)
+					if err != nil {
+						return err
+					}
+					swapEvents = append(swapEvents, convertSwapEvent(evt, basedef.SWAP_SWAP))
+				case swapperLockEventId:
+					evt, err := swapperFilterer.ParseLockEvent(log)
+					if err != nil {
+						return err
+					}
+					proxyLockEvents = append(proxyLockEvents, convertSwapperLockEvent(evt))
+				}
+			}
+			return nil
+		}()
+		if err != nil {
+			return nil, nil, nil, nil, 0, 0, fmt.Errorf("HandleBlockRange, parse log %d of tx %s :%s", log.Index, log.TxHash.String(), err.Error())
+		}
+	}
+
+	blockTimer, err := this.blockTimes(rawLogs)
+	if err != nil {
+		return nil, nil, nil, nil, 0, 0, err
+	}
+	wrapperTransactions, srcTransactions, dstTransactions := this.assembleTransactions(blockTimer, wrapperTransactions, eccmLockEvents, eccmUnLockEvents, proxyLockEvents, proxyUnlockEvents, swapEvents)
+	return wrapperTransactions, srcTransactions, nil, dstTransactions, len(proxyLockEvents), len(proxyUnlockEvents), nil
+}
+
+// blockTimes gets the time of the blocks of the logs, only blocks with events
+// are fetched and the times are cached.
+func (this *EthereumChainListen) blockTimes(rawLogs []types.Log) (map[uint64]uint64, error) {
+	blockTimer := make(map[uint64]uint64)
+	for _, log := range rawLogs {
+		if _, ok := blockTimer[log.BlockNumber]; ok {
+			continue
+		}
+		if tt, ok := this.headerTimes.get(log.BlockNumber); ok {
+			blockTimer[log.BlockNumber] = tt
+			continue
+		}
+		blockHeader, err := this.ethSdk.GetHeaderByNumber(log.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if blockHeader == nil {
+			return nil, fmt.Errorf("there is no ethereum block on height: %d!", log.BlockNumber)
+		}
+		blockTimer[log.BlockNumber] = blockHeader.Time
+		this.headerTimes.set(log.BlockNumber, blockHeader.Time)
+	}
+	return blockTimer, nil
+}
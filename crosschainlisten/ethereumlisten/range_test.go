@@ -0,0 +1,37 @@
+package ethereumlisten
+
+import (
+	"poly-bridge/conf"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthereumChainListen_LogContracts(t *testing.T) {
+	listen := &EthereumChainListen{ethCfg: &conf.ChainListenConfig{
+		WrapperContract:  []string{"0x1111111111111111111111111111111111111111", "", "2222222222222222222222222222222222222222"},
+		CCMContract:      "3333333333333333333333333333333333333333",
+		ProxyContract:    []string{"0x0000000000000000000000000000000000000000", "4444444444444444444444444444444444444444"},
+		NFTProxyContract: []string{"5555555555555555555555555555555555555555"},
+	}}
+	contracts := listen.logContracts()
+	assert.Equal(t, 5, len(contracts))
+	assert.Equal(t, logContract{kind: contractWrapper, index: 0}, contracts[common.HexToAddress("1111111111111111111111111111111111111111")])
+	assert.Equal(t, logContract{kind: contractWrapper, index: 2}, contracts[common.HexToAddress("2222222222222222222222222222222222222222")])
+	assert.Equal(t, contractECCM, contracts[common.HexToAddress("3333333333333333333333333333333333333333")].kind)
+	assert.Equal(t, contractProxy, contracts[common.HexToAddress("4444444444444444444444444444444444444444")].kind)
+	assert.Equal(t, contractNFTProxy, contracts[common.HexToAddress("5555555555555555555555555555555555555555")].kind)
+}
+
+func TestHeaderTimeCache(t *testing.T) {
+	cache := newHeaderTimeCache()
+	for h := uint64(1); h <= headerTimeCacheSize+10; h++ {
+		cache.set(h, h*10)
+	}
+	_, ok := cache.get(1)
+	assert.False(t, ok)
+	tt, ok := cache.get(headerTimeCacheSize + 10)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(headerTimeCacheSize+10)*10, tt)
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ethereumlisten
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/beego/beego/v2/core/logs"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Subscribe subscribes newHeads and the logs of the configured contracts on
+// the first websocket node that accepts both, and pushes their block heights.
+// While the listener is busy only the highest pending height is kept.
+func (this *EthereumChainListen) Subscribe(ctx context.Context) (<-chan uint64, error) {
+	urls := this.ethCfg.GetWsNodesUrl()
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	var lastErr error
+	for _, url := range urls {
+		heights, err := this.subscribe(ctx, url)
+		if err == nil {
+			logs.Info("chain %s subscribed to %s", this.GetChainName(), url)
+			return heights, nil
+		}
+		logs.Error("chain %s subscribe to %s err: %v", this.GetChainName(), url, err)
+		lastErr = err
+	}
+	return nil, fmt.Errorf("all websocket nodes are not working, last err: %v", lastErr)
+}
+
+func (this *EthereumChainListen) subscribe(ctx context.Context, url string) (<-chan uint64, error) {
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	headCh := make(chan *types.Header, 16)
+	headSub, err := client.SubscribeNewHead(ctx, headCh)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	var logSub ethereum.Subscription
+	logCh := make(chan types.Log, 64)
+	if addresses := logAddresses(this.logContracts()); len(addresses) > 0 {
+		query := ethereum.FilterQuery{
+			Addresses: addresses,
+			Topics:    logTopics,
+		}
+		logSub, err = client.SubscribeFilterLogs(ctx, query, logCh)
+		if err != nil {
+			headSub.Unsubscribe()
+			client.Close()
+			return nil, err
+		}
+	}
+	heights := make(chan uint64, 1)
+	go func() {
+		defer close(heights)
+		defer client.Close()
+		defer headSub.Unsubscribe()
+		var logErr <-chan error
+		if logSub != nil {
+			defer logSub.Unsubscribe()
+			logErr = logSub.Err()
+		}
+		for {
+			var height uint64
+			select {
+			case header := <-headCh:
+				height = header.Number.Uint64()
+			case log := <-logCh:
+				if log.Removed {
+					continue
+				}
+				height = log.BlockNumber
+			case err := <-headSub.Err():
+				logs.Error("chain %s newHeads subscription err: %v", this.GetChainName(), err)
+				return
+			case err := <-logErr:
+				logs.Error("chain %s logs subscription err: %v", this.GetChainName(), err)
+				return
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case heights <- height:
+			default:
+				// the listener has not taken the last height yet, leave the higher one
+				select {
+				case last := <-heights:
+					if last > height {
+						height = last
+					}
+				default:
+				}
+				heights <- height
+			}
+		}
+	}()
+	return heights, nil
+}
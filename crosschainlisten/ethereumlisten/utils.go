@@ -6,8 +6,11 @@ import (
 
 	"poly-bridge/basedef"
 	"poly-bridge/go_abi/eccm_abi"
+	"poly-bridge/go_abi/lock_proxy_abi"
 	nftlp "poly-bridge/go_abi/nft_lock_proxy_abi"
 	nftwp "poly-bridge/go_abi/nft_wrap_abi"
+	"poly-bridge/go_abi/swapper_abi"
+	"poly-bridge/go_abi/wrapper_abi"
 	"poly-bridge/models"
 )
 
@@ -93,6 +96,28 @@ func assembleDstTransaction(
 	return dstTransaction
 }
 
+func erc20WrapLockEvent2WrapTx(evt *wrapper_abi.PolyWrapperPolyWrapperLock) *models.WrapperTransaction {
+	return &models.WrapperTransaction{
+		Hash:         evt.Raw.TxHash.String()[2:],
+		User:         models.FormatString(strings.ToLower(evt.Sender.String()[2:])),
+		DstChainId:   evt.ToChainId,
+		DstUser:      models.FormatString(hex.EncodeToString(evt.ToAddress)),
+		FeeTokenHash: models.FormatString(strings.ToLower(evt.FromAsset.String()[2:])),
+		FeeAmount:    models.NewBigInt(evt.Fee),
+		ServerId:     evt.Id.Uint64(),
+		BlockHeight:  evt.Raw.BlockNumber,
+	}
+}
+
+func erc20WrapSpeedUpEvent2WrapTx(evt *wrapper_abi.PolyWrapperPolyWrapperSpeedUp) *models.WrapperTransaction {
+	return &models.WrapperTransaction{
+		Hash:         evt.TxHash.String(),
+		User:         models.FormatString(evt.Sender.String()),
+		FeeTokenHash: models.FormatString(evt.FromAsset.String()),
+		FeeAmount:    models.NewBigInt(evt.Efee),
+	}
+}
+
 func wrapLockEvent2WrapTx(evt *nftwp.PolyNFTWrapperPolyWrapperLock) *models.WrapperTransaction {
 	return &models.WrapperTransaction{
 		Hash:         evt.Raw.TxHash.String()[2:],
@@ -149,6 +174,64 @@ func convertLockProxyEvent(evt *nftlp.PolyNFTLockProxyLockEvent) *models.ProxyLo
 	}
 }
 
+func convertErc20LockProxyEvent(evt *lock_proxy_abi.LockProxyLockEvent) *models.ProxyLockEvent {
+	return &models.ProxyLockEvent{
+		BlockNumber:   evt.Raw.BlockNumber,
+		Method:        _eth_lock,
+		TxHash:        evt.Raw.TxHash.String()[2:],
+		FromAddress:   evt.FromAddress.String()[2:],
+		FromAssetHash: strings.ToLower(evt.FromAssetHash.String()[2:]),
+		ToChainId:     uint32(evt.ToChainId),
+		ToAssetHash:   hex.EncodeToString(evt.ToAssetHash),
+		ToAddress:     hex.EncodeToString(evt.ToAddress),
+		Amount:        evt.Amount,
+	}
+}
+
+func convertErc20UnlockProxyEvent(evt *lock_proxy_abi.LockProxyUnlockEvent) *models.ProxyUnlockEvent {
+	return &models.ProxyUnlockEvent{
+		BlockNumber: evt.Raw.BlockNumber,
+		Method:      _eth_unlock,
+		TxHash:      evt.Raw.TxHash.String()[2:],
+		ToAssetHash: strings.ToLower(evt.ToAssetHash.String()[2:]),
+		ToAddress:   strings.ToLower(evt.ToAddress.String()[2:]),
+		Amount:      evt.Amount,
+	}
+}
+
+func convertSwapperLockEvent(evt *swapper_abi.SwapperLockEvent) *models.ProxyLockEvent {
+	return &models.ProxyLockEvent{
+		BlockNumber:   evt.Raw.BlockNumber,
+		Method:        _eth_lock,
+		TxHash:        evt.Raw.TxHash.String()[2:],
+		FromAddress:   evt.FromAddress.String()[2:],
+		FromAssetHash: strings.ToLower(evt.FromAssetHash.String()[2:]),
+		ToChainId:     uint32(evt.ToChainId),
+		ToAssetHash:   hex.EncodeToString(evt.ToAssetHash),
+		ToAddress:     hex.EncodeToString(evt.ToAddress),
+		Amount:        evt.Amount,
+	}
+}
+
+// convertSwapEvent also takes the add and remove liquidity events, which have
+// the same fields as the swap event and are converted to it by the caller.
+func convertSwapEvent(evt *swapper_abi.SwapperSwapEvent, swapType uint64) *models.SwapLockEvent {
+	return &models.SwapLockEvent{
+		BlockNumber:   evt.Raw.BlockNumber,
+		Type:          swapType,
+		TxHash:        evt.Raw.TxHash.String()[2:],
+		FromAssetHash: strings.ToLower(evt.FromAssetHash.String()[2:]),
+		FromAddress:   strings.ToLower(evt.FromAddress.String()[2:]),
+		ToChainId:     evt.ToChainId,
+		ToPoolId:      evt.ToPoolId,
+		ToAddress:     hex.EncodeToString(evt.ToAddress),
+		Amount:        evt.Amount,
+		FeeAssetHash:  "0000000000000000000000000000000000000000",
+		Fee:           evt.Fee,
+		ServerId:      evt.Id,
+	}
+}
+
 func verifyAndExecuteEvent2ProxyUnlockEvent(
 	evt *eccm_abi.EthCrossChainManagerVerifyHeaderAndExecuteTxEvent,
 	fee uint64,
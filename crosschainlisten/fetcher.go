@@ -0,0 +1,307 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package crosschainlisten
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"poly-bridge/basedef"
+	"poly-bridge/cacheRedis"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+)
+
+const (
+	blockRetryCount      = 5
+	blockRetryBackoff    = time.Second
+	blockRetryMaxBackoff = time.Second * 30
+)
+
+type blockResult struct {
+	height uint64
+	err    error
+}
+
+// ChainRangeHandle is implemented by chain handles that can fetch the events
+// of a range of blocks with one query. It is used when the range size is set.
+type ChainRangeHandle interface {
+	GetRangeSize() uint64
+	HandleBlockRange(start, end uint64) ([]*models.WrapperTransaction, []*models.SrcTransaction, []*models.PolyTransaction, []*models.DstTransaction, int, int, error)
+}
+
+// handleBlocks indexes the blocks in (chain.Height, end] and only moves
+// chain.Height over the contiguous range of committed blocks.
+func (ccl *CrossChainListen) handleBlocks(ctx context.Context, chain *models.Chain, end uint64) error {
+	commit := func(height uint64) {
+		chain.Height = height
+		if err := ccl.db.UpdateChain(chain); err != nil {
+			logs.Error("UpdateChain [chainId:%d, height:%d] err %v", chain.ChainId, chain.Height, err)
+		}
+		ccl.blocks.prune(chain.Height)
+	}
+	if rangeHandle, ok := ccl.handle.(ChainRangeHandle); ok && rangeHandle.GetRangeSize() > 0 {
+		return ccl.handleRanges(ctx, rangeHandle, chain, end, commit)
+	}
+	handle := func(height uint64) error {
+		return ccl.handleBlock(chain.Name, height)
+	}
+	return HandleBlocks(ctx, chain.Name, chain.Height, end, ccl.handle.GetBatchSize(), handle, commit)
+}
+
+// handleRanges splits (chain.Height, end] into windows of the range size and
+// runs them through HandleBlocks by window number, so a window is retried and
+// committed the same way as a single block.
+func (ccl *CrossChainListen) handleRanges(ctx context.Context, rangeHandle ChainRangeHandle, chain *models.Chain, end uint64, commit func(height uint64)) error {
+	start, size := chain.Height, rangeHandle.GetRangeSize()
+	windowEnd := func(window uint64) uint64 {
+		if height := start + window*size; height < end {
+			return height
+		}
+		return end
+	}
+	handle := func(window uint64) error {
+		return ccl.handleRange(chain.Name, rangeHandle, windowEnd(window-1)+1, windowEnd(window))
+	}
+	windows := (end - start + size - 1) / size
+	return HandleBlocks(ctx, chain.Name, 0, windows, ccl.handle.GetBatchSize(), handle, func(window uint64) {
+		commit(windowEnd(window))
+	})
+}
+
+// HandleBlocks runs handle for every height in (start, end] on a pool of
+// workers. A failed block is retried with backoff without touching the
+// others, and commit is called each time the contiguous range of handled
+// blocks grows, so no block is skipped. It stops dispatching new heights
+// once a block runs out of retries or ctx is done, and returns after the
+// blocks in flight are handled.
+func HandleBlocks(ctx context.Context, name string, start, end, workers uint64, handle func(height uint64) error, commit func(height uint64)) error {
+	if workers == 0 {
+		workers = 1
+	}
+	heights := make(chan uint64)
+	results := make(chan *blockResult, workers)
+	stop := make(chan struct{})
+	go func() {
+		defer close(heights)
+		for height := start + 1; height <= end; height++ {
+			select {
+			case heights <- height:
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg := new(sync.WaitGroup)
+	for i := uint64(0); i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heights {
+				results <- &blockResult{height: height, err: retryBlock(ctx, name, height, handle)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var failed error
+	committed := start
+	done := make(map[uint64]bool)
+	for result := range results {
+		if result.err != nil {
+			if failed == nil {
+				failed = result.err
+				close(stop)
+			}
+			continue
+		}
+		done[result.height] = true
+		height := committed
+		for done[height+1] {
+			delete(done, height+1)
+			height++
+		}
+		if height > committed {
+			committed = height
+			commit(committed)
+		}
+	}
+	if failed == nil {
+		failed = ctx.Err()
+	}
+	return failed
+}
+
+func retryBlock(ctx context.Context, name string, height uint64, handle func(height uint64) error) (err error) {
+	backoff := blockRetryBackoff
+	for i := 0; i < blockRetryCount; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > blockRetryMaxBackoff {
+				backoff = blockRetryMaxBackoff
+			}
+		}
+		if err = handle(height); err == nil {
+			return nil
+		}
+		logs.Error("handle block chain: %s, height: %d, retry: %d, err: %v", name, height, i, err)
+	}
+	return err
+}
+
+func (ccl *CrossChainListen) handleBlock(chainName string, height uint64) error {
+	hash, parentHash, ok, err := ccl.blockHash(height)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err := ccl.checkParent(height, parentHash); err != nil {
+			return err
+		}
+	}
+	wrapperTransactions, srcTransactions, polyTransactions, dstTransactions, err := ccl.HandleNewBlock(height)
+	if err != nil {
+		return err
+	}
+	logs.Info("HandleNewBlock [chainName: %s, height: %d]. "+
+		"len(wrapperTransactions)=%d, len(srcTransactions)=%d, len(polyTransactions)=%d, len(dstTransactions)=%d",
+		chainName, height, len(wrapperTransactions), len(srcTransactions), len(polyTransactions), len(dstTransactions))
+	if ok {
+		if err := ccl.checkBlockHash(height, hash); err != nil {
+			return err
+		}
+	}
+	if err := ccl.saveEvents(wrapperTransactions, srcTransactions, polyTransactions, dstTransactions); err != nil {
+		return err
+	}
+	if ok {
+		ccl.blocks.setHash(height, hash, parentHash)
+	}
+	return nil
+}
+
+func (ccl *CrossChainListen) handleRange(chainName string, rangeHandle ChainRangeHandle, start, end uint64) error {
+	_, parentHash, ok, err := ccl.blockHash(start)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if err := ccl.checkParent(start, parentHash); err != nil {
+			return err
+		}
+	}
+	hash, endParentHash, ok, err := ccl.blockHash(end)
+	if err != nil {
+		return err
+	}
+	wrapperTransactions, srcTransactions, polyTransactions, dstTransactions, _, _, err := rangeHandle.HandleBlockRange(start, end)
+	if err != nil {
+		return err
+	}
+	logs.Info("HandleBlockRange [chainName: %s, height: %d-%d]. "+
+		"len(wrapperTransactions)=%d, len(srcTransactions)=%d, len(polyTransactions)=%d, len(dstTransactions)=%d",
+		chainName, start, end, len(wrapperTransactions), len(srcTransactions), len(polyTransactions), len(dstTransactions))
+	if ok {
+		if err := ccl.checkBlockHash(end, hash); err != nil {
+			return err
+		}
+	}
+	if err := ccl.saveEvents(wrapperTransactions, srcTransactions, polyTransactions, dstTransactions); err != nil {
+		return err
+	}
+	if ok {
+		ccl.blocks.setHash(end, hash, endParentHash)
+	}
+	return nil
+}
+
+// checkBlockHash fails when the block was replaced while its events were
+// fetched, so the events are fetched again from the new block instead of
+// being recorded under the hash read before.
+func (ccl *CrossChainListen) checkBlockHash(height uint64, hash string) error {
+	current, _, _, err := ccl.blockHash(height)
+	if err != nil {
+		return err
+	}
+	if current != hash {
+		return fmt.Errorf("chain %s block %d changed from %s to %s while handling", ccl.handle.GetChainName(), height, hash, current)
+	}
+	return nil
+}
+
+func (ccl *CrossChainListen) saveEvents(wrapperTransactions []*models.WrapperTransaction, srcTransactions []*models.SrcTransaction, polyTransactions []*models.PolyTransaction, dstTransactions []*models.DstTransaction) error {
+	err := ccl.db.UpdateEvents(wrapperTransactions, srcTransactions, polyTransactions, dstTransactions)
+	if err != nil {
+		return err
+	}
+	if !ccl.config.Backup {
+		go ccl.checkLargeTransaction(srcTransactions)
+		ccl.publishTransactionStatus(polyTransactions, dstTransactions)
+	}
+	return nil
+}
+
+// publishTransactionStatus pushes the new poly and destination legs to the
+// status stream, the effect publishes the wrapper status once it catches up.
+func (ccl *CrossChainListen) publishTransactionStatus(polyTransactions []*models.PolyTransaction, dstTransactions []*models.DstTransaction) {
+	if cacheRedis.Redis == nil || len(polyTransactions)+len(dstTransactions) == 0 {
+		return
+	}
+	events := make([]*models.TransactionStatusEvent, 0, len(polyTransactions)+len(dstTransactions))
+	for _, tx := range polyTransactions {
+		events = append(events, &models.TransactionStatusEvent{
+			Hash:       tx.SrcHash,
+			PolyHash:   tx.Hash,
+			SrcChainId: tx.SrcChainId,
+			DstChainId: tx.DstChainId,
+			Status:     basedef.STATE_POLY_CONFIRMED,
+			Time:       tx.Time,
+		})
+	}
+	for _, tx := range dstTransactions {
+		event := &models.TransactionStatusEvent{
+			PolyHash:   tx.PolyHash,
+			DstHash:    tx.Hash,
+			SrcChainId: tx.SrcChainId,
+			DstChainId: tx.ChainId,
+			Status:     basedef.STATE_DESTINATION_DONE,
+			Time:       tx.Time,
+		}
+		if tx.DstTransfer != nil {
+			event.DstUser = tx.DstTransfer.To
+		}
+		events = append(events, event)
+	}
+	if err := cacheRedis.Redis.PublishTransactionStatus(events); err != nil {
+		logs.Error("chain %s publish transaction status err: %v", ccl.handle.GetChainName(), err)
+	}
+}
@@ -0,0 +1,124 @@
+package crosschainlisten
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"poly-bridge/conf"
+	"poly-bridge/crosschaindao"
+	"poly-bridge/models"
+)
+
+type testChainHandle struct {
+	ChainHandle
+	mutex sync.Mutex
+	fails map[uint64]int
+	calls map[uint64]int
+}
+
+func (h *testChainHandle) HandleNewBlock(height uint64) ([]*models.WrapperTransaction, []*models.SrcTransaction, []*models.PolyTransaction, []*models.DstTransaction, int, int, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.calls[height]++
+	if h.fails[height] > 0 {
+		h.fails[height]--
+		return nil, nil, nil, nil, 0, 0, fmt.Errorf("height %d is not ready", height)
+	}
+	return nil, nil, nil, nil, 0, 0, nil
+}
+
+func (h *testChainHandle) GetBatchSize() uint64 {
+	return 4
+}
+
+type testChainDao struct {
+	crosschaindao.CrossChainDao
+	heights []uint64
+}
+
+func (dao *testChainDao) UpdateEvents([]*models.WrapperTransaction, []*models.SrcTransaction, []*models.PolyTransaction, []*models.DstTransaction) error {
+	return nil
+}
+
+func (dao *testChainDao) UpdateChain(chain *models.Chain) error {
+	dao.heights = append(dao.heights, chain.Height)
+	return nil
+}
+
+func TestHandleBlocksRetry(t *testing.T) {
+	handle := &testChainHandle{fails: map[uint64]int{103: 1}, calls: make(map[uint64]int)}
+	dao := new(testChainDao)
+	ccl := &CrossChainListen{handle: handle, db: dao, config: &conf.Config{Backup: true}, blocks: newBlockTracker(0)}
+	chain := &models.Chain{ChainId: 2, Height: 100}
+	if err := ccl.handleBlocks(context.Background(), chain, 110); err != nil {
+		t.Fatalf("handleBlocks err: %v", err)
+	}
+	if chain.Height != 110 {
+		t.Errorf("chain height = %d, want 110", chain.Height)
+	}
+	for height := uint64(101); height <= 110; height++ {
+		want := 1
+		if height == 103 {
+			want = 2
+		}
+		if handle.calls[height] != want {
+			t.Errorf("height %d handled %d times, want %d", height, handle.calls[height], want)
+		}
+	}
+	for i := 1; i < len(dao.heights); i++ {
+		if dao.heights[i] <= dao.heights[i-1] {
+			t.Errorf("committed heights are not increasing: %v", dao.heights)
+		}
+	}
+}
+
+type testRangeHandle struct {
+	testChainHandle
+	ranges [][2]uint64
+}
+
+func (h *testRangeHandle) GetRangeSize() uint64 {
+	return 4
+}
+
+func (h *testRangeHandle) HandleBlockRange(start, end uint64) ([]*models.WrapperTransaction, []*models.SrcTransaction, []*models.PolyTransaction, []*models.DstTransaction, int, int, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.ranges = append(h.ranges, [2]uint64{start, end})
+	return nil, nil, nil, nil, 0, 0, nil
+}
+
+func TestHandleBlocksRange(t *testing.T) {
+	handle := &testRangeHandle{testChainHandle: testChainHandle{calls: make(map[uint64]int)}}
+	dao := new(testChainDao)
+	ccl := &CrossChainListen{handle: handle, db: dao, config: &conf.Config{Backup: true}, blocks: newBlockTracker(0)}
+	chain := &models.Chain{ChainId: 2, Height: 100}
+	if err := ccl.handleBlocks(context.Background(), chain, 110); err != nil {
+		t.Fatalf("handleBlocks err: %v", err)
+	}
+	if chain.Height != 110 {
+		t.Errorf("chain height = %d, want 110", chain.Height)
+	}
+	if len(handle.calls) != 0 {
+		t.Errorf("blocks handled one by one: %v", handle.calls)
+	}
+	covered := make(map[uint64]bool)
+	for _, r := range handle.ranges {
+		for height := r[0]; height <= r[1]; height++ {
+			if covered[height] {
+				t.Errorf("height %d handled twice", height)
+			}
+			covered[height] = true
+		}
+	}
+	for height := uint64(101); height <= 110; height++ {
+		if !covered[height] {
+			t.Errorf("height %d not handled", height)
+		}
+	}
+	if len(handle.ranges) != 3 {
+		t.Errorf("ranges = %v, want 3 windows", handle.ranges)
+	}
+}
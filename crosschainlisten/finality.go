@@ -0,0 +1,110 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package crosschainlisten
+
+import (
+	"fmt"
+
+	"poly-bridge/basedef"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+)
+
+// ChainFinalityHandle is implemented by chain handles whose node reports the
+// final height itself, for the finalized and l1 finality.
+type ChainFinalityHandle interface {
+	GetFinalizedHeight(finality string) (uint64, error)
+}
+
+// finalizedHeight returns the highest block that can not be reverted by the
+// finality of the chain, latest is the height of the chain.
+func (ccl *CrossChainListen) finalizedHeight(latest uint64) (uint64, error) {
+	chainConfig := ccl.config.GetChainListenConfig(ccl.handle.GetChainId())
+	if chainConfig == nil {
+		return 0, fmt.Errorf("missing chain %d listen config", ccl.handle.GetChainId())
+	}
+	switch finality := chainConfig.GetFinality(); finality {
+	case basedef.FINALITY_INSTANT:
+		return latest, nil
+	case basedef.FINALITY_DEPTH:
+		return depthHeight(latest, chainConfig.GetFinalityDepth()), nil
+	default:
+		finalityHandle, ok := ccl.handle.(ChainFinalityHandle)
+		if !ok {
+			return 0, fmt.Errorf("finality %s is not supported", finality)
+		}
+		height, err := finalityHandle.GetFinalizedHeight(finality)
+		if finality != basedef.FINALITY_L1 {
+			return height, err
+		}
+		// nodes without the safe tag are followed by the depth
+		if err != nil {
+			if !ccl.finalityFallback {
+				logs.Warn("ListenChain - chain %s get l1 height err: %v, use finality depth %d", ccl.handle.GetChainName(), err, chainConfig.GetFinalityDepth())
+				ccl.finalityFallback = true
+			}
+			return depthHeight(latest, chainConfig.GetFinalityDepth()), nil
+		}
+		if ccl.finalityFallback {
+			logs.Info("ListenChain - chain %s l1 height is available again", ccl.handle.GetChainName())
+			ccl.finalityFallback = false
+		}
+		return height, nil
+	}
+}
+
+func depthHeight(latest uint64, depth uint64) uint64 {
+	if latest < depth {
+		return 0
+	}
+	return latest - depth
+}
+
+// updateFinality sets the confirmations of the indexed transactions which are
+// final each time the finalized height moves. The blocks of the reorg depth
+// below the last finalized height are checked again, for the transactions
+// saved late or by a reindex, older history is left as it is.
+func (ccl *CrossChainListen) updateFinality(chain *models.Chain, latest uint64) {
+	finalized, err := ccl.finalizedHeight(latest)
+	if err != nil {
+		logs.Error("ListenChain - chain %s get finalized height err: %v", ccl.handle.GetChainName(), err)
+		return
+	}
+	if finalized > chain.Height {
+		finalized = chain.Height
+	}
+	if finalized <= chain.FinalizedHeight {
+		return
+	}
+	start := chain.FinalizedHeight
+	if start == 0 {
+		start = finalized
+	}
+	if chainConfig := ccl.config.GetChainListenConfig(chain.ChainId); chainConfig != nil {
+		start = depthHeight(start, chainConfig.GetReorgDepth())
+	}
+	if err := ccl.db.UpdateConfirmations(chain.ChainId, start, finalized, latest); err != nil {
+		logs.Error("UpdateConfirmations [chainId:%d, height:%d-%d] err %v", chain.ChainId, start, finalized, err)
+		return
+	}
+	chain.FinalizedHeight = finalized
+	if err := ccl.db.UpdateChain(chain); err != nil {
+		logs.Error("UpdateChain [chainId:%d, height:%d] err %v", chain.ChainId, chain.Height, err)
+	}
+}
@@ -0,0 +1,95 @@
+package crosschainlisten
+
+import (
+	"fmt"
+	"testing"
+
+	"poly-bridge/basedef"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+)
+
+type testFinalityHandle struct {
+	ChainHandle
+	chainId uint64
+}
+
+func (h *testFinalityHandle) GetChainId() uint64 {
+	return h.chainId
+}
+
+func (h *testFinalityHandle) GetChainName() string {
+	return "test"
+}
+
+// testL1Handle is a node that rejects the safe tag until safe is set.
+type testL1Handle struct {
+	testFinalityHandle
+	safe uint64
+}
+
+func (h *testL1Handle) GetFinalizedHeight(finality string) (uint64, error) {
+	if h.safe == 0 {
+		return 0, fmt.Errorf("unknown block tag safe")
+	}
+	return h.safe, nil
+}
+
+type testFinalityDao struct {
+	testChainDao
+	ranges [][3]uint64
+}
+
+func (dao *testFinalityDao) UpdateConfirmations(chainId uint64, start uint64, finalized uint64, latest uint64) error {
+	dao.ranges = append(dao.ranges, [3]uint64{start, finalized, latest})
+	return nil
+}
+
+func TestUpdateFinality(t *testing.T) {
+	chainConfig := &conf.ChainListenConfig{ChainId: 2, Finality: basedef.FINALITY_DEPTH, Defer: 1, FinalityDepth: 12, ReorgDepth: 10}
+	dao := new(testFinalityDao)
+	ccl := &CrossChainListen{
+		handle: &testFinalityHandle{chainId: 2},
+		db:     dao,
+		config: &conf.Config{ChainListenConfig: []*conf.ChainListenConfig{chainConfig}},
+	}
+	chain := &models.Chain{ChainId: 2, Height: 99, FinalizedHeight: 80}
+	ccl.updateFinality(chain, 100)
+	if chain.FinalizedHeight != 88 {
+		t.Errorf("finalized height = %d, want 88", chain.FinalizedHeight)
+	}
+	ccl.updateFinality(chain, 100)
+	if len(dao.ranges) != 1 || dao.ranges[0] != [3]uint64{70, 88, 100} {
+		t.Errorf("confirmation updates = %v", dao.ranges)
+	}
+
+	chainConfig.Finality = basedef.FINALITY_INSTANT
+	ccl.updateFinality(chain, 100)
+	if chain.FinalizedHeight != 99 {
+		t.Errorf("finalized height = %d, want the indexed height 99", chain.FinalizedHeight)
+	}
+
+	chainConfig.Finality = basedef.FINALITY_FINALIZED
+	if _, err := ccl.finalizedHeight(100); err == nil {
+		t.Errorf("finalized finality should need the node")
+	}
+}
+
+func TestL1FinalityFallback(t *testing.T) {
+	chainConfig := &conf.ChainListenConfig{ChainId: 2, Finality: basedef.FINALITY_L1, FinalityDepth: 12}
+	handle := &testL1Handle{testFinalityHandle: testFinalityHandle{chainId: 2}}
+	ccl := &CrossChainListen{
+		handle: handle,
+		db:     new(testFinalityDao),
+		config: &conf.Config{ChainListenConfig: []*conf.ChainListenConfig{chainConfig}},
+	}
+	height, err := ccl.finalizedHeight(100)
+	if err != nil || height != 88 {
+		t.Errorf("finalized height without the safe tag = %d, %v, want the depth 88", height, err)
+	}
+	handle.safe = 95
+	height, err = ccl.finalizedHeight(100)
+	if err != nil || height != 95 {
+		t.Errorf("finalized height = %d, %v, want the safe height 95", height, err)
+	}
+}
@@ -18,6 +18,7 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"poly-bridge/basedef"
@@ -72,7 +73,7 @@ func TestEthereumListen_StartStop(t *testing.T) {
 	}
 	chainHandle := crosschainlisten.NewChainHandle(ethListenConfig)
 	chainListen := crosschainlisten.NewCrossChainListen(chainHandle, dao, config)
-	chainListen.Start()
+	chainListen.Start(context.Background())
 	time.Sleep(15 * time.Second)
 	//chainListen.Stop()
 }
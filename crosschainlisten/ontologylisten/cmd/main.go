@@ -18,6 +18,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/beego/beego/v2/core/logs"
@@ -121,7 +122,7 @@ func startServer(ctx *cli.Context) {
 		panic("chain handler is invalid")
 	}
 	chainListen = crosschainlisten.NewCrossChainListen(chainHandler, db, config)
-	chainListen.Start()
+	chainListen.Start(context.Background())
 }
 
 func waitSignal() os.Signal {
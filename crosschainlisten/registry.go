@@ -0,0 +1,68 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package crosschainlisten
+
+import (
+	"poly-bridge/basedef"
+	"poly-bridge/conf"
+	"poly-bridge/crosschainlisten/ethereumlisten"
+	"poly-bridge/crosschainlisten/neo3listen"
+	"poly-bridge/crosschainlisten/neolisten"
+	"poly-bridge/crosschainlisten/o3listen"
+	"poly-bridge/crosschainlisten/ontologylisten"
+	"poly-bridge/crosschainlisten/polylisten"
+	"poly-bridge/crosschainlisten/switcheolisten"
+	"poly-bridge/crosschainlisten/zilliqalisten"
+)
+
+type ChainHandleFactory func(chainListenConfig *conf.ChainListenConfig) ChainHandle
+
+var chainHandleFactories = make(map[string]ChainHandleFactory)
+
+// RegisterChainHandle sets the factory of the chain handles of a chain family,
+// chains are mapped to a family by the Family of their ChainListenConfig.
+func RegisterChainHandle(family string, factory ChainHandleFactory) {
+	chainHandleFactories[family] = factory
+}
+
+func init() {
+	RegisterChainHandle(basedef.FAMILY_POLY, func(cfg *conf.ChainListenConfig) ChainHandle {
+		return polylisten.NewPolyChainListen(cfg)
+	})
+	RegisterChainHandle(basedef.FAMILY_EVM, func(cfg *conf.ChainListenConfig) ChainHandle {
+		return ethereumlisten.NewEthereumChainListen(cfg)
+	})
+	RegisterChainHandle(basedef.FAMILY_O3, func(cfg *conf.ChainListenConfig) ChainHandle {
+		return o3listen.NewO3ChainListen(cfg)
+	})
+	RegisterChainHandle(basedef.FAMILY_NEO, func(cfg *conf.ChainListenConfig) ChainHandle {
+		return neolisten.NewNeoChainListen(cfg)
+	})
+	RegisterChainHandle(basedef.FAMILY_NEO3, func(cfg *conf.ChainListenConfig) ChainHandle {
+		return neo3listen.NewNeo3ChainListen(cfg)
+	})
+	RegisterChainHandle(basedef.FAMILY_ONTOLOGY, func(cfg *conf.ChainListenConfig) ChainHandle {
+		return ontologylisten.NewOntologyChainListen(cfg)
+	})
+	RegisterChainHandle(basedef.FAMILY_SWITCHEO, func(cfg *conf.ChainListenConfig) ChainHandle {
+		return switcheolisten.NewSwitcheoChainListen(cfg)
+	})
+	RegisterChainHandle(basedef.FAMILY_ZILLIQA, func(cfg *conf.ChainListenConfig) ChainHandle {
+		return zilliqalisten.NewZilliqaChainListen(cfg)
+	})
+}
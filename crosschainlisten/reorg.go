@@ -0,0 +1,214 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package crosschainlisten
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"poly-bridge/basedef"
+	"poly-bridge/common"
+	"poly-bridge/conf"
+
+	"github.com/beego/beego/v2/core/logs"
+)
+
+// ChainBlockHandle is implemented by chain handles whose chain can reorganize,
+// so the listener can compare the block hashes it indexed against the node.
+type ChainBlockHandle interface {
+	GetBlockHash(height uint64) (hash string, parentHash string, err error)
+}
+
+type indexedBlock struct {
+	hash       string
+	parentHash string
+}
+
+// blockTracker keeps the hashes of the last indexed blocks of a chain. The
+// transactions of orphaned blocks are found in the database by height, so
+// they are removed even when they were indexed before a restart.
+type blockTracker struct {
+	depth  uint64
+	blocks map[uint64]*indexedBlock
+	mutex  sync.Mutex
+}
+
+func newBlockTracker(depth uint64) *blockTracker {
+	if depth == 0 {
+		depth = basedef.DEFAULT_REORG_DEPTH
+	}
+	return &blockTracker{
+		depth:  depth,
+		blocks: make(map[uint64]*indexedBlock),
+	}
+}
+
+func (bt *blockTracker) setHash(height uint64, hash, parentHash string) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	bt.blocks[height] = &indexedBlock{hash: hash, parentHash: parentHash}
+}
+
+func (bt *blockTracker) getHash(height uint64) (string, bool) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	block, ok := bt.blocks[height]
+	if !ok || block.hash == "" {
+		return "", false
+	}
+	return block.hash, true
+}
+
+// prune drops the records that are older than the tracked depth.
+func (bt *blockTracker) prune(height uint64) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	for h := range bt.blocks {
+		if h+bt.depth < height {
+			delete(bt.blocks, h)
+		}
+	}
+}
+
+// rollback forgets every block above the fork height.
+func (bt *blockTracker) rollback(forkHeight uint64) {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	for h := range bt.blocks {
+		if h > forkHeight {
+			delete(bt.blocks, h)
+		}
+	}
+}
+
+// checkReorg compares the parent hash of the next block with the hash recorded
+// for the current height. On mismatch it walks back to the fork point, removes
+// the orphaned events and returns the height indexing should resume from. A
+// reorg deeper than the recorded blocks raises an alarm and rolls back the
+// whole reorg depth.
+func (ccl *CrossChainListen) checkReorg(height uint64) (uint64, error) {
+	blockHandle, ok := ccl.handle.(ChainBlockHandle)
+	if !ok {
+		return height, nil
+	}
+	recorded, ok := ccl.blocks.getHash(height)
+	if !ok {
+		return height, nil
+	}
+	_, parentHash, err := blockHandle.GetBlockHash(height + 1)
+	if err != nil {
+		return height, err
+	}
+	if parentHash == "" || parentHash == recorded {
+		return height, nil
+	}
+	logs.Warn("chain %s reorg detected at height %d, recorded hash: %s, parent hash of next block: %s",
+		ccl.handle.GetChainName(), height, recorded, parentHash)
+	forkHeight := height
+	for forkHeight > 0 {
+		forkHeight--
+		recorded, ok = ccl.blocks.getHash(forkHeight)
+		if !ok {
+			if forkHeight+ccl.blocks.depth <= height {
+				logs.Error("chain %s reorg at height %d is deeper than %d blocks, rollback to height %d",
+					ccl.handle.GetChainName(), height, ccl.blocks.depth, forkHeight)
+				ccl.sendDeepReorgDingAlarm(height, forkHeight)
+				break
+			}
+			continue
+		}
+		hash, _, err := blockHandle.GetBlockHash(forkHeight)
+		if err != nil {
+			return height, err
+		}
+		if hash == recorded {
+			break
+		}
+	}
+	srcHashes, polyHashes, dstHashes, err := ccl.db.GetEventHashes(ccl.handle.GetChainId(), forkHeight)
+	if err != nil {
+		return height, err
+	}
+	logs.Warn("chain %s rollback to height %d, remove src: %v, poly: %v, dst: %v",
+		ccl.handle.GetChainName(), forkHeight, srcHashes, polyHashes, dstHashes)
+	if err := ccl.db.RemoveEvents(srcHashes, polyHashes, dstHashes); err != nil {
+		return height, err
+	}
+	ccl.blocks.rollback(forkHeight)
+	return forkHeight, nil
+}
+
+func (ccl *CrossChainListen) sendDeepReorgDingAlarm(height, forkHeight uint64) {
+	if conf.GlobalConfig == nil || conf.GlobalConfig.BotConfig == nil || conf.GlobalConfig.BotConfig.DingUrl == "" {
+		return
+	}
+	title := fmt.Sprintf("chain %s reorg deeper than %d blocks", ccl.handle.GetChainName(), ccl.blocks.depth)
+	body := fmt.Sprintf("## %s\n- Height: %d\n- Rollback to: %d\n- Time: %s\n",
+		title, height, forkHeight, time.Now().Format("2006-01-02 15:04:05"))
+	if err := common.PostDingCard(title, body, []map[string]string{}, conf.GlobalConfig.BotConfig.DingUrl); err != nil {
+		logs.Error("chain %s send reorg alarm err: %v", ccl.handle.GetChainName(), err)
+	}
+}
+
+// seedBlocks records the hashes of the blocks up to the height when it has no
+// hash, like after a restart, so a later reorg of the blocks indexed before is
+// detected. A reorg while the listen was not running can not be detected.
+func (ccl *CrossChainListen) seedBlocks(height uint64) {
+	blockHandle, ok := ccl.handle.(ChainBlockHandle)
+	if !ok {
+		return
+	}
+	if _, ok := ccl.blocks.getHash(height); ok {
+		return
+	}
+	for h := height; h > 0 && h+ccl.blocks.depth > height; h-- {
+		hash, parentHash, err := blockHandle.GetBlockHash(h)
+		if err != nil {
+			logs.Error("chain %s get block hash on height %d err: %v", ccl.handle.GetChainName(), h, err)
+			return
+		}
+		ccl.blocks.setHash(h, hash, parentHash)
+	}
+}
+
+// blockHash reads the hash of the block, ok is false when the chain has no
+// block hashes or the listen is a backup, which does not check reorgs.
+func (ccl *CrossChainListen) blockHash(height uint64) (hash string, parentHash string, ok bool, err error) {
+	blockHandle, ok := ccl.handle.(ChainBlockHandle)
+	if !ok || ccl.config.Backup {
+		return "", "", false, nil
+	}
+	hash, parentHash, err = blockHandle.GetBlockHash(height)
+	return hash, parentHash, err == nil, err
+}
+
+// checkParent fails when the parent hash of the block is not the hash recorded
+// below it. Only the last block of a range is recorded, so a reorg inside an
+// indexed range is only seen by the range after it, the reorg is then handled
+// by checkReorg from the committed height.
+func (ccl *CrossChainListen) checkParent(height uint64, parentHash string) error {
+	if height == 0 || parentHash == "" {
+		return nil
+	}
+	recorded, ok := ccl.blocks.getHash(height - 1)
+	if ok && parentHash != recorded {
+		return fmt.Errorf("chain %s parent hash %s of height %d is not the indexed hash %s", ccl.handle.GetChainName(), parentHash, height, recorded)
+	}
+	return nil
+}
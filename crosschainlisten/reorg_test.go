@@ -0,0 +1,202 @@
+package crosschainlisten
+
+import (
+	"context"
+	"fmt"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestBlockTrackerRollback(t *testing.T) {
+	bt := newBlockTracker(10)
+	for h := uint64(100); h <= 105; h++ {
+		bt.setHash(h, "hash", "parent")
+	}
+	bt.rollback(102)
+	for h := uint64(100); h <= 105; h++ {
+		_, ok := bt.getHash(h)
+		if ok != (h <= 102) {
+			t.Errorf("height %d kept = %v", h, ok)
+		}
+	}
+}
+
+func TestBlockTrackerPrune(t *testing.T) {
+	bt := newBlockTracker(2)
+	for h := uint64(1); h <= 5; h++ {
+		bt.setHash(h, "hash", "parent")
+	}
+	bt.prune(5)
+	for h := uint64(1); h <= 5; h++ {
+		_, ok := bt.getHash(h)
+		if ok != (h >= 3) {
+			t.Errorf("height %d kept = %v", h, ok)
+		}
+	}
+}
+
+// testReorgHandle is a chain whose blocks from forkHeight are replaced once
+// forked is set, each range has a destination transaction at its first block.
+type testReorgHandle struct {
+	testRangeHandle
+	forkHeight uint64
+	forked     bool
+}
+
+func (h *testReorgHandle) GetChainName() string {
+	return "test"
+}
+
+func (h *testReorgHandle) GetChainId() uint64 {
+	return 2
+}
+
+func (h *testReorgHandle) hash(height uint64) string {
+	if h.forked && height >= h.forkHeight {
+		return fmt.Sprintf("fork-%d", height)
+	}
+	return fmt.Sprintf("main-%d", height)
+}
+
+func (h *testReorgHandle) GetBlockHash(height uint64) (string, string, error) {
+	return h.hash(height), h.hash(height - 1), nil
+}
+
+func (h *testReorgHandle) HandleBlockRange(start, end uint64) ([]*models.WrapperTransaction, []*models.SrcTransaction, []*models.PolyTransaction, []*models.DstTransaction, int, int, error) {
+	return nil, nil, nil, []*models.DstTransaction{{Hash: fmt.Sprintf("d%d", start), Height: start}}, 0, 0, nil
+}
+
+// testReorgDao keeps the destination transactions by height like the
+// database, so a rollback removes them without the tracker.
+type testReorgDao struct {
+	testChainDao
+	mutex   sync.Mutex
+	events  map[string]uint64
+	removed []string
+}
+
+func (dao *testReorgDao) UpdateEvents(wrapperTransactions []*models.WrapperTransaction, srcTransactions []*models.SrcTransaction, polyTransactions []*models.PolyTransaction, dstTransactions []*models.DstTransaction) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	if dao.events == nil {
+		dao.events = make(map[string]uint64)
+	}
+	for _, tx := range dstTransactions {
+		dao.events[tx.Hash] = tx.Height
+	}
+	return nil
+}
+
+func (dao *testReorgDao) GetEventHashes(chainId uint64, height uint64) ([]string, []string, []string, error) {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	dstHashes := make([]string, 0)
+	for hash, h := range dao.events {
+		if h > height {
+			dstHashes = append(dstHashes, hash)
+		}
+	}
+	return nil, nil, dstHashes, nil
+}
+
+func (dao *testReorgDao) RemoveEvents(srcHashes []string, polyHashes []string, dstHashes []string) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	for _, hash := range dstHashes {
+		delete(dao.events, hash)
+	}
+	dao.removed = append(dao.removed, dstHashes...)
+	return nil
+}
+
+func TestReorgInRange(t *testing.T) {
+	handle := &testReorgHandle{forkHeight: 106}
+	dao := new(testReorgDao)
+	ccl := &CrossChainListen{handle: handle, db: dao, config: &conf.Config{}, blocks: newBlockTracker(8)}
+	chain := &models.Chain{ChainId: 2, Height: 100}
+	if err := ccl.handleBlocks(context.Background(), chain, 112); err != nil {
+		t.Fatalf("handleBlocks err: %v", err)
+	}
+
+	// the block 106 in the middle of the range 105-108 is replaced
+	handle.forked = true
+	_, parentHash, _ := handle.GetBlockHash(109)
+	if err := ccl.checkParent(109, parentHash); err == nil {
+		t.Errorf("range after the reorg is not rejected")
+	}
+	forkHeight, err := ccl.checkReorg(112)
+	if err != nil {
+		t.Fatalf("checkReorg err: %v", err)
+	}
+	if forkHeight != 104 {
+		t.Errorf("fork height = %d, want the last range end 104", forkHeight)
+	}
+	sort.Strings(dao.removed)
+	if len(dao.removed) != 2 || dao.removed[0] != "d105" || dao.removed[1] != "d109" {
+		t.Errorf("removed = %v, want d105 and d109", dao.removed)
+	}
+
+	chain.Height = forkHeight
+	if err := ccl.handleBlocks(context.Background(), chain, 112); err != nil {
+		t.Fatalf("handleBlocks after reorg err: %v", err)
+	}
+	if forkHeight, err = ccl.checkReorg(112); err != nil || forkHeight != 112 {
+		t.Errorf("checkReorg after reindex = %d, %v", forkHeight, err)
+	}
+}
+
+func TestReorgAfterRestart(t *testing.T) {
+	handle := &testReorgHandle{forkHeight: 106}
+	dao := new(testReorgDao)
+	ccl := &CrossChainListen{handle: handle, db: dao, config: &conf.Config{}, blocks: newBlockTracker(8)}
+	chain := &models.Chain{ChainId: 2, Height: 100}
+	if err := ccl.handleBlocks(context.Background(), chain, 112); err != nil {
+		t.Fatalf("handleBlocks err: %v", err)
+	}
+
+	// the restarted listen records the hashes of the indexed blocks again
+	ccl.blocks = newBlockTracker(8)
+	ccl.seedBlocks(112)
+	handle.forked = true
+	forkHeight, err := ccl.checkReorg(112)
+	if err != nil {
+		t.Fatalf("checkReorg err: %v", err)
+	}
+	if forkHeight != 105 {
+		t.Errorf("fork height = %d, want 105", forkHeight)
+	}
+	sort.Strings(dao.removed)
+	if len(dao.removed) != 1 || dao.removed[0] != "d109" {
+		t.Errorf("removed = %v, want d109", dao.removed)
+	}
+}
+
+func TestDeepReorg(t *testing.T) {
+	handle := &testReorgHandle{forkHeight: 90}
+	dao := new(testReorgDao)
+	ccl := &CrossChainListen{handle: handle, db: dao, config: &conf.Config{}, blocks: newBlockTracker(8)}
+	chain := &models.Chain{ChainId: 2, Height: 100}
+	if err := ccl.handleBlocks(context.Background(), chain, 112); err != nil {
+		t.Fatalf("handleBlocks err: %v", err)
+	}
+
+	// the fork is below every recorded block, the whole depth is rolled back
+	handle.forked = true
+	forkHeight, err := ccl.checkReorg(112)
+	if err != nil {
+		t.Fatalf("checkReorg err: %v", err)
+	}
+	if forkHeight+8 > 112 {
+		t.Errorf("fork height = %d, want at most 104", forkHeight)
+	}
+	chain.Height = forkHeight
+	if err := ccl.handleBlocks(context.Background(), chain, 112); err != nil {
+		t.Fatalf("handleBlocks after reorg err: %v", err)
+	}
+	if forkHeight, err = ccl.checkReorg(112); err != nil || forkHeight != 112 {
+		t.Errorf("checkReorg after reindex = %d, %v", forkHeight, err)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package crosschainlisten
+
+import (
+	"context"
+
+	"github.com/beego/beego/v2/core/logs"
+)
+
+// ChainSubscribeHandle is implemented by chain handles that can push the
+// heights of new blocks and events. The listener handles the blocks as soon as
+// a height arrives and still polls every listen slot to fill the gaps. A nil
+// channel without error means the chain has no subscription configured, the
+// channel is closed when the subscription is lost.
+type ChainSubscribeHandle interface {
+	Subscribe(ctx context.Context) (<-chan uint64, error)
+}
+
+// subscribe opens the subscription with the context of the listenChain run, so
+// it is closed when the run returns and a restarted run opens its own.
+func (ccl *CrossChainListen) subscribe(ctx context.Context, handle ChainSubscribeHandle) <-chan uint64 {
+	heights, err := handle.Subscribe(ctx)
+	if err != nil {
+		logs.Error("ListenChain - chain %s subscribe err: %v", ccl.handle.GetChainName(), err)
+		return nil
+	}
+	return heights
+}
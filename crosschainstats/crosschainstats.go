@@ -48,7 +48,7 @@ type Stats struct {
 var ccs *Stats
 
 // Start - Do stats aggregation/calculation
-func StartCrossChainStats(server string, cfg *conf.StatsConfig, dbCfg *conf.DBConfig, ipCfg *conf.IPPortConfig, chainCfg []*conf.ChainListenConfig) {
+func StartCrossChainStats(ctx context.Context, server string, cfg *conf.StatsConfig, dbCfg *conf.DBConfig, ipCfg *conf.IPPortConfig, chainCfg []*conf.ChainListenConfig) {
 	if server != basedef.SERVER_POLY_BRIDGE {
 		panic("CrossChainStats Only runs on bridge server")
 	}
@@ -57,7 +57,7 @@ func StartCrossChainStats(server string, cfg *conf.StatsConfig, dbCfg *conf.DBCo
 	}
 
 	dao := bridgedao.NewBridgeDao(dbCfg, false)
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 	ccs = &Stats{dao: dao, cfg: cfg, Context: ctx, cancel: cancel, ipCfg: ipCfg, chainCfg: chainCfg}
 	ccs.Start()
 }
@@ -66,12 +66,14 @@ func StartCrossChainStats(server string, cfg *conf.StatsConfig, dbCfg *conf.DBCo
 func StopCrossChainStats() {
 	if ccs != nil {
 		ccs.Stop()
+		ccs = nil
 	}
 }
 
 func (this *Stats) run(interval int64, f func() error) {
-	this.wg.Add(1)
+	defer this.wg.Done()
 	ticker := time.NewTicker(time.Second * time.Duration(interval))
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
@@ -80,26 +82,30 @@ func (this *Stats) run(interval int64, f func() error) {
 				logs.Error("stats run error%s", err)
 			}
 		case <-this.Done():
-			break
+			return
 		}
 	}
-	this.wg.Done()
+}
+
+func (this *Stats) start(interval int64, f func() error) {
+	this.wg.Add(1)
+	go this.run(interval, f)
 }
 
 func (this *Stats) Start() {
-	go this.run(this.cfg.TokenBasicStatsInterval, this.computeStats)
-	go this.run(this.cfg.TokenAmountCheckInterval, this.computeTokensStats)
-	go this.run(this.cfg.TokenStatisticInterval, this.computeTokenStatistics)
-	go this.run(this.cfg.LockTokenStatisticInterval, this.computeLockTokenStatistics)
-	go this.run(this.cfg.ChainStatisticInterval, this.computeChainStatistics)
-	go this.run(this.cfg.ChainAddressCheckInterval, this.computeChainStatisticAssets)
-	go this.run(this.cfg.AssetStatisticInterval, this.computeAssetStatistics)
-	go this.run(this.cfg.AssetAdressInterval, this.computeAssetStatisticAdress)
+	this.start(this.cfg.TokenBasicStatsInterval, this.computeStats)
+	this.start(this.cfg.TokenAmountCheckInterval, this.computeTokensStats)
+	this.start(this.cfg.TokenStatisticInterval, this.computeTokenStatistics)
+	this.start(this.cfg.LockTokenStatisticInterval, this.computeLockTokenStatistics)
+	this.start(this.cfg.ChainStatisticInterval, this.computeChainStatistics)
+	this.start(this.cfg.ChainAddressCheckInterval, this.computeChainStatisticAssets)
+	this.start(this.cfg.AssetStatisticInterval, this.computeAssetStatistics)
+	this.start(this.cfg.AssetAdressInterval, this.computeAssetStatisticAdress)
 	if this.cfg.CensusTimeLinesInterval != 0 {
-		go this.run(this.cfg.CensusTimeLinesInterval, this.censusTimeLines)
+		this.start(this.cfg.CensusTimeLinesInterval, this.censusTimeLines)
 	}
 	if this.cfg.CensusAssetLinesInterval != 0 {
-		go this.run(this.cfg.CensusAssetLinesInterval, this.censusAssetLines)
+		this.start(this.cfg.CensusAssetLinesInterval, this.censusAssetLines)
 	}
 }
 
@@ -495,13 +501,23 @@ func (this *Stats) computeAssetStatistics() (err error) {
 		if err != nil {
 			logs.Error("Failed to CalculateAssets %w", err)
 		}
+		prices := this.assetPriceSamples(assetInfos, old.TokenBasicName, tokenBasicBTC.Name)
 		for _, assetInfo := range assetInfos {
 			amount_new := decimal.NewFromBigInt(&assetInfo.Amount.Int, 0)
 			precision_new := decimal.New(int64(1), int32(assetInfo.Precision))
 			real_amount := amount_new.Div(precision_new)
-			price_new := decimal.NewFromInt(assetInfo.Price).Div(decimal.NewFromInt(basedef.PRICE_PRECISION))
+			// value the transfers at the prices of their time, or the current ones without history
+			price := assetInfo.Price
+			if historyPrice, ok := prices.PriceAt(old.TokenBasicName, assetInfo.Time); ok {
+				price = historyPrice
+			}
+			price_new := decimal.NewFromInt(price).Div(decimal.NewFromInt(basedef.PRICE_PRECISION))
 			amount_usd := real_amount.Mul(price_new)
-			amount_btc := amount_usd.Div(BTCPrice)
+			btc_price := BTCPrice
+			if historyPrice, ok := prices.PriceAt(tokenBasicBTC.Name, assetInfo.Time); ok && historyPrice > 0 {
+				btc_price = decimal.NewFromInt(historyPrice).Div(decimal.NewFromInt(basedef.PRICE_PRECISION))
+			}
+			amount_btc := amount_usd.Div(btc_price)
 
 			old.Amount = models.NewBigInt((real_amount.Mul(decimal.New(int64(100), 0)).Add(decimal.NewFromBigInt(&old.Amount.Int, 0))).BigInt())
 			old.AmountUsd = models.NewBigInt((amount_usd.Mul(decimal.New(int64(10000), 0)).Add(decimal.NewFromBigInt(&old.AmountUsd.Int, 0))).BigInt())
@@ -520,6 +536,33 @@ func (this *Stats) computeAssetStatistics() (err error) {
 	return nil
 }
 
+// assetPriceSamples returns the kept prices of the token basics over the time
+// of the asset infos.
+func (this *Stats) assetPriceSamples(assetInfos []*models.AssetInfo, names ...string) models.TokenPriceSamples {
+	prices := make(models.TokenPriceSamples)
+	if len(assetInfos) == 0 {
+		return prices
+	}
+	from, to := assetInfos[0].Time, assetInfos[0].Time
+	for _, assetInfo := range assetInfos {
+		if assetInfo.Time < from {
+			from = assetInfo.Time
+		}
+		if assetInfo.Time > to {
+			to = assetInfo.Time
+		}
+	}
+	for _, name := range names {
+		histories, err := this.dao.GetTokenPriceHistory(name, from, to)
+		if err != nil {
+			logs.Error("Failed to GetTokenPriceHistory of %s err: %v", name, err)
+			continue
+		}
+		prices[name] = histories
+	}
+	return prices
+}
+
 func (this *Stats) computeAssetStatisticAdress() (err error) {
 	logs.Info("start computeAssetStatisticAdress")
 	newAssetAdresses, err := this.dao.CalculateAssetAdress()
@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"poly-bridge/basedef"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Dialector returns the gorm dialector of the driver selected in the db config,
+// mysql is used when no driver is configured.
+func Dialector(dbCfg *conf.DBConfig) gorm.Dialector {
+	switch strings.ToLower(dbCfg.Driver) {
+	case basedef.DB_DRIVER_POSTGRES:
+		host, port := dbCfg.URL, "5432"
+		if i := strings.LastIndex(dbCfg.URL, ":"); i >= 0 {
+			host, port = dbCfg.URL[:i], dbCfg.URL[i+1:]
+		}
+		return postgres.Open(fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			host, port, dbCfg.User, dbCfg.Password, dbCfg.Scheme))
+	case basedef.DB_DRIVER_SQLITE:
+		dsn := dbCfg.URL
+		if !strings.Contains(dsn, "?") {
+			// listeners write concurrently, wait for the lock instead of failing
+			dsn += "?_busy_timeout=5000"
+		}
+		return sqlite.Open(dsn)
+	default:
+		return mysql.Open(dbCfg.User + ":" + dbCfg.Password + "@tcp(" + dbCfg.URL + ")/" +
+			dbCfg.Scheme + "?charset=utf8")
+	}
+}
+
+// Open connects to the configured database and migrates the schema when
+// AutoMigrate is set.
+func Open(dbCfg *conf.DBConfig, config *gorm.Config) (*gorm.DB, error) {
+	db, err := gorm.Open(Dialector(dbCfg), config)
+	if err != nil {
+		return nil, err
+	}
+	if dbCfg.AutoMigrate {
+		if err := Migrate(db); err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// Models lists every table of the models package.
+func Models() []interface{} {
+	return []interface{}{
+		&models.Chain{},
+		&models.ChainFee{},
+		&models.ChainStatistic{},
+		&models.AssetStatistic{},
+		&models.LockTokenStatistic{},
+		&models.TokenBasic{},
+		&models.Token{},
+		&models.TokenMap{},
+		&models.TokenStatistic{},
+		&models.PriceMarket{},
+		&models.TokenPriceHistory{},
+		&models.TimeStatistic{},
+		&models.NFTProfile{},
+		&models.NftUser{},
+		&models.WrapperTransaction{},
+		&models.SrcTransaction{},
+		&models.SrcTransfer{},
+		&models.SrcSwap{},
+		&models.PolyTransaction{},
+		&models.DstTransaction{},
+		&models.DstTransfer{},
+		&models.DstSwap{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.WebhookDeadLetter{},
+	}
+}
+
+// integerWidth matches the display width of the mysql integer types of the
+// models, like bigint(20), which postgres does not accept.
+var integerWidth = regexp.MustCompile(`(?i)^(tinyint|smallint|mediumint|int|integer|bigint)\(\d+\)`)
+
+// Migrate creates or updates the tables of the models. The column types of the
+// models are the mysql ones, other databases get them without display width.
+func Migrate(db *gorm.DB) error {
+	if db.Dialector.Name() != basedef.DB_DRIVER_MYSQL {
+		for _, model := range Models() {
+			// the parsed schema is cached by gorm and reused by AutoMigrate
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(model); err != nil {
+				return err
+			}
+			for _, field := range stmt.Schema.Fields {
+				field.DataType = schema.DataType(integerWidth.ReplaceAllString(string(field.DataType), "$1"))
+			}
+		}
+	}
+	return db.AutoMigrate(Models()...)
+}
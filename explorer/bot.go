@@ -28,6 +28,7 @@ import (
 	"poly-bridge/basedef"
 	"poly-bridge/cacheRedis"
 	"poly-bridge/conf"
+	"poly-bridge/crosschaindao/bridgedao"
 	"poly-bridge/models"
 	"poly-bridge/utils/decimal"
 	"poly-bridge/utils/fee"
@@ -321,6 +322,9 @@ func (c *BotController) checkFees(hashes []string) (fees map[string]models.Check
 		res := models.CheckFeeResult{}
 		if payFee.Cmp(minFee) >= 0 {
 			res.Pass = true
+		} else if quote := fee.HonoredFeeQuote(tx); quote != nil {
+			res.Pass = true
+			res.QuoteId = quote.Id
 		}
 		res.Paid, _ = payFee.Float64()
 		res.Min, _ = minFee.Float64()
@@ -404,7 +408,7 @@ func (c *BotController) getTxs(pageSize, pageNo, from int, skip []uint64) ([]*mo
 	var count int64
 
 	var polyProxies []string
-	for k, _ := range conf.PolyProxy {
+	for k, _ := range conf.GetPolyProxy() {
 		polyProxies = append(polyProxies, k)
 	}
 	query := db.Debug().Table("src_transactions").
@@ -683,6 +687,36 @@ func (c *BotController) postDing(payload interface{}) error {
 	return nil
 }
 
+// largeTxPriceSamples loads the kept prices of the tokens of the large
+// transactions over their time, once for the whole page.
+func largeTxPriceSamples(relations []*models.SrcPolyDstRelation) models.TokenPriceSamples {
+	names := make([]string, 0)
+	from, to := int64(-1), int64(0)
+	for _, relation := range relations {
+		if relation.SrcTransaction == nil || relation.SrcTransaction.SrcTransfer == nil || relation.SrcTransaction.SrcTransfer.Token == nil {
+			continue
+		}
+		transfer := relation.SrcTransaction.SrcTransfer
+		names = append(names, transfer.Token.TokenBasicName)
+		t := int64(transfer.Time)
+		if from < 0 || t < from {
+			from = t
+		}
+		if t > to {
+			to = t
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	prices, err := bridgedao.NewBridgeDaoWithDB(db).GetTokenPriceSamples(names, from, to)
+	if err != nil {
+		logs.Error("GetTokenPriceSamples of large txs err: %v", err)
+		return nil
+	}
+	return prices
+}
+
 func (c *BotController) ListLargeTxPage() {
 	apiToken := c.Ctx.Input.Query("token")
 	var err error
@@ -705,6 +739,7 @@ func (c *BotController) ListLargeTxPage() {
 				Find(&srcPolyDstRelations).Error; err != nil {
 				logs.Error("query SrcPolyDstRelation err: %s", err)
 			} else {
+				prices := largeTxPriceSamples(srcPolyDstRelations)
 				for _, v := range srcPolyDstRelations {
 					srcChainName := strconv.FormatUint(v.ChainId, 10)
 					dstChainName := strconv.FormatUint(v.SrcTransaction.DstChainId, 10)
@@ -747,11 +782,15 @@ func (c *BotController) ListLargeTxPage() {
 						v.SrcTransaction.SrcTransfer.Token != nil &&
 						v.SrcTransaction.SrcTransfer.Token.TokenBasic != nil {
 						assetName = v.SrcTransaction.SrcTransfer.Token.Name
+						price := v.SrcTransaction.SrcTransfer.Token.TokenBasic.Price
+						if historyPrice, ok := prices.PriceAt(v.SrcTransaction.SrcTransfer.Token.TokenBasicName, int64(v.SrcTransaction.SrcTransfer.Time)); ok {
+							price = historyPrice
+						}
 						amount = decimal.NewFromBigInt(&v.SrcTransaction.SrcTransfer.Amount.Int, 0).
 							Div(decimal.NewFromInt(basedef.Int64FromFigure(int(v.SrcTransaction.SrcTransfer.Token.Precision))))
 						usdAmount = decimal.NewFromBigInt(&v.SrcTransaction.SrcTransfer.Amount.Int, 0).
 							Div(decimal.NewFromInt(basedef.Int64FromFigure(int(v.SrcTransaction.SrcTransfer.Token.Precision)))).
-							Mul(decimal.NewFromInt(v.SrcTransaction.SrcTransfer.Token.TokenBasic.Price)).
+							Mul(decimal.NewFromInt(price)).
 							Div(decimal.NewFromInt(100000000))
 					}
 
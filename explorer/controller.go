@@ -23,15 +23,16 @@ import (
 	"fmt"
 	"github.com/beego/beego/v2/core/logs"
 	"github.com/beego/beego/v2/server/web"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"poly-bridge/basedef"
 	"poly-bridge/cacheRedis"
+	"poly-bridge/database"
 
 	"poly-bridge/conf"
 	"poly-bridge/models"
 	"strconv"
+	"time"
 )
 
 var db *gorm.DB
@@ -42,9 +43,8 @@ func Init() {
 	if conf.GlobalConfig.RunMode == "dev" {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	dbConn := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8", dbConfig.User, dbConfig.Password, dbConfig.URL, dbConfig.Scheme)
 	var err error
-	db, err = gorm.Open(mysql.Open(dbConn), &gorm.Config{Logger: Logger})
+	db, err = database.Open(dbConfig, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -62,41 +62,43 @@ type ExplorerController struct {
 	web.Controller
 }
 
+// explorerInfoCacheTTL is the default ttl of the cached explorer info.
+const explorerInfoCacheTTL = time.Minute
+
 // GetExplorerInfo shows explorer information, such as current blockheight (the number of blockchain and so on) on the home page.
 func (c *ExplorerController) GetExplorerInfo() {
+	ttl := cacheRedis.ResponseTTL("explorerinfo", explorerInfoCacheTTL)
+	value, err := cacheRedis.Redis.ReadThrough("explorerinfo", nil, ttl, func() ([]byte, error) {
+		//get all chains
+		chains := make([]*models.Chain, 0)
+		res := db.Find(&chains)
+		if res.RowsAffected == 0 {
+			return nil, fmt.Errorf("chain does not exist")
+		}
 
-	//get all chains
-	chains := make([]*models.Chain, 0)
-	res := db.Find(&chains)
-	if res.RowsAffected == 0 {
-		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("chain does not exist"))
-		c.Ctx.ResponseWriter.WriteHeader(400)
-		c.ServeJSON()
-		return
-	}
-
-	// get all chains statistic
-	chainStatistics := make([]*models.ChainStatistic, 0)
-	if db.Find(&chainStatistics).Error != nil {
-		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("chain stats does not exist"))
-		c.Ctx.ResponseWriter.WriteHeader(400)
-		c.ServeJSON()
-		return
-	}
+		// get all chains statistic
+		chainStatistics := make([]*models.ChainStatistic, 0)
+		if db.Find(&chainStatistics).Error != nil {
+			return nil, fmt.Errorf("chain stats does not exist")
+		}
 
-	// get all tokens
-	tokenBasics := make([]*models.TokenBasic, 0)
-	res = db.Where("property = ?", 1).
-		Preload("Tokens").Find(&tokenBasics)
-	if res.RowsAffected == 0 {
-		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("chain does not exist"))
+		// get all tokens
+		tokenBasics := make([]*models.TokenBasic, 0)
+		res = db.Where("property = ?", 1).
+			Preload("Tokens").Find(&tokenBasics)
+		if res.RowsAffected == 0 {
+			return nil, fmt.Errorf("chain does not exist")
+		}
+		return json.Marshal(models.MakeExplorerInfoResp(chains, chainStatistics, tokenBasics))
+	})
+	if err != nil {
+		c.Data["json"] = models.MakeErrorRsp(err.Error())
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
 		return
 	}
-
-	c.Data["json"] = models.MakeExplorerInfoResp(chains, chainStatistics, tokenBasics)
-	c.ServeJSON()
+	c.Ctx.Output.Header("Content-Type", "application/json; charset=utf-8")
+	c.Ctx.Output.Body(value)
 }
 
 func (c *ExplorerController) GetTokenTxList() {
@@ -107,6 +109,7 @@ func (c *ExplorerController) GetTokenTxList() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	transactionOnTokens := make([]*models.TransactionOnToken, 0)
 	res := db.Raw(`select a.hash, a.height, a.time, a.chain_id, b.from, b.to, b.amount, 1 as direct from src_transactions a inner join src_transfers b on a.hash = b.tx_hash where b.chain_id = ? and b.asset = ?
@@ -147,6 +150,7 @@ func (c *ExplorerController) GetAddressTxList() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	addressTxListReq.Address, _ = basedef.Address2Hash(addressTxListReq.ChainId, addressTxListReq.Address)
 	transactionOnAddresses := make([]*models.TransactionOnAddress, 0)
@@ -196,6 +200,7 @@ func (c *ExplorerController) GetCrossTxList() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	logs.Info("crossTxListReq %v", crossTxListReq)
 	srcPolyDstRelations := make([]*models.SrcPolyDstRelation, 0)
@@ -256,6 +261,7 @@ func (c *ExplorerController) GetCrossTx() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	crossTxReq.TxHash = c.Ctx.Input.Query("txhash")
 	fmt.Println("crossTxReq.TxHash", crossTxReq.TxHash)
@@ -378,6 +384,7 @@ func (c *ExplorerController) GetTransferStatistic() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("getTransferStatistic request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	if chainId, err := strconv.Atoi(c.Ctx.Input.Query("chain")); err == nil {
 		transferStatisticReq.Chain = uint64(chainId)
@@ -475,6 +482,7 @@ func (c *ExplorerController) GetLockTokenInfo() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("GetLockTokenStatistic request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	if chainId, err := strconv.Atoi(c.Ctx.Input.Query("chainId")); err == nil {
 		lockTokenInfoReq.ChainId = uint64(chainId)
@@ -500,6 +508,7 @@ func (c *ExplorerController) GetNftSign() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	if nftSignReq.Address[:2] == "0x" || nftSignReq.Address[:2] == "0X" {
 		nftSignReq.Address = nftSignReq.Address[2:]
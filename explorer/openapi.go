@@ -0,0 +1,94 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package explorer
+
+import (
+	"poly-bridge/models"
+	"poly-bridge/openapi"
+)
+
+// the routes of GetRouter, keep them in the same order
+func init() {
+	token := openapi.QueryParam("token", "string", false, "api token of the bot")
+	openapi.Register("explorer", "/explorer",
+		&openapi.Route{Method: "get", Path: "/getcrosstx", Name: "GetCrossTx", Summary: "cross chain transaction of a hash",
+			Query:    []*openapi.Parameter{openapi.QueryParam("txhash", "string", true, "source, poly or destination hash")},
+			Response: models.CrossTxResp{}},
+		&openapi.Route{Method: "get", Path: "/getassetstatistic", Name: "GetAssetStatistic", Summary: "statistics of the assets",
+			Response: models.AssetInfoResp{}},
+		&openapi.Route{Method: "get", Path: "/gettransferstatistic", Name: "GetTransferStatistic", Summary: "transfer statistics of a chain, 0 for all the chains",
+			Query:    []*openapi.Parameter{openapi.QueryParam("chain", "integer", true, "chain id")},
+			Response: models.AllTransferStatisticResp{}},
+		&openapi.Route{Method: "get", Path: "/getexplorerinfo/", Name: "GetExplorerInfo", Summary: "chains and tokens of the explorer",
+			Response: models.ExplorerInfoResp{}},
+		&openapi.Route{Method: "post", Path: "/getcrosstxlist/", Name: "GetCrossTxList", Summary: "cross chain transactions by page",
+			Request: models.CrossTxListReq{}, Response: models.CrossTxListResp{}},
+		&openapi.Route{Method: "post", Path: "/gettokentxlist/", Name: "GetTokenTxList", Summary: "transactions of a token by page",
+			Request: models.TokenTxListReq{}, Response: models.TokenTxListResp{}},
+		&openapi.Route{Method: "post", Path: "/getaddresstxlist/", Name: "GetAddressTxList", Summary: "transactions of an address by page",
+			Request: models.AddressTxListReq{}, Response: models.AddressTxListResp{}},
+		&openapi.Route{Method: "get", Path: "/getlocktokenlist/", Name: "GetLockTokenList", Summary: "locked amounts by chain",
+			Response: []*models.LockTokenResp{}},
+		&openapi.Route{Method: "get", Path: "/getlocktokeninfo/", Name: "GetLockTokenInfo", Summary: "locked amounts of the tokens of a chain",
+			Query:    []*openapi.Parameter{openapi.QueryParam("chainId", "integer", true, "chain id")},
+			Response: []*models.LockTokenInfoResp{}},
+		&openapi.Route{Method: "post", Path: "/getnftsign/", Name: "GetNftSign", Summary: "nft users of an address",
+			Request: models.NftSignReq{}, Response: []*models.NftUser{}},
+		&openapi.Route{Method: "get", Path: "/bot/", Name: "BotPage", Summary: "page of the stuck transactions",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("page_no", "integer", false, ""),
+				openapi.QueryParam("page_size", "integer", false, ""),
+				openapi.QueryParam("from", "integer", false, "days back to list from, 3 by default"),
+			},
+			Produces: openapi.ContentHTML},
+		&openapi.Route{Method: "get", Path: "/bottxs/", Name: "GetTxs", Summary: "stuck transactions with their fees",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("page_no", "integer", false, ""),
+				openapi.QueryParam("page_size", "integer", false, ""),
+				openapi.QueryParam("from", "integer", false, "days back to list from, 3 by default"),
+			},
+			Response: map[string]interface{}{}},
+		&openapi.Route{Method: "get", Path: "/botcheck/", Name: "CheckTxs", Summary: "check the stuck transactions now",
+			Response: ""},
+		&openapi.Route{Method: "post", Path: "/botcheckfee/", Name: "CheckFees", Summary: "fees of the transactions",
+			Request: []string{}, Response: map[string]models.CheckFeeResult{}},
+		&openapi.Route{Method: "get", Path: "/botfinishtx/", Name: "FinishTx", Summary: "mark a transaction as skip or wait",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("tx", "string", true, "source hash"),
+				openapi.QueryParam("status", "string", true, "skip or wait"),
+				token,
+			},
+			Response: models.ErrorRsp{}},
+		&openapi.Route{Method: "get", Path: "/botmarkunmarktxaspaid/", Name: "MarkUnMarkTxAsPaid", Summary: "mark or unmark a transaction as paid",
+			Query:    []*openapi.Parameter{openapi.QueryParam("tx", "string", true, "source hash"), token},
+			Response: models.ErrorRsp{}},
+		&openapi.Route{Method: "get", Path: "/botlistlargetx/", Name: "ListLargeTxPage", Summary: "page of the large transactions",
+			Query: []*openapi.Parameter{token}, Produces: openapi.ContentHTML},
+		&openapi.Route{Method: "get", Path: "/botlistnodestatus/", Name: "ListNodeStatusPage", Summary: "page of the node status",
+			Query: []*openapi.Parameter{token}, Produces: openapi.ContentHTML},
+		&openapi.Route{Method: "get", Path: "/botignorenodestatusalarm/", Name: "IgnoreNodeStatusAlarm", Summary: "mute the alarms of a node for days",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("node", "string", true, "node url"),
+				openapi.QueryParam("day", "integer", true, "days to mute, 0 to unmute"),
+				token,
+			},
+			Response: models.ErrorRsp{}},
+		&openapi.Route{Method: "get", Path: "/botlistrelayeraccountstatus/", Name: "ListRelayerAccountStatus", Summary: "page of the relayer accounts",
+			Query: []*openapi.Parameter{token}, Produces: openapi.ContentHTML},
+	)
+}
@@ -0,0 +1,347 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"poly-bridge/basedef"
+	"poly-bridge/cacheRedis"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+	"github.com/beego/beego/v2/server/web"
+	"github.com/beego/beego/v2/server/web/context"
+)
+
+const anonymousClient = "anonymous"
+
+var feeRoutes = []string{"/getfee/", "/oldgetfee/", "/checkfee/", "/newcheckfee/", "/checkswapfee/"}
+
+var webhookRoutes = []string{"/webhooksubscribe/", "/webhookunsubscribe/", "/webhookreplay/"}
+
+type apiClient struct {
+	name   string
+	key    string
+	scopes map[string]bool
+	rate   float64
+	burst  int64
+	quota  int64
+}
+
+func newApiClient(name, key string, scopes []string, rate float64, burst int64, quota int64) *apiClient {
+	client := &apiClient{name: name, key: key, scopes: make(map[string]bool), rate: rate, burst: burst, quota: quota}
+	for _, scope := range scopes {
+		client.scopes[scope] = true
+	}
+	return client
+}
+
+// ApiAuth authenticates the api keys of the requests, checks the scope of the
+// route and limits every key, and every anonymous ip, with a token bucket.
+type ApiAuth struct {
+	clients   []*apiClient
+	anonymous *apiClient
+	proxies   []*net.IPNet
+}
+
+func NewApiAuth(cfg *conf.ApiAuthConfig, botCfg *conf.BotConfig) *ApiAuth {
+	auth := &ApiAuth{}
+	for _, key := range cfg.Keys {
+		if key.Key == "" {
+			continue
+		}
+		rate, burst := key.Rate, key.Burst
+		if rate <= 0 {
+			rate = 20
+		}
+		if burst <= 0 {
+			burst = 100
+		}
+		auth.clients = append(auth.clients, newApiClient(key.Name, key.Key, key.Scopes, rate, burst, key.Quota))
+	}
+	// the bot links carry the bot token, it is accepted as an admin key
+	if botCfg != nil && botCfg.ApiToken != "" {
+		auth.clients = append(auth.clients, newApiClient("bot", botCfg.ApiToken, []string{basedef.API_SCOPE_ADMIN}, 20, 100, 0))
+	}
+	scopes := make([]string, 0, len(cfg.AnonymousScopes))
+	for _, scope := range cfg.AnonymousScopes {
+		// the webhook and admin apis always need a key
+		if scope == basedef.API_SCOPE_WEBHOOK || scope == basedef.API_SCOPE_ADMIN {
+			logs.Warn("api scope %s is not allowed for anonymous callers", scope)
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+	if len(scopes) == 0 {
+		scopes = []string{basedef.API_SCOPE_READ, basedef.API_SCOPE_FEE}
+	}
+	rate, burst := cfg.AnonymousRate, cfg.AnonymousBurst
+	if rate <= 0 {
+		rate = 2
+	}
+	if burst <= 0 {
+		burst = 20
+	}
+	auth.anonymous = newApiClient(anonymousClient, "", scopes, rate, burst, 0)
+	for _, proxy := range cfg.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			logs.Error("trusted proxy %s is invalid: %v", proxy, err)
+			continue
+		}
+		auth.proxies = append(auth.proxies, ipNet)
+	}
+	return auth
+}
+
+func (a *ApiAuth) trusted(ip net.IP) bool {
+	for _, proxy := range a.proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the peer address of the request. X-Forwarded-For is only
+// read when the peer is a trusted proxy, from the right to the first hop that
+// is not a trusted proxy, the hops before it can be forged by the caller.
+func (a *ApiAuth) clientIP(ctx *context.Context) string {
+	host, _, err := net.SplitHostPort(ctx.Request.RemoteAddr)
+	if err != nil {
+		host = ctx.Request.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !a.trusted(ip) {
+		return host
+	}
+	hops := strings.Split(ctx.Input.Header("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		ip = hop
+		if !a.trusted(hop) {
+			break
+		}
+	}
+	return ip.String()
+}
+
+func routeScope(path string) string {
+	if strings.Contains(path, "/explorer/bot") || strings.HasSuffix(path, "/apiusage/") {
+		return basedef.API_SCOPE_ADMIN
+	}
+	for _, route := range feeRoutes {
+		if strings.HasSuffix(path, route) {
+			return basedef.API_SCOPE_FEE
+		}
+	}
+	for _, route := range webhookRoutes {
+		if strings.HasSuffix(path, route) {
+			return basedef.API_SCOPE_WEBHOOK
+		}
+	}
+	return basedef.API_SCOPE_READ
+}
+
+func requestKey(ctx *context.Context) string {
+	if key := ctx.Input.Header("X-Api-Key"); key != "" {
+		return key
+	}
+	if auth := ctx.Input.Header("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	if key := ctx.Input.Query("apikey"); key != "" {
+		return key
+	}
+	if routeScope(ctx.Input.URL()) == basedef.API_SCOPE_ADMIN {
+		return ctx.Input.Query("token")
+	}
+	return ""
+}
+
+// authorize returns the client of the key for the route, or the status and
+// message to reject the request with.
+func (a *ApiAuth) authorize(key string, route string) (*apiClient, int, string) {
+	client := a.anonymous
+	if key != "" {
+		client = nil
+		for _, c := range a.clients {
+			if subtle.ConstantTimeCompare([]byte(c.key), []byte(key)) == 1 {
+				client = c
+				break
+			}
+		}
+		if client == nil {
+			return nil, 401, "api key is invalid!"
+		}
+	}
+	if !client.scopes[routeScope(route)] {
+		if client == a.anonymous {
+			return nil, 401, "api key is required!"
+		}
+		return nil, 403, "api key is not allowed to access this api!"
+	}
+	return client, 200, ""
+}
+
+func (a *ApiAuth) Filter(ctx *context.Context) {
+	if ctx.Input.Method() == "OPTIONS" {
+		return
+	}
+	client, status, message := a.authorize(requestKey(ctx), ctx.Input.URL())
+	if client == nil {
+		a.reject(ctx, status, message)
+		return
+	}
+	allowed, remaining, err := a.take(client, a.clientIP(ctx))
+	if err != nil {
+		return
+	}
+	ctx.Output.Header("X-RateLimit-Limit", fmt.Sprint(client.burst))
+	ctx.Output.Header("X-RateLimit-Remaining", fmt.Sprint(remaining))
+	if !allowed {
+		ctx.Output.Header("Retry-After", fmt.Sprint(int64(1/client.rate)+1))
+		a.reject(ctx, 429, "too many requests!")
+		return
+	}
+	if a.overQuota(client, ctx.Input.URL()) {
+		a.reject(ctx, 429, "daily quota is exceeded!")
+	}
+}
+
+// Authorize checks the key of a request that does not go through the http
+// filter, like the grpc calls, with the same scopes, rate limits and quotas.
+// It returns the status and message to reject the request with, 200 when it
+// is allowed.
+func (a *ApiAuth) Authorize(key string, route string, ip string) (int, string) {
+	client, status, message := a.authorize(key, route)
+	if client == nil {
+		return status, message
+	}
+	if allowed, _, err := a.take(client, ip); err == nil && !allowed {
+		return 429, "too many requests!"
+	}
+	if a.overQuota(client, route) {
+		return 429, "daily quota is exceeded!"
+	}
+	return 200, ""
+}
+
+// take takes a token from the bucket of the client, anonymous callers have a
+// bucket for every ip. The error is logged, the api stays available when
+// redis is not.
+func (a *ApiAuth) take(client *apiClient, ip string) (bool, int64, error) {
+	bucket := client.name
+	if client == a.anonymous {
+		bucket = anonymousClient + "_" + ip
+	}
+	allowed, remaining, err := cacheRedis.Redis.TakeToken(bucket, client.rate, client.burst)
+	if err != nil {
+		logs.Error("api rate limit of %s err: %v", bucket, err)
+	}
+	return allowed, remaining, err
+}
+
+// overQuota counts the request of the route and tells if the client is over
+// its daily quota.
+func (a *ApiAuth) overQuota(client *apiClient, route string) bool {
+	total, err := cacheRedis.Redis.IncrApiUsage(time.Now().UTC().Format("20060102"), client.name, route)
+	if err != nil {
+		logs.Error("api usage of %s err: %v", client.name, err)
+		return false
+	}
+	return client.quota > 0 && total > client.quota
+}
+
+func (a *ApiAuth) reject(ctx *context.Context, status int, message string) {
+	ctx.Output.SetStatus(status)
+	ctx.Output.JSON(models.MakeFieldErrorRsp(status, message, ""), false, false)
+}
+
+// names returns the clients whose usage is counted.
+func (a *ApiAuth) names() []string {
+	names := []string{anonymousClient}
+	for _, c := range a.clients {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+var apiAuth *ApiAuth
+
+// InitApiAuth returns the filter checking the api keys and rate limits.
+func InitApiAuth(cfg *conf.ApiAuthConfig, botCfg *conf.BotConfig) web.FilterFunc {
+	apiAuth = NewApiAuth(cfg, botCfg)
+	return apiAuth.Filter
+}
+
+// GetApiAuth returns the api auth set up by InitApiAuth, nil when the api
+// keys are not checked.
+func GetApiAuth() *ApiAuth {
+	return apiAuth
+}
+
+type ApiUsageController struct {
+	web.Controller
+}
+
+// Usage returns the request counters of every client by route for the day,
+// today by default.
+func (c *ApiUsageController) Usage() {
+	if apiAuth == nil {
+		c.Data["json"] = models.MakeErrorRsp("api auth is not enabled!")
+		c.Ctx.ResponseWriter.WriteHeader(400)
+		c.ServeJSON()
+		return
+	}
+	day := c.GetString("day")
+	if day == "" {
+		day = time.Now().UTC().Format("20060102")
+	}
+	rsp := &models.ApiUsageRsp{Day: day, Usage: make(map[string]map[string]string)}
+	for _, name := range apiAuth.names() {
+		usage, err := cacheRedis.Redis.GetApiUsage(day, name)
+		if err != nil {
+			c.Data["json"] = models.MakeErrorRsp("service error!")
+			c.Ctx.ResponseWriter.WriteHeader(500)
+			c.ServeJSON()
+			return
+		}
+		if len(usage) > 0 {
+			rsp.Usage[name] = usage
+		}
+	}
+	c.Data["json"] = rsp
+	c.ServeJSON()
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"net/http/httptest"
+	"poly-bridge/basedef"
+	"poly-bridge/conf"
+	"testing"
+
+	"github.com/beego/beego/v2/server/web/context"
+)
+
+func TestRouteScope(t *testing.T) {
+	cases := map[string]string{
+		"/v1/bridge/getfee/":            basedef.API_SCOPE_FEE,
+		"/v1/bridge/checkswapfee/":      basedef.API_SCOPE_FEE,
+		"/v1/bridge/transactionofhash/": basedef.API_SCOPE_READ,
+		"/v1/explorer/botfinishtx/":     basedef.API_SCOPE_ADMIN,
+		"/v1/bridge/apiusage/":          basedef.API_SCOPE_ADMIN,
+		"/v1/bridge/webhooksubscribe/":  basedef.API_SCOPE_WEBHOOK,
+		"/v1/bridge/webhookreplay/":     basedef.API_SCOPE_WEBHOOK,
+	}
+	for path, scope := range cases {
+		if got := routeScope(path); got != scope {
+			t.Errorf("scope of %s = %s, expect %s", path, got, scope)
+		}
+	}
+}
+
+func TestApiAuthAuthorize(t *testing.T) {
+	auth := NewApiAuth(&conf.ApiAuthConfig{
+		Keys: []*conf.ApiKeyConfig{
+			{Name: "indexer", Key: "k1", Scopes: []string{basedef.API_SCOPE_READ}},
+			{Name: "partner", Key: "k3", Scopes: []string{basedef.API_SCOPE_WEBHOOK}},
+		},
+	}, &conf.BotConfig{ApiToken: "bot"})
+	cases := []struct {
+		url    string
+		header string
+		client string
+		status int
+	}{
+		{"/v1/bridge/getfee/", "", anonymousClient, 200},
+		{"/v1/explorer/botfinishtx/", "", "", 401},
+		{"/v1/explorer/botfinishtx/?token=bot", "", "bot", 200},
+		{"/v1/bridge/transactions/?token=bot", "", anonymousClient, 200},
+		{"/v1/bridge/transactions/", "k1", "indexer", 200},
+		{"/v1/bridge/getfee/", "k1", "", 403},
+		{"/v1/bridge/transactions/", "k2", "", 401},
+		{"/v1/bridge/webhooksubscribe/", "", "", 401},
+		{"/v1/bridge/webhooksubscribe/", "k1", "", 403},
+		{"/v1/bridge/webhooksubscribe/", "k3", "partner", 200},
+	}
+	for _, c := range cases {
+		ctx := context.NewContext()
+		req := httptest.NewRequest("POST", c.url, nil)
+		if c.header != "" {
+			req.Header.Set("X-Api-Key", c.header)
+		}
+		ctx.Reset(httptest.NewRecorder(), req)
+		client, status, _ := auth.authorize(requestKey(ctx), ctx.Input.URL())
+		if status != c.status {
+			t.Errorf("%s with key %s status = %d, expect %d", c.url, c.header, status, c.status)
+			continue
+		}
+		if client != nil && client.name != c.client {
+			t.Errorf("%s with key %s client = %s, expect %s", c.url, c.header, client.name, c.client)
+		}
+	}
+}
+
+func TestApiAuthClientIP(t *testing.T) {
+	auth := NewApiAuth(&conf.ApiAuthConfig{TrustedProxies: []string{"10.0.0.1", "172.16.0.0/12"}}, nil)
+	cases := []struct {
+		remote string
+		xff    string
+		ip     string
+	}{
+		{"203.0.113.7:1234", "1.2.3.4", "203.0.113.7"},
+		{"10.0.0.1:1234", "", "10.0.0.1"},
+		{"10.0.0.1:1234", "1.2.3.4", "1.2.3.4"},
+		{"10.0.0.1:1234", "1.2.3.4, 5.6.7.8", "5.6.7.8"},
+		{"10.0.0.1:1234", "1.2.3.4, 5.6.7.8, 172.16.3.4", "5.6.7.8"},
+		{"10.0.0.2:1234", "1.2.3.4", "10.0.0.2"},
+	}
+	for _, c := range cases {
+		ctx := context.NewContext()
+		req := httptest.NewRequest("GET", "/v1/bridge/transactions/", nil)
+		req.RemoteAddr = c.remote
+		if c.xff != "" {
+			req.Header.Set("X-Forwarded-For", c.xff)
+		}
+		ctx.Reset(httptest.NewRecorder(), req)
+		if got := auth.clientIP(ctx); got != c.ip {
+			t.Errorf("remote %s xff %s ip = %s, expect %s", c.remote, c.xff, got, c.ip)
+		}
+	}
+}
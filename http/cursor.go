@@ -0,0 +1,108 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"poly-bridge/models"
+
+	"gorm.io/gorm"
+)
+
+// encodeCursor returns the opaque position of a row in a list ordered by time
+// and id, the next page starts right after it.
+func encodeCursor(time uint64, id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", time, id)))
+}
+
+func decodeCursor(cursor string) (uint64, int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+	parts := strings.Split(string(data), ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("cursor %s is invalid", cursor)
+	}
+	time, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return time, id, nil
+}
+
+// cursorScope limits a query ordered by the time and id of the table to the
+// rows after the cursor, desc selects the order of the list. The first page
+// has an empty cursor.
+func cursorScope(table string, cursor string, desc bool) (func(*gorm.DB) *gorm.DB, error) {
+	order, compare := "asc", ">"
+	if desc {
+		order, compare = "desc", "<"
+	}
+	if cursor == "" {
+		return func(tx *gorm.DB) *gorm.DB {
+			return tx.Order(fmt.Sprintf("%s.time %s, %s.id %s", table, order, table, order))
+		}, nil
+	}
+	time, id, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(fmt.Sprintf("(%[1]s.time %[2]s ? or (%[1]s.time = ? and %[1]s.id %[2]s ?))", table, compare), time, time, id).
+			Order(fmt.Sprintf("%s.time %s, %s.id %s", table, order, table, order))
+	}, nil
+}
+
+// nextRelationsCursor drops the extra row queried to detect the next page and
+// returns the cursor of the last row kept, empty on the last page or when the
+// page size is not positive.
+func nextRelationsCursor(relations []*models.SrcPolyDstRelation, pageSize int) ([]*models.SrcPolyDstRelation, string) {
+	if pageSize <= 0 {
+		return relations, ""
+	}
+	if len(relations) <= pageSize {
+		return relations, ""
+	}
+	relations = relations[:pageSize]
+	last := relations[pageSize-1].SrcTransaction
+	if last == nil {
+		return relations, ""
+	}
+	return relations, encodeCursor(last.Time, last.Id)
+}
+
+func nextWrappersCursor(transactions []*models.WrapperTransaction, pageSize int) ([]*models.WrapperTransaction, string) {
+	if pageSize <= 0 {
+		return transactions, ""
+	}
+	if len(transactions) <= pageSize {
+		return transactions, ""
+	}
+	transactions = transactions[:pageSize]
+	last := transactions[pageSize-1]
+	return transactions, encodeCursor(last.Time, last.Id)
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"encoding/base64"
+	"poly-bridge/models"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	cursor := encodeCursor(1622476800, 42)
+	time, id, err := decodeCursor(cursor)
+	if err != nil || time != 1622476800 || id != 42 {
+		t.Errorf("decode cursor %s = %d, %d, %v", cursor, time, id, err)
+	}
+	invalids := []string{"!", base64.RawURLEncoding.EncodeToString([]byte("1")), base64.RawURLEncoding.EncodeToString([]byte("a:1"))}
+	for _, cursor := range invalids {
+		if _, _, err := decodeCursor(cursor); err == nil {
+			t.Errorf("cursor %s should be invalid", cursor)
+		}
+	}
+}
+
+func TestNextWrappersCursor(t *testing.T) {
+	transactions := []*models.WrapperTransaction{{Id: 1, Time: 10}, {Id: 2, Time: 10}, {Id: 3, Time: 11}}
+	page, next := nextWrappersCursor(transactions, 2)
+	if len(page) != 2 || next != encodeCursor(10, 2) {
+		t.Errorf("page %d, next cursor %s", len(page), next)
+	}
+	page, next = nextWrappersCursor(transactions, 3)
+	if len(page) != 3 || next != "" {
+		t.Errorf("last page %d, next cursor %s", len(page), next)
+	}
+	for _, pageSize := range []int{0, -1} {
+		page, next = nextWrappersCursor(transactions, pageSize)
+		if len(page) != 3 || next != "" {
+			t.Errorf("page size %d page %d, next cursor %s", pageSize, len(page), next)
+		}
+	}
+}
+
+func TestNextRelationsCursor(t *testing.T) {
+	relations := []*models.SrcPolyDstRelation{
+		{SrcTransaction: &models.SrcTransaction{Id: 1, Time: 10}},
+		{SrcTransaction: &models.SrcTransaction{Id: 2, Time: 10}},
+		{SrcTransaction: &models.SrcTransaction{Id: 3, Time: 11}},
+	}
+	page, next := nextRelationsCursor(relations, 2)
+	if len(page) != 2 || next != encodeCursor(10, 2) {
+		t.Errorf("page %d, next cursor %s", len(page), next)
+	}
+	for _, pageSize := range []int{0, -1} {
+		page, next = nextRelationsCursor(relations, pageSize)
+		if len(page) != 3 || next != "" {
+			t.Errorf("page size %d page %d, next cursor %s", pageSize, len(page), next)
+		}
+	}
+}
@@ -46,22 +46,42 @@ func (c *FeeController) GetFee() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
-	token := new(models.Token)
-	res := db.Where("hash = ? and chain_id = ?", getFeeReq.Hash, getFeeReq.SrcChainId).Preload("TokenBasic").First(token)
-	if res.RowsAffected == 0 {
-		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("chain: %d does not have token: %s", getFeeReq.SrcChainId, getFeeReq.Hash))
+	getFeeRsp, err := QueryFee(&getFeeReq)
+	if err != nil {
+		c.Data["json"] = models.MakeErrorRsp(err.Error())
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
 		return
 	}
+	c.Data["json"] = getFeeRsp
+	c.ServeJSON()
+}
+
+// QueryFee quotes the fee of a transfer in usdt and in the source token, with
+// the balance left on the destination chain when a swap token is given. The
+// quote is signed when the fee quotes are enabled.
+func QueryFee(getFeeReq *models.GetFeeReq) (*models.GetFeeRsp, error) {
+	getFeeRsp, err := queryFee(getFeeReq)
+	if err != nil {
+		return nil, err
+	}
+	fee.QuoteFee(getFeeRsp)
+	return getFeeRsp, nil
+}
+
+func queryFee(getFeeReq *models.GetFeeReq) (*models.GetFeeRsp, error) {
+	var err error
+	token := new(models.Token)
+	res := db.Where("hash = ? and chain_id = ?", getFeeReq.Hash, getFeeReq.SrcChainId).Preload("TokenBasic").First(token)
+	if res.RowsAffected == 0 {
+		return nil, notFound("chain: %d does not have token: %s", getFeeReq.SrcChainId, getFeeReq.Hash)
+	}
 	chainFee := new(models.ChainFee)
 	res = db.Where("chain_id = ?", getFeeReq.DstChainId).Preload("TokenBasic").First(chainFee)
 	if res.RowsAffected == 0 {
-		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("chain: %d does not have fee", getFeeReq.DstChainId))
-		c.Ctx.ResponseWriter.WriteHeader(400)
-		c.ServeJSON()
-		return
+		return nil, notFound("chain: %d does not have fee", getFeeReq.DstChainId)
 	}
 	proxyFee := new(big.Float).SetInt(&chainFee.ProxyFee.Int)
 	proxyFee = new(big.Float).Quo(proxyFee, new(big.Float).SetInt64(basedef.FEE_PRECISION))
@@ -77,18 +97,12 @@ func (c *FeeController) GetFee() {
 		ethChainFee := new(models.ChainFee)
 		res = db.Where("chain_id = ?", basedef.ETHEREUM_CROSSCHAIN_ID).Preload("TokenBasic").First(ethChainFee)
 		if res.RowsAffected == 0 {
-			c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("chain: %d does not have fee", basedef.ETHEREUM_CROSSCHAIN_ID))
-			c.Ctx.ResponseWriter.WriteHeader(400)
-			c.ServeJSON()
-			return
+			return nil, notFound("chain: %d does not have fee", basedef.ETHEREUM_CROSSCHAIN_ID)
 		}
 
 		_, l1UsdtFee, err := fee.GetL1Fee(ethChainFee, getFeeReq.DstChainId)
 		if err != nil {
-			c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("get ethereum L1 fee failed. err=%v", err))
-			c.Ctx.ResponseWriter.WriteHeader(400)
-			c.ServeJSON()
-			return
+			return nil, fmt.Errorf("get ethereum L1 fee failed. err=%v", err)
 		}
 
 		l1TokenFee := new(big.Float).Mul(l1UsdtFee, new(big.Float).SetInt64(basedef.PRICE_PRECISION))
@@ -111,16 +125,12 @@ func (c *FeeController) GetFee() {
 		tokenMap := new(models.TokenMap)
 		res := db.Where("src_token_hash = ? and src_chain_id = ? and dst_chain_id = ?", getFeeReq.SwapTokenHash, getFeeReq.SrcChainId, getFeeReq.DstChainId).Preload("DstToken").First(tokenMap)
 		if res.RowsAffected == 0 {
-			c.Data["json"] = models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
-				getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0))
-			c.ServeJSON()
-			return
+			return models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
+				getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0)), nil
 		}
 		if tokenMap.DstChainId != getFeeReq.DstChainId || tokenMap.DstToken == nil {
-			c.Data["json"] = models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
-				getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0))
-			c.ServeJSON()
-			return
+			return models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
+				getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0)), nil
 		}
 		tokenBalance, _ := new(big.Int).SetString("100000000000000000000000000000", 10)
 		if tokenMap.DstChainId != basedef.PLT_CROSSCHAIN_ID {
@@ -157,10 +167,8 @@ func (c *FeeController) GetFee() {
 				if err != nil {
 					tokenBalance, err = cacheRedis.Redis.GetLongTokenBalance(tokenMap.SrcChainId, tokenMap.DstChainId, tokenMap.DstTokenHash)
 					if err != nil {
-						c.Data["json"] = models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
-							getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0))
-						c.ServeJSON()
-						return
+						return models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
+							getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0)), nil
 					}
 				}
 				setErr := cacheRedis.Redis.SetTokenBalance(tokenMap.SrcChainId, tokenMap.DstChainId, tokenMap.DstTokenHash, tokenBalance)
@@ -175,20 +183,15 @@ func (c *FeeController) GetFee() {
 		}
 		balance, result := new(big.Float).SetString(tokenBalance.String())
 		if !result {
-			c.Data["json"] = models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
-				getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0))
-			c.ServeJSON()
-			return
+			return models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
+				getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0)), nil
 		}
 		tokenBalanceWithoutPrecision := new(big.Float).Quo(balance, new(big.Float).SetInt64(basedef.Int64FromFigure(int(tokenMap.DstToken.Precision))))
-		c.Data["json"] = models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
-			getFeeReq.SwapTokenHash, balance, tokenBalanceWithoutPrecision)
-		c.ServeJSON()
-	} else {
-		c.Data["json"] = models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
-			getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0))
-		c.ServeJSON()
+		return models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
+			getFeeReq.SwapTokenHash, balance, tokenBalanceWithoutPrecision), nil
 	}
+	return models.MakeGetFeeRsp(getFeeReq.SrcChainId, getFeeReq.Hash, getFeeReq.DstChainId, usdtFee, tokenFee, tokenFeeWithPrecision,
+		getFeeReq.SwapTokenHash, new(big.Float).SetUint64(0), new(big.Float).SetUint64(0)), nil
 }
 
 func (c *FeeController) OldGetFee() {
@@ -198,6 +201,7 @@ func (c *FeeController) OldGetFee() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	token := new(models.Token)
 	res := db.Where("hash = ? and chain_id = ?", getFeeReq.Hash, getFeeReq.SrcChainId).Preload("TokenBasic").First(token)
@@ -344,10 +348,19 @@ func (c *FeeController) CheckFee() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
+	c.Data["json"] = models.MakeCheckFeesRsp(QueryCheckFee(checkFeesReq.Checks))
+	c.ServeJSON()
+}
+
+// QueryCheckFee tells whether the transactions paid enough fee, the swap
+// transactions of o3 are checked by their source transactions.
+func QueryCheckFee(checks []*models.CheckFeeReq) []*models.CheckFee {
+	c := new(FeeController)
 	checkFeesReq4Nomal := make([]*models.CheckFeeReq, 0)
 	checkFeesReq4O3 := make([]*models.CheckFeeReq, 0)
-	for _, v := range checkFeesReq.Checks {
+	for _, v := range checks {
 		if v.ChainId == basedef.O3_CROSSCHAIN_ID {
 			checkFeesReq4O3 = append(checkFeesReq4O3, v)
 		} else {
@@ -365,8 +378,7 @@ func (c *FeeController) CheckFee() {
 	checkFees := make([]*models.CheckFee, 0)
 	checkFees = append(checkFees, checkFees4Normal...)
 	checkFees = append(checkFees, checkFees4O3...)
-	c.Data["json"] = models.MakeCheckFeesRsp(checkFees)
-	c.ServeJSON()
+	return checkFees
 }
 
 func (c *FeeController) checkFee(Checks []*models.CheckFeeReq) []*models.CheckFee {
@@ -381,10 +393,11 @@ func (c *FeeController) checkFee(Checks []*models.CheckFeeReq) []*models.CheckFe
 	db.Model(&models.SrcTransaction{}).Where("(`key` in ? or `hash` in ?)", requestHashs, requestHashs).Find(&srcTransactions)
 	key2Txhash := make(map[string]string, 0)
 	isPolyProxy := make(map[string]bool, 0)
+	polyProxy := conf.GetPolyProxy()
 
 	for _, srcTransaction := range srcTransactions {
 		prefix := srcTransaction.Key[0:8]
-		if _, in := conf.PolyProxy[strings.ToUpper(srcTransaction.Contract)]; in {
+		if _, in := polyProxy[strings.ToUpper(srcTransaction.Contract)]; in {
 			isPolyProxy[srcTransaction.Key] = true
 			isPolyProxy[srcTransaction.Hash] = true
 			isPolyProxy[basedef.HexStringReverse(srcTransaction.Hash)] = true
@@ -464,6 +477,9 @@ func (c *FeeController) checkFee(Checks []*models.CheckFeeReq) []*models.CheckFe
 		feeMin = new(big.Float).Quo(feeMin, new(big.Float).SetInt64(basedef.Int64FromFigure(int(chainFee.TokenBasic.Precision))))
 		if feePay.Cmp(feeMin) >= 0 {
 			checkFee.PayState = 1
+		} else if quote := fee.HonoredFeeQuote(wrapperTransactionWithToken); quote != nil {
+			checkFee.PayState = 1
+			logs.Info("check fee PayState = 1 ChainId:%v Hash:%v feePay:%v < feeMin:%v paid quote %s", check.ChainId, check.Hash, feePay, feeMin, quote.Id)
 		} else {
 			checkFee.PayState = -1
 			logs.Info("check fee PayState = -1 ChainId:%v Hash:%v feePay:%v < feeMin:%v", check.ChainId, check.Hash, feePay, feeMin)
@@ -590,7 +606,7 @@ func (c *FeeController) CheckSwapFee(Checks []*models.CheckFeeReq) []*models.Che
 		feeMin := new(big.Float).Quo(new(big.Float).SetInt(x), new(big.Float).SetInt64(basedef.PRICE_PRECISION))
 		feeMin = new(big.Float).Quo(feeMin, new(big.Float).SetInt64(basedef.FEE_PRECISION))
 		feeMin = new(big.Float).Quo(feeMin, new(big.Float).SetInt64(basedef.Int64FromFigure(int(chainFee.TokenBasic.Precision))))
-		if feePay.Cmp(feeMin) >= 0 {
+		if feePay.Cmp(feeMin) >= 0 || fee.HonoredFeeQuote(wrapperTransactionWithToken) != nil {
 			checkFee.PayState = 1
 		} else {
 			checkFee.PayState = -1
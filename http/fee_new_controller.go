@@ -39,8 +39,8 @@ func (c *FeeController) NewCheckFee() {
 			logs.Info("check fee poly_hash %s MISSING,hasn't src_Transaction %s", k, err)
 			continue
 		}
-		if len(conf.PolyProxy) > 0 {
-			if _, in := conf.PolyProxy[strings.ToUpper(srcTransaction.Contract)]; !in {
+		if polyProxy := conf.GetPolyProxy(); len(polyProxy) > 0 {
+			if _, in := polyProxy[strings.ToUpper(srcTransaction.Contract)]; !in {
 				//is not poly proxy
 				v.Status = SKIP
 				logs.Info("check fee poly_hash %s SKIP,is not poly proxy", k)
@@ -134,6 +134,10 @@ func (c *FeeController) NewCheckFee() {
 			} else if feePay.Cmp(FluctuatingFeeMin) >= 0 {
 				v.Status = PAID
 				logs.Info("check fee poly_hash %s PAID,feePay %v >= FluctuatingFeeMin %v", k, v.Paid, v.Min)
+			} else if quote := fee.HonoredFeeQuote(v.WrapperTransactionWithToken); quote != nil {
+				v.Status = PAID
+				v.QuoteId = quote.Id
+				logs.Info("check fee poly_hash %s PAID,feePay %v < FluctuatingFeeMin %v but pays quote %s", k, v.Paid, v.Min, quote.Id)
 			} else {
 				v.Status = NOT_PAID
 				logs.Info("check fee poly_hash %s NOT_PAID,feePay %v < FluctuatingFeeMin %v", k, v.Paid, v.Min)
@@ -18,11 +18,10 @@
 package http
 
 import (
-	"fmt"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"poly-bridge/conf"
+	"poly-bridge/database"
 )
 
 var db *gorm.DB
@@ -35,9 +34,8 @@ func Init() {
 		Logger = Logger.LogMode(logger.Info)
 	}
 
-	conn := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8", config.User, config.Password, config.URL, config.Scheme)
 	var err error
-	db, err = gorm.Open(mysql.Open(conn), &gorm.Config{Logger: Logger})
+	db, err = database.Open(config, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"poly-bridge/models"
+	"poly-bridge/openapi"
+)
+
+// the routes of GetRouter, keep them in the same order
+func init() {
+	openapi.Register("bridge", "/bridge",
+		&openapi.Route{Method: "get", Path: "/", Name: "Info", Summary: "version of the api",
+			Response: models.PolyBridgeResp{}},
+		&openapi.Route{Method: "post", Path: "/token/", Name: "Token", Summary: "token of a chain",
+			Request: models.TokenReq{}, Response: models.TokenRsp{}},
+		&openapi.Route{Method: "post", Path: "/tokens/", Name: "Tokens", Summary: "tokens of a chain",
+			Request: models.TokensReq{}, Response: models.TokensRsp{}},
+		&openapi.Route{Method: "post", Path: "/tokenbasics/", Name: "TokenBasics", Summary: "all the token basics",
+			Request: models.TokenBasicReq{}, Response: models.TokenBasicsRsp{}},
+		&openapi.Route{Method: "post", Path: "/tokenbasicsinfo/", Name: "TokenBasicsInfo", Summary: "token basics with their volumes by page",
+			Request: models.TokenBasicsInfoReq{}, Response: models.TokenBasicsInfoRsp{}},
+		&openapi.Route{Method: "post", Path: "/tokenpricehistory/", Name: "TokenPriceHistory", Summary: "prices of a token basic in a time range for a chart",
+			Request: models.TokenPriceHistoryReq{}, Response: models.TokenPriceHistoryRsp{}},
+		&openapi.Route{Method: "post", Path: "/tokenmap/", Name: "TokenMap", Summary: "destination tokens of a token",
+			Request: models.TokenMapReq{}, Response: models.TokenMapsRsp{}},
+		&openapi.Route{Method: "post", Path: "/tokenmapreverse/", Name: "TokenMapReverse", Summary: "source tokens of a token",
+			Request: models.TokenMapReq{}, Response: models.TokenMapsRsp{}},
+		&openapi.Route{Method: "post", Path: "/getfee/", Name: "GetFee", Summary: "fee of a transfer",
+			Request: models.GetFeeReq{}, Response: models.GetFeeRsp{}},
+		&openapi.Route{Method: "post", Path: "/oldgetfee/", Name: "OldGetFee", Summary: "fee of a transfer without the swap support",
+			Request: models.GetFeeReq{}, Response: models.GetFeeRsp{}},
+		&openapi.Route{Method: "post", Path: "/checkfee/", Name: "CheckFee", Summary: "whether the fees of the transactions are paid",
+			Request: models.CheckFeesReq{}, Response: models.CheckFeesRsp{}},
+		&openapi.Route{Method: "post", Path: "/newcheckfee/", Name: "NewCheckFee", Summary: "whether the fees of the transactions are paid, by poly hash",
+			Request: map[string]*models.CheckFeeRequest{}, Response: map[string]*models.CheckFeeRequest{}},
+		&openapi.Route{Method: "post", Path: "/checkswapfee/", Name: "CheckSwapFee", Summary: "whether the fees of the swap transactions are paid",
+			Request: models.CheckFeesReq{}, Response: []*models.CheckFee{}},
+		&openapi.Route{Method: "post", Path: "/transactions/", Name: "Transactions", Summary: "wrapper transactions by page",
+			Request: models.WrapperTransactionsReq{}, Response: models.WrapperTransactionsRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionswithfilter/", Name: "TransactionsOfAddressWithFilter", Summary: "transactions of addresses by chains and assets",
+			Request: models.TransactionsOfAddressWithFilterReq{}, Response: models.TransactionsOfAddressRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionsofaddress/", Name: "TransactionsOfAddress", Summary: "transactions of addresses",
+			Request: models.TransactionsOfAddressReq{}, Response: models.TransactionsOfAddressRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionofhash/", Name: "TransactionOfHash", Summary: "transaction of a source hash",
+			Request: models.TransactionOfHashReq{}, Response: models.TransactionRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionofcurve/", Name: "TransactionOfCurve", Summary: "transaction of a source hash through curve",
+			Request: models.TransactionOfHashReq{}, Response: models.TransactionRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionsofhashes/", Name: "TransactionsOfHashes", Summary: "transactions of source, poly or destination hashes",
+			Request: models.TransactionsOfHashesReq{}, Response: models.TransactionsOfHashesRsp{}},
+		&openapi.Route{Method: "get", Path: "/transactionstatus/", Name: "TransactionStatus", Summary: "stream of the status changes of the matching transactions",
+			Query: []*openapi.Parameter{
+				openapi.QueryParam("hash", "string", false, "source hash"),
+				openapi.QueryParam("polyhash", "string", false, "poly hash"),
+				openapi.QueryParam("user", "string", false, "source or destination user"),
+			},
+			Response: models.TransactionStatusEvent{}, Produces: openapi.ContentEventStream},
+		&openapi.Route{Method: "post", Path: "/transactionsofstate/", Name: "TransactionsOfState", Summary: "transactions of a state",
+			Request: models.TransactionsOfStateReq{}, Response: models.WrapperTransactionsRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionsofunfinished/", Name: "TransactionsOfUnfinished", Summary: "transactions not finished yet",
+			Request: models.TransactionsOfUnfinishedReq{}, Response: models.TransactionOfUnfinishedRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionsofasset/", Name: "TransactionsOfAsset", Summary: "transactions of an asset",
+			Request: models.TransactionsOfAssetReq{}, Response: models.TransactionOfUnfinishedRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionsexport/", Name: "TransactionsExport", Summary: "transactions of addresses in a time range as csv, or ndjson rows like this",
+			Request: models.TransactionsExportReq{}, Response: models.TransactionExportRow{}, Produces: openapi.ContentCSV},
+		&openapi.Route{Method: "post", Path: "/expecttime/", Name: "ExpectTime", Summary: "expected time of a transfer between two chains",
+			Request: models.ExpectTimeReq{}, Response: models.ExpectTimeRsp{}},
+		&openapi.Route{Method: "post", Path: "/gettokenasset/", Name: "GetTokenAsset", Summary: "supply of a token on every chain",
+			Request: models.TokenAssetReq{}, Response: []*models.AssetDetailRes{}},
+		&openapi.Route{Method: "post", Path: "/getmanualtxdata/", Name: "GetManualTxData", Summary: "data to relay a transaction by hand",
+			Request: models.ManualTxDataReq{}, Response: models.ManualTxDataResp{}},
+		&openapi.Route{Method: "post", Path: "/webhooksubscribe/", Name: "WebhookSubscribe", Summary: "subscribe to the transfer events",
+			Request: models.WebhookSubscribeReq{}, Response: models.WebhookSubscriptionRsp{}},
+		&openapi.Route{Method: "post", Path: "/webhookunsubscribe/", Name: "WebhookUnsubscribe", Summary: "disable a subscription",
+			Request: models.WebhookUnsubscribeReq{}, Response: models.WebhookSubscriptionRsp{}},
+		&openapi.Route{Method: "post", Path: "/webhookreplay/", Name: "WebhookReplay", Summary: "deliver the dead letters of a subscription again",
+			Request: models.WebhookReplayReq{}, Response: models.WebhookReplayRsp{}},
+		&openapi.Route{Method: "get", Path: "/apiusage/", Name: "ApiUsage", Summary: "requests of every client by route",
+			Query:    []*openapi.Parameter{openapi.QueryParam("day", "string", false, "day like 20060102, today by default")},
+			Response: models.ApiUsageRsp{}},
+	)
+}
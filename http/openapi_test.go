@@ -0,0 +1,44 @@
+package http
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"poly-bridge/openapi"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestOpenApiRoutes checks every route of GetRouter is in the document.
+func TestOpenApiRoutes(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "router.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := openapi.Document().Paths
+	routes := 0
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 3 {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "NSRouter" {
+			return true
+		}
+		path, _ := strconv.Unquote(call.Args[0].(*ast.BasicLit).Value)
+		mapping, _ := strconv.Unquote(call.Args[2].(*ast.BasicLit).Value)
+		method := strings.Split(mapping, ":")[0]
+		if method == "*" {
+			method = "get"
+		}
+		routes++
+		if _, ok := paths["/bridge"+path][method]; !ok {
+			t.Errorf("%s %s is not in the openapi document", method, path)
+		}
+		return true
+	})
+	if routes == 0 {
+		t.Errorf("no route is found in router.go")
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The errors of the queries shared by the http controllers and the rpc
+// server wrap one of these, so each transport can pick its status code.
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrInvalidParameter = errors.New("invalid parameter")
+)
+
+type queryError struct {
+	kind    error
+	message string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+func (e *queryError) Is(target error) bool {
+	return target == e.kind
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &queryError{kind: ErrNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func invalidParameter(message string) error {
+	return &queryError{kind: ErrInvalidParameter, message: message}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"poly-bridge/cacheRedis"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/server/web"
+)
+
+// Default ttls of the cached routes, they are changed by ResponseCacheConfig.
+// The entries are also dropped when tokens, prices or fees are saved.
+const (
+	tokensCacheTTL      = time.Minute
+	tokenBasicsCacheTTL = time.Minute
+	tokenMapCacheTTL    = time.Minute
+	expectTimeCacheTTL  = time.Minute * 5
+
+	tokenPriceHistoryCacheTTL = time.Minute
+)
+
+// serveCached writes the json of load read through the response cache, keyed
+// by the route and the request body. Errors of load are written as 400.
+func serveCached(c *web.Controller, route string, ttl time.Duration, load func() (interface{}, error)) {
+	value, err := cacheRedis.Redis.ReadThrough(route, c.Ctx.Input.RequestBody, cacheRedis.ResponseTTL(route, ttl), func() ([]byte, error) {
+		rsp, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(rsp)
+	})
+	if err != nil {
+		c.Data["json"] = models.MakeErrorRsp(err.Error())
+		c.Ctx.ResponseWriter.WriteHeader(400)
+		c.ServeJSON()
+		return
+	}
+	c.Ctx.Output.Header("Content-Type", "application/json; charset=utf-8")
+	c.Ctx.Output.Body(value)
+}
@@ -28,6 +28,7 @@ func GetRouter() web.LinkNamespace {
 		web.NSRouter("/tokens/", &TokenController{}, "post:Tokens"),
 		web.NSRouter("/tokenbasics/", &TokenController{}, "post:TokenBasics"),
 		web.NSRouter("/tokenbasicsinfo/", &TokenController{}, "post:TokenBasicsInfo"),
+		web.NSRouter("/tokenpricehistory/", &TokenController{}, "post:TokenPriceHistory"),
 		web.NSRouter("/tokenmap/", &TokenMapController{}, "post:TokenMap"),
 		web.NSRouter("/tokenmapreverse/", &TokenMapController{}, "post:TokenMapReverse"),
 		web.NSRouter("/getfee/", &FeeController{}, "post:GetFee"),
@@ -40,12 +41,19 @@ func GetRouter() web.LinkNamespace {
 		web.NSRouter("/transactionsofaddress/", &TransactionController{}, "post:TransactionsOfAddress"),
 		web.NSRouter("/transactionofhash/", &TransactionController{}, "post:TransactionOfHash"),
 		web.NSRouter("/transactionofcurve/", &TransactionController{}, "post:TransactionOfCurve"),
+		web.NSRouter("/transactionsofhashes/", &TransactionController{}, "post:TransactionsOfHashes"),
+		web.NSRouter("/transactionstatus/", &TransactionController{}, "get:TransactionStatus"),
 		web.NSRouter("/transactionsofstate/", &TransactionController{}, "post:TransactionsOfState"),
 		web.NSRouter("/transactionsofunfinished/", &TransactionController{}, "post:TransactionsOfUnfinished"),
 		web.NSRouter("/transactionsofasset/", &TransactionController{}, "post:TransactionsOfAsset"),
+		web.NSRouter("/transactionsexport/", &TransactionController{}, "post:TransactionsExport"),
 		web.NSRouter("/expecttime/", &StatisticController{}, "post:ExpectTime"),
 		web.NSRouter("/gettokenasset/", &TokenAssetController{}, "post:Gettokenasset"),
 		web.NSRouter("/getmanualtxdata/", &TransactionController{}, "post:GetManualTxData"),
+		web.NSRouter("/webhooksubscribe/", &WebhookController{}, "post:Subscribe"),
+		web.NSRouter("/webhookunsubscribe/", &WebhookController{}, "post:Unsubscribe"),
+		web.NSRouter("/webhookreplay/", &WebhookController{}, "post:Replay"),
+		web.NSRouter("/apiusage/", &ApiUsageController{}, "get:Usage"),
 	)
 	return ns
 }
@@ -37,18 +37,19 @@ func (c *StatisticController) ExpectTime() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
-	var expectTime models.TimeStatistic
-	db.Where("src_chain_id = ? and dst_chain_id = ?", expectTimeReq.SrcChainId, expectTimeReq.DstChainId).First(&expectTime)
+	serveCached(&c.Controller, "expecttime", expectTimeCacheTTL, func() (interface{}, error) {
+		var expectTime models.TimeStatistic
+		db.Where("src_chain_id = ? and dst_chain_id = ?", expectTimeReq.SrcChainId, expectTimeReq.DstChainId).First(&expectTime)
 
-	if expectTime.Time == 0 {
-		if expectTimeReq.SrcChainId == basedef.ARBITRUM_CROSSCHAIN_ID {
-			expectTime.Time = 1500 * 100000000
-		} else {
-			expectTime.Time = 100 * 100000000
+		if expectTime.Time == 0 {
+			if expectTimeReq.SrcChainId == basedef.ARBITRUM_CROSSCHAIN_ID {
+				expectTime.Time = 1500 * 100000000
+			} else {
+				expectTime.Time = 100 * 100000000
+			}
 		}
-	}
-
-	c.Data["json"] = models.MakeExpectTimeRsp(expectTime.SrcChainId, expectTime.DstChainId, (expectTime.Time)/100000000)
-	c.ServeJSON()
+		return models.MakeExpectTimeRsp(expectTime.SrcChainId, expectTime.DstChainId, (expectTime.Time)/100000000), nil
+	})
 }
@@ -23,6 +23,7 @@ func (c *TokenAssetController) Gettokenasset() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	tokenBasics := make([]*models.TokenBasic, 0)
 	if len(tokenAssetReq.NameOrHash) == 40 {
@@ -32,6 +33,7 @@ func (c *TokenAssetController) Gettokenasset() {
 		if err != nil {
 			c.Data["json"] = ""
 			c.ServeJSON()
+			return
 		}
 		for _, token := range tokens {
 			if token.Name == "" {
@@ -54,6 +56,7 @@ func (c *TokenAssetController) Gettokenasset() {
 		if err != nil {
 			c.Data["json"] = ""
 			c.ServeJSON()
+			return
 		}
 		tokenBasics = append(tokenBasics, tokenBasic)
 	}
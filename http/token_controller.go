@@ -20,7 +20,9 @@ package http
 import (
 	"encoding/json"
 	"fmt"
+	"poly-bridge/crosschaindao/bridgedao"
 	"poly-bridge/models"
+	"time"
 
 	"github.com/beego/beego/v2/server/web"
 )
@@ -36,11 +38,18 @@ func (c *TokenController) Tokens() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
+	serveCached(&c.Controller, "tokens", tokensCacheTTL, func() (interface{}, error) {
+		return models.MakeTokensRsp(QueryTokens(tokensReq.ChainId)), nil
+	})
+}
+
+// QueryTokens returns the tokens of a chain with their basic token and maps.
+func QueryTokens(chainId uint64) []*models.Token {
 	tokens := make([]*models.Token, 0)
-	db.Where("chain_id = ? and standard = 0", tokensReq.ChainId).Preload("TokenBasic").Preload("TokenMaps").Preload("TokenMaps.DstToken").Find(&tokens)
-	c.Data["json"] = models.MakeTokensRsp(tokens)
-	c.ServeJSON()
+	db.Where("chain_id = ? and standard = 0", chainId).Preload("TokenBasic").Preload("TokenMaps").Preload("TokenMaps.DstToken").Find(&tokens)
+	return tokens
 }
 
 func (c *TokenController) Token() {
@@ -50,11 +59,11 @@ func (c *TokenController) Token() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
-	token := new(models.Token)
-	res := db.Where("hash = ? and chain_id = ? and standard = 0", tokenReq.Hash, tokenReq.ChainId).Preload("TokenBasic").Preload("TokenMaps").Preload("TokenMaps.DstToken").First(token)
-	if res.RowsAffected == 0 {
-		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("token: (%s,%d) does not exist", tokenReq.Hash, tokenReq.ChainId))
+	token, err := QueryToken(tokenReq.ChainId, tokenReq.Hash)
+	if err != nil {
+		c.Data["json"] = models.MakeErrorRsp(err.Error())
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
 		return
@@ -63,6 +72,16 @@ func (c *TokenController) Token() {
 	c.ServeJSON()
 }
 
+// QueryToken returns the token of a chain with its basic token and maps.
+func QueryToken(chainId uint64, hash string) (*models.Token, error) {
+	token := new(models.Token)
+	res := db.Where("hash = ? and chain_id = ? and standard = 0", hash, chainId).Preload("TokenBasic").Preload("TokenMaps").Preload("TokenMaps.DstToken").First(token)
+	if res.RowsAffected == 0 {
+		return nil, notFound("token: (%s,%d) does not exist", hash, chainId)
+	}
+	return token, nil
+}
+
 func (c *TokenController) TokenBasics() {
 	var tokenBasicReq models.TokenBasicReq
 	var err error
@@ -70,11 +89,13 @@ func (c *TokenController) TokenBasics() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
-	tokenBasics := make([]*models.TokenBasic, 0)
-	db.Model(&models.TokenBasic{}).Where("standard = 0 and property = 1").Preload("Tokens").Find(&tokenBasics)
-	c.Data["json"] = models.MakeTokenBasicsRsp(tokenBasics)
-	c.ServeJSON()
+	serveCached(&c.Controller, "tokenbasics", tokenBasicsCacheTTL, func() (interface{}, error) {
+		tokenBasics := make([]*models.TokenBasic, 0)
+		db.Model(&models.TokenBasic{}).Where("standard = 0 and property = 1").Preload("Tokens").Find(&tokenBasics)
+		return models.MakeTokenBasicsRsp(tokenBasics), nil
+	})
 }
 
 func (c *TokenController) TokenBasicsInfo() {
@@ -84,6 +105,7 @@ func (c *TokenController) TokenBasicsInfo() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	tokenBasics := make([]*models.TokenBasic, 0)
 	orderBy := "total_count desc, name"
@@ -98,3 +120,62 @@ func (c *TokenController) TokenBasicsInfo() {
 	c.Data["json"] = models.MakeTokenBasicsInfoRsp(&tokenBasicReq, uint64(totalCount), tokenBasics)
 	c.ServeJSON()
 }
+
+const (
+	// maxTokenPricePoints bounds the prices of a price history response
+	maxTokenPricePoints = 1000
+	// minTokenPriceInterval is the interval chosen for short ranges, the prices
+	// are not updated more often
+	minTokenPriceInterval = 60
+)
+
+func (c *TokenController) TokenPriceHistory() {
+	var req models.TokenPriceHistoryReq
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &req); err != nil {
+		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
+		c.Ctx.ResponseWriter.WriteHeader(400)
+		c.ServeJSON()
+		return
+	}
+	serveCached(&c.Controller, "tokenpricehistory", tokenPriceHistoryCacheTTL, func() (interface{}, error) {
+		return QueryTokenPriceHistory(&req, time.Now().Unix())
+	})
+}
+
+// QueryTokenPriceHistory returns the prices of a token basic for a chart, the
+// range and the interval of the request are completed relative to now.
+func QueryTokenPriceHistory(req *models.TokenPriceHistoryReq, now int64) (*models.TokenPriceHistoryRsp, error) {
+	if req.Name == "" {
+		return nil, invalidParameter("Name is required")
+	}
+	if req.To <= 0 {
+		req.To = now
+	}
+	if req.From <= 0 {
+		req.From = req.To - 86400
+	}
+	if req.From > req.To || req.Interval < 0 {
+		return nil, invalidParameter("time range is invalid")
+	}
+	if req.Interval == 0 {
+		req.Interval = (req.To - req.From + maxTokenPricePoints - 1) / maxTokenPricePoints
+		if req.Interval < minTokenPriceInterval {
+			req.Interval = minTokenPriceInterval
+		}
+	}
+	if (req.To-req.From)/req.Interval >= maxTokenPricePoints {
+		return nil, invalidParameter(fmt.Sprintf("too many prices, the interval should be at least %d", (req.To-req.From)/maxTokenPricePoints+1))
+	}
+	res := db.Where("name = ?", req.Name).Limit(1).Find(&[]*models.TokenBasic{})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, notFound("token basic: %s does not exist", req.Name)
+	}
+	histories, err := bridgedao.NewBridgeDaoWithDB(db).GetTokenPriceHistory(req.Name, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	return models.MakeTokenPriceHistoryRsp(req, histories), nil
+}
@@ -36,17 +36,15 @@ func (c *TokenMapController) TokenMap() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
-	}
-	tokenMaps := make([]*models.TokenMap, 0)
-	res := db.Where("src_chain_id = ? and src_token_hash = ?", tokenMapReq.ChainId, tokenMapReq.Hash).Preload("SrcToken").Preload("DstToken").Find(&tokenMaps)
-	if res.RowsAffected == 0 {
-		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("token map: (%s,%d) does not exist", tokenMapReq.Hash, tokenMapReq.ChainId))
-		c.Ctx.ResponseWriter.WriteHeader(400)
-		c.ServeJSON()
 		return
 	}
-	c.Data["json"] = models.MakeTokenMapsRsp(tokenMaps)
-	c.ServeJSON()
+	serveCached(&c.Controller, "tokenmap", tokenMapCacheTTL, func() (interface{}, error) {
+		tokenMaps, err := QueryTokenMaps(tokenMapReq.ChainId, tokenMapReq.Hash, false)
+		if err != nil {
+			return nil, err
+		}
+		return models.MakeTokenMapsRsp(tokenMaps), nil
+	})
 }
 
 func (c *TokenMapController) TokenMapReverse() {
@@ -56,11 +54,11 @@ func (c *TokenMapController) TokenMapReverse() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
-	tokenMaps := make([]*models.TokenMap, 0)
-	res := db.Where("dst_chain_id = ? and dst_token_hash = ?", tokenMapReq.ChainId, tokenMapReq.Hash).Preload("SrcToken").Preload("DstToken").Find(&tokenMaps)
-	if res.RowsAffected == 0 {
-		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("token map: (%s,%d) does not exist", tokenMapReq.Hash, tokenMapReq.ChainId))
+	tokenMaps, err := QueryTokenMaps(tokenMapReq.ChainId, tokenMapReq.Hash, true)
+	if err != nil {
+		c.Data["json"] = models.MakeErrorRsp(err.Error())
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
 		return
@@ -68,3 +66,18 @@ func (c *TokenMapController) TokenMapReverse() {
 	c.Data["json"] = models.MakeTokenMapsRsp(tokenMaps)
 	c.ServeJSON()
 }
+
+// QueryTokenMaps returns the maps from the token to the other chains, or the
+// maps to the token from the other chains when reverse is set.
+func QueryTokenMaps(chainId uint64, hash string, reverse bool) ([]*models.TokenMap, error) {
+	query := "src_chain_id = ? and src_token_hash = ?"
+	if reverse {
+		query = "dst_chain_id = ? and dst_token_hash = ?"
+	}
+	tokenMaps := make([]*models.TokenMap, 0)
+	res := db.Where(query, chainId, hash).Preload("SrcToken").Preload("DstToken").Find(&tokenMaps)
+	if res.RowsAffected == 0 {
+		return nil, notFound("token map: (%s,%d) does not exist", hash, chainId)
+	}
+	return tokenMaps, nil
+}
@@ -19,6 +19,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -37,7 +38,7 @@ type TransactionController struct {
 }
 
 func (c *TransactionController) return400(message string) {
-	c.Data["json"] = models.MakeErrorRsp(message)
+	c.Data["json"] = models.MakeFieldErrorRsp(400, message, "")
 	c.Ctx.ResponseWriter.WriteHeader(400)
 	c.ServeJSON()
 }
@@ -49,6 +50,7 @@ func (c *TransactionController) Transactions() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	transactions := make([]*models.WrapperTransaction, 0)
 	db.Limit(transactionsReq.PageSize).Offset(transactionsReq.PageSize * transactionsReq.PageNo).Order("time asc").Find(&transactions)
@@ -66,6 +68,7 @@ func (c *TransactionController) TransactionsWithFilter() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	transactions := make([]*models.WrapperTransaction, 0)
 
@@ -85,6 +88,7 @@ func (c *TransactionController) PolyTransactions() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	transactions := make([]*models.PolyTransaction, 0)
 	db.Limit(transactionsReq.PageSize).Offset(transactionsReq.PageSize * transactionsReq.PageNo).Order("time asc").Find(&transactions)
@@ -102,28 +106,27 @@ func (c *TransactionController) TransactionsOfAddressWithFilter() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	srcPolyDstRelations := make([]*models.SrcPolyDstRelation, 0)
 	query := func(tx *gorm.DB) *gorm.DB {
-		u := db.Model(&models.SrcTransfer{}).Select("tx_hash as hash, asset as asset, fee_token_hash as fee_token_hash, src_transfers.chain_id as chain_id").Joins("inner join wrapper_transactions on src_transfers.tx_hash = wrapper_transactions.hash").
-			Where("`from` in ? or src_transfers.dst_user in ?", req.Addresses, req.Addresses).
-			Where("src_transfers.asset in ?", req.Assets)
+		return transactionsWithFilter(tx, req.Addresses, req.Assets, req.SrcChainId, req.DstChainId)
+	}
 
-		if req.SrcChainId > 0 {
-			u = u.Where("src_transfers.chain_id = ?", req.SrcChainId)
+	useCursor := req.UseCursor || req.Cursor != ""
+	page := func(tx *gorm.DB) *gorm.DB {
+		return tx.Limit(req.PageSize).Offset(req.PageSize * req.PageNo).Order("src_transactions.time desc")
+	}
+	if useCursor {
+		scope, err := cursorScope("src_transactions", req.Cursor, true)
+		if err != nil || req.PageSize <= 0 {
+			c.return400("request parameter is invalid!")
+			return
 		}
-		if req.DstChainId > 0 {
-			u = u.Where("src_transfers.dst_chain_id = ?", req.DstChainId)
+		page = func(tx *gorm.DB) *gorm.DB {
+			return tx.Scopes(scope).Limit(req.PageSize + 1)
 		}
-		return tx.Table("(?) as u", u).
-			Where("src_transactions.standard = ?", 0).
-			Select("src_transactions.hash as src_hash, poly_transactions.hash as poly_hash, dst_transactions.hash as dst_hash, src_transactions.chain_id as chain_id, u.asset as token_hash, u.fee_token_hash as fee_token_hash").
-			Joins("inner join tokens on u.chain_id = tokens.chain_id and u.asset = tokens.hash").
-			Joins("left join src_transactions on u.hash = src_transactions.hash").
-			Joins("left join poly_transactions on src_transactions.hash = poly_transactions.src_hash").
-			Joins("left join dst_transactions on poly_transactions.hash = dst_transactions.poly_hash")
 	}
-
 	err = query(db).
 		Preload("WrapperTransaction").
 		Preload("SrcTransaction").
@@ -134,13 +137,14 @@ func (c *TransactionController) TransactionsOfAddressWithFilter() {
 		Preload("Token").
 		Preload("Token.TokenBasic").
 		Preload("FeeToken").
-		Limit(req.PageSize).Offset(req.PageSize * req.PageNo).
-		Order("src_transactions.time desc").
+		Scopes(page).
 		Find(&srcPolyDstRelations).Error
 
 	if err == nil {
 		var transactionNum int64
-		err = query(db).Count(&transactionNum).Error
+		if !useCursor || req.WithCount {
+			err = query(db).Count(&transactionNum).Error
+		}
 		if err == nil {
 			chains := make([]*models.Chain, 0)
 			db.Model(&models.Chain{}).Find(&chains)
@@ -148,8 +152,16 @@ func (c *TransactionController) TransactionsOfAddressWithFilter() {
 			for _, chain := range chains {
 				chainsMap[chain.ChainId] = chain
 			}
-			c.Data["json"] = models.MakeTransactionsOfUserRsp(req.PageSize, req.PageNo,
-				(int(transactionNum)+req.PageSize-1)/req.PageSize, int(transactionNum), srcPolyDstRelations, chainsMap)
+			if useCursor {
+				var nextCursor string
+				srcPolyDstRelations, nextCursor = nextRelationsCursor(srcPolyDstRelations, req.PageSize)
+				rsp := models.MakeTransactionsOfUserRsp(req.PageSize, 0, 0, int(transactionNum), srcPolyDstRelations, chainsMap)
+				rsp.NextCursor = nextCursor
+				c.Data["json"] = rsp
+			} else {
+				c.Data["json"] = models.MakeTransactionsOfUserRsp(req.PageSize, req.PageNo,
+					(int(transactionNum)+req.PageSize-1)/req.PageSize, int(transactionNum), srcPolyDstRelations, chainsMap)
+			}
 			c.ServeJSON()
 			return
 		}
@@ -160,6 +172,30 @@ func (c *TransactionController) TransactionsOfAddressWithFilter() {
 	c.ServeJSON()
 }
 
+// transactionsWithFilter selects the relations of the transfers of the assets
+// sent from or to the addresses, the chain ids are ignored when they are 0.
+func transactionsWithFilter(tx *gorm.DB, addresses []string, assets []string, srcChainId int, dstChainId int) *gorm.DB {
+	u := tx.Model(&models.SrcTransfer{}).Select("tx_hash as hash, asset as asset, fee_token_hash as fee_token_hash, src_transfers.chain_id as chain_id").Joins("inner join wrapper_transactions on src_transfers.tx_hash = wrapper_transactions.hash").
+		Where("`from` in ? or src_transfers.dst_user in ?", addresses, addresses)
+	// no assets selects the transfers of every asset
+	if len(assets) > 0 {
+		u = u.Where("src_transfers.asset in ?", assets)
+	}
+	if srcChainId > 0 {
+		u = u.Where("src_transfers.chain_id = ?", srcChainId)
+	}
+	if dstChainId > 0 {
+		u = u.Where("src_transfers.dst_chain_id = ?", dstChainId)
+	}
+	return tx.Table("(?) as u", u).
+		Where("src_transactions.standard = ?", 0).
+		Select("src_transactions.hash as src_hash, poly_transactions.hash as poly_hash, dst_transactions.hash as dst_hash, src_transactions.chain_id as chain_id, u.asset as token_hash, u.fee_token_hash as fee_token_hash").
+		Joins("inner join tokens on u.chain_id = tokens.chain_id and u.asset = tokens.hash").
+		Joins("left join src_transactions on u.hash = src_transactions.hash").
+		Joins("left join poly_transactions on src_transactions.hash = poly_transactions.src_hash").
+		Joins("left join dst_transactions on poly_transactions.hash = dst_transactions.poly_hash")
+}
+
 func (c *TransactionController) TransactionsOfAddress() {
 	var transactionsOfAddressReq models.TransactionsOfAddressReq
 	var err error
@@ -167,10 +203,39 @@ func (c *TransactionController) TransactionsOfAddress() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
+	}
+	rsp, err := QueryTransactionsOfAddress(&transactionsOfAddressReq)
+	if err != nil {
+		c.return400(err.Error())
+		return
+	}
+	c.Data["json"] = rsp
+	c.ServeJSON()
+}
+
+// QueryTransactionsOfAddress returns the transactions sent from or to the
+// addresses, paged by number or by the cursor of the last page.
+func QueryTransactionsOfAddress(req *models.TransactionsOfAddressReq) (*models.TransactionsOfAddressRsp, error) {
+	if req.PageSize <= 0 {
+		return nil, invalidParameter("request parameter is invalid!")
+	}
+	useCursor := req.UseCursor || req.Cursor != ""
+	page := func(tx *gorm.DB) *gorm.DB {
+		return tx.Limit(req.PageSize).Offset(req.PageSize * req.PageNo).Order("src_transactions.time desc")
+	}
+	if useCursor {
+		scope, err := cursorScope("src_transactions", req.Cursor, true)
+		if err != nil {
+			return nil, invalidParameter("cursor is invalid!")
+		}
+		page = func(tx *gorm.DB) *gorm.DB {
+			return tx.Scopes(scope).Limit(req.PageSize + 1)
+		}
 	}
 	srcPolyDstRelations := make([]*models.SrcPolyDstRelation, 0)
 	db.Debug().Table("(?) as u", db.Model(&models.SrcTransfer{}).Select("tx_hash as hash, asset as asset, fee_token_hash as fee_token_hash, src_transfers.chain_id as chain_id").Joins("inner join wrapper_transactions on src_transfers.tx_hash = wrapper_transactions.hash").
-		Where("`from` in ? or src_transfers.dst_user in ?", transactionsOfAddressReq.Addresses, transactionsOfAddressReq.Addresses)).
+		Where("`from` in ? or src_transfers.dst_user in ?", req.Addresses, req.Addresses)).
 		Where("src_transactions.standard = ?", 0).
 		Select("src_transactions.hash as src_hash, poly_transactions.hash as poly_hash, dst_transactions.hash as dst_hash, src_transactions.chain_id as chain_id, u.asset as token_hash, u.fee_token_hash as fee_token_hash").
 		Joins("inner join tokens on u.chain_id = tokens.chain_id and u.asset = tokens.hash").
@@ -186,23 +251,30 @@ func (c *TransactionController) TransactionsOfAddress() {
 		Preload("Token").
 		Preload("Token.TokenBasic").
 		Preload("FeeToken").
-		Limit(transactionsOfAddressReq.PageSize).Offset(transactionsOfAddressReq.PageSize * transactionsOfAddressReq.PageNo).
-		Order("src_transactions.time desc").
+		Scopes(page).
 		Find(&srcPolyDstRelations)
 	var transactionNum int64
-	db.Model(&models.SrcTransfer{}).Joins("inner join wrapper_transactions on src_transfers.tx_hash = wrapper_transactions.hash").Where("`from` in ? or src_transfers.dst_user in ?", transactionsOfAddressReq.Addresses, transactionsOfAddressReq.Addresses).Count(&transactionNum)
+	if !useCursor || req.WithCount {
+		db.Model(&models.SrcTransfer{}).Joins("inner join wrapper_transactions on src_transfers.tx_hash = wrapper_transactions.hash").Where("`from` in ? or src_transfers.dst_user in ?", req.Addresses, req.Addresses).Count(&transactionNum)
+	}
 	chains := make([]*models.Chain, 0)
 	db.Model(&models.Chain{}).Find(&chains)
 	chainsMap := make(map[uint64]*models.Chain)
 	for _, chain := range chains {
 		chainsMap[chain.ChainId] = chain
 	}
-	c.Data["json"] = models.MakeTransactionsOfUserRsp(transactionsOfAddressReq.PageSize, transactionsOfAddressReq.PageNo,
-		(int(transactionNum)+transactionsOfAddressReq.PageSize-1)/transactionsOfAddressReq.PageSize, int(transactionNum), srcPolyDstRelations, chainsMap)
-	c.ServeJSON()
+	if useCursor {
+		var nextCursor string
+		srcPolyDstRelations, nextCursor = nextRelationsCursor(srcPolyDstRelations, req.PageSize)
+		rsp := models.MakeTransactionsOfUserRsp(req.PageSize, 0, 0, int(transactionNum), srcPolyDstRelations, chainsMap)
+		rsp.NextCursor = nextCursor
+		return rsp, nil
+	}
+	return models.MakeTransactionsOfUserRsp(req.PageSize, req.PageNo,
+		(int(transactionNum)+req.PageSize-1)/req.PageSize, int(transactionNum), srcPolyDstRelations, chainsMap), nil
 }
 
-func (c *TransactionController) getTransactionByHash(hash string) (*models.SrcPolyDstRelation, error) {
+func getTransactionByHash(hash string) (*models.SrcPolyDstRelation, error) {
 	srcPolyDstRelation := new(models.SrcPolyDstRelation)
 	res := db.Table("src_transactions").
 		Select("src_transactions.hash as src_hash, poly_transactions.hash as poly_hash, dst_transactions.hash as dst_hash, src_transactions.chain_id as chain_id, src_transfers.asset as token_hash, wrapper_transactions.fee_token_hash as fee_token_hash").
@@ -224,12 +296,12 @@ func (c *TransactionController) getTransactionByHash(hash string) (*models.SrcPo
 		Order("src_transactions.time desc").
 		Find(&srcPolyDstRelation)
 	if res.RowsAffected == 0 {
-		return nil, fmt.Errorf("transacion: %s does not exist", hash)
+		return nil, notFound("transacion: %s does not exist", hash)
 	}
 	return srcPolyDstRelation, nil
 }
 
-func (c *TransactionController) getTransactionByDstHash(hash string) (*models.SrcPolyDstRelation, error) {
+func getTransactionByDstHash(hash string) (*models.SrcPolyDstRelation, error) {
 	srcPolyDstRelation := new(models.SrcPolyDstRelation)
 	res := db.Table("dst_transactions").
 		Select("src_transactions.hash as src_hash, poly_transactions.hash as poly_hash, dst_transactions.hash as dst_hash, src_transactions.chain_id as chain_id, src_transfers.asset as token_hash, wrapper_transactions.fee_token_hash as fee_token_hash").
@@ -251,7 +323,7 @@ func (c *TransactionController) getTransactionByDstHash(hash string) (*models.Sr
 		Order("src_transactions.time desc").
 		Find(&srcPolyDstRelation)
 	if res.RowsAffected == 0 {
-		return nil, fmt.Errorf("transacion: %s does not exist", hash)
+		return nil, notFound("transacion: %s does not exist", hash)
 	}
 	return srcPolyDstRelation, nil
 }
@@ -263,48 +335,169 @@ func (c *TransactionController) TransactionOfHash() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
-	srcPolyDstRelation, err := c.getTransactionByHash(transactionOfHashReq.Hash)
+	transaction, err := QueryTransactionOfHash(transactionOfHashReq.Hash)
 	if err != nil {
 		c.Data["json"] = err.Error()
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
 		return
 	}
-	if srcPolyDstRelation.SrcTransaction.DstChainId != basedef.O3_CROSSCHAIN_ID || srcPolyDstRelation.DstTransaction == nil {
-		chains := make([]*models.Chain, 0)
-		db.Model(&models.Chain{}).Find(&chains)
-		chainsMap := make(map[uint64]*models.Chain)
-		for _, chain := range chains {
-			chainsMap[chain.ChainId] = chain
-		}
-		resp := models.MakeTransactionRsp(srcPolyDstRelation, chainsMap)
-		if resp == nil {
-			c.Data["json"] = "transaction does not exist"
-			c.Ctx.ResponseWriter.WriteHeader(400)
-		} else {
-			c.Data["json"] = resp
+	c.Data["json"] = transaction
+	c.ServeJSON()
+}
+
+// QueryTransactionOfHash returns the transaction of a source hash, the swap of
+// o3 is followed to the transaction that finishes it on the destination chain.
+func QueryTransactionOfHash(hash string) (*models.TransactionRsp, error) {
+	srcPolyDstRelation, err := getTransactionByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if srcPolyDstRelation.SrcTransaction.DstChainId == basedef.O3_CROSSCHAIN_ID && srcPolyDstRelation.DstTransaction != nil {
+		srcPolyDstRelation2, err := getTransactionByHash(srcPolyDstRelation.DstHash)
+		if err != nil {
+			return nil, err
 		}
-		c.ServeJSON()
+		srcPolyDstRelation.DstHash = srcPolyDstRelation2.DstHash
+		srcPolyDstRelation.DstTransaction = srcPolyDstRelation2.DstTransaction
+	}
+	chains := make([]*models.Chain, 0)
+	db.Model(&models.Chain{}).Find(&chains)
+	chainsMap := make(map[uint64]*models.Chain)
+	for _, chain := range chains {
+		chainsMap[chain.ChainId] = chain
+	}
+	transaction := models.MakeTransactionRsp(srcPolyDstRelation, chainsMap)
+	if transaction == nil {
+		return nil, notFound("transaction does not exist")
+	}
+	return transaction, nil
+}
+
+const maxTransactionsOfHashes = 1000
+
+// TransactionsOfHashes resolves source, poly and destination hashes in a fixed
+// number of queries. Poly and destination hashes are mapped to the source hash
+// first, then all the transactions are loaded together.
+func (c *TransactionController) TransactionsOfHashes() {
+	var req models.TransactionsOfHashesReq
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &req); err != nil {
+		c.return400(fmt.Sprintf("request parameter is invalid, 1 to %d hashes are allowed!", maxTransactionsOfHashes))
+		return
+	}
+	rsp, err := QueryTransactionsOfHashes(req.Hashes)
+	if errors.Is(err, ErrInvalidParameter) {
+		c.return400(err.Error())
 		return
 	}
-	srcPolyDstRelation2, err := c.getTransactionByHash(srcPolyDstRelation.DstHash)
 	if err != nil {
-		c.Data["json"] = err.Error()
-		c.Ctx.ResponseWriter.WriteHeader(400)
+		c.Data["json"] = models.MakeErrorRsp("service error!")
+		c.Ctx.ResponseWriter.WriteHeader(500)
 		c.ServeJSON()
 		return
 	}
-	srcPolyDstRelation.DstHash = srcPolyDstRelation2.DstHash
-	srcPolyDstRelation.DstTransaction = srcPolyDstRelation2.DstTransaction
+	c.Data["json"] = rsp
+	c.ServeJSON()
+}
+
+// QueryTransactionsOfHashes looks up the transactions of source, poly or
+// destination hashes, the hashes that match nothing are listed as not found.
+func QueryTransactionsOfHashes(hashes []string) (*models.TransactionsOfHashesRsp, error) {
+	if len(hashes) == 0 || len(hashes) > maxTransactionsOfHashes {
+		return nil, invalidParameter(fmt.Sprintf("request parameter is invalid, 1 to %d hashes are allowed!", maxTransactionsOfHashes))
+	}
+	srcHashes := make(map[string]string)
+	lookups := make([]string, 0, len(hashes)*2)
+	for _, hash := range hashes {
+		hash = normalizeHash(hash)
+		if _, ok := srcHashes[hash]; hash == "" || ok {
+			continue
+		}
+		srcHashes[hash] = hash
+		lookups = append(lookups, hash, "0x"+hash)
+	}
+
+	polyTransactions := make([]*models.PolyTransaction, 0)
+	err := db.Select("hash, src_hash").Where("hash in ?", lookups).Find(&polyTransactions).Error
+	if err == nil {
+		for _, tx := range polyTransactions {
+			srcHashes[normalizeHash(tx.Hash)] = tx.SrcHash
+		}
+		dstSrcHashes := make([]*struct {
+			Hash    string
+			SrcHash string
+		}, 0)
+		err = db.Table("dst_transactions").
+			Select("dst_transactions.hash as hash, poly_transactions.src_hash as src_hash").
+			Joins("inner join poly_transactions on dst_transactions.poly_hash = poly_transactions.hash").
+			Where("dst_transactions.hash in ?", lookups).
+			Scan(&dstSrcHashes).Error
+		for _, tx := range dstSrcHashes {
+			srcHashes[normalizeHash(tx.Hash)] = tx.SrcHash
+		}
+	}
+	srcPolyDstRelations := make([]*models.SrcPolyDstRelation, 0)
+	if err == nil {
+		srcLookups := make([]string, 0, len(srcHashes)*2)
+		for _, hash := range srcHashes {
+			srcLookups = append(srcLookups, hash, "0x"+normalizeHash(hash))
+		}
+		err = db.Table("src_transactions").
+			Select("src_transactions.hash as src_hash, poly_transactions.hash as poly_hash, dst_transactions.hash as dst_hash, src_transactions.chain_id as chain_id, src_transfers.asset as token_hash, wrapper_transactions.fee_token_hash as fee_token_hash").
+			Where("src_transactions.hash in ?", srcLookups).
+			Where("src_transactions.standard = ?", 0).
+			Joins("left join wrapper_transactions on src_transactions.hash = wrapper_transactions.hash").
+			Joins("left join src_transfers on src_transactions.hash = src_transfers.tx_hash").
+			Joins("left join poly_transactions on src_transactions.hash = poly_transactions.src_hash").
+			Joins("left join dst_transactions on poly_transactions.hash = dst_transactions.poly_hash").
+			Preload("WrapperTransaction").
+			Preload("SrcTransaction").
+			Preload("SrcTransaction.SrcTransfer").
+			Preload("PolyTransaction").
+			Preload("DstTransaction").
+			Preload("DstTransaction.DstTransfer").
+			Preload("Token").
+			Preload("Token.TokenBasic").
+			Preload("FeeToken").
+			Find(&srcPolyDstRelations).Error
+	}
+	if err != nil {
+		logs.Error("Load transactions of hashes error %v", err)
+		return nil, err
+	}
+
 	chains := make([]*models.Chain, 0)
 	db.Model(&models.Chain{}).Find(&chains)
 	chainsMap := make(map[uint64]*models.Chain)
 	for _, chain := range chains {
 		chainsMap[chain.ChainId] = chain
 	}
-	c.Data["json"] = models.MakeTransactionRsp(srcPolyDstRelation, chainsMap)
-	c.ServeJSON()
+	transactions := make(map[string]*models.TransactionRsp)
+	for _, relation := range srcPolyDstRelations {
+		if _, ok := transactions[normalizeHash(relation.SrcHash)]; ok {
+			continue
+		}
+		if rsp := models.MakeTransactionRsp(relation, chainsMap); rsp != nil {
+			transactions[normalizeHash(relation.SrcHash)] = rsp
+		}
+	}
+	rsp := &models.TransactionsOfHashesRsp{
+		Transactions: make(map[string]*models.TransactionRsp),
+		NotFound:     make([]string, 0),
+	}
+	for _, hash := range hashes {
+		if _, ok := rsp.Transactions[hash]; ok {
+			continue
+		}
+		transaction, ok := transactions[normalizeHash(srcHashes[normalizeHash(hash)])]
+		if !ok {
+			rsp.NotFound = append(rsp.NotFound, hash)
+		}
+		rsp.Transactions[hash] = transaction
+	}
+	return rsp, nil
 }
 
 func (c *TransactionController) TransactionOfCurve() {
@@ -316,7 +509,7 @@ func (c *TransactionController) TransactionOfCurve() {
 		c.ServeJSON()
 		return
 	}
-	srcPolyDstRelation1, err := c.getTransactionByDstHash(transactionOfHashReq.Hash)
+	srcPolyDstRelation1, err := getTransactionByDstHash(transactionOfHashReq.Hash)
 	if err != nil {
 		c.Data["json"] = err.Error()
 		c.Ctx.ResponseWriter.WriteHeader(400)
@@ -329,7 +522,7 @@ func (c *TransactionController) TransactionOfCurve() {
 		c.ServeJSON()
 		return
 	}
-	srcPolyDstRelation2, err := c.getTransactionByHash(srcPolyDstRelation1.DstHash)
+	srcPolyDstRelation2, err := getTransactionByHash(srcPolyDstRelation1.DstHash)
 	if err != nil {
 		c.Data["json"] = err.Error()
 		c.Ctx.ResponseWriter.WriteHeader(400)
@@ -353,8 +546,28 @@ func (c *TransactionController) TransactionsOfState() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	transactions := make([]*models.WrapperTransaction, 0)
+	if transactionsOfStateReq.UseCursor || transactionsOfStateReq.Cursor != "" {
+		scope, err := cursorScope("wrapper_transactions", transactionsOfStateReq.Cursor, false)
+		if err != nil || transactionsOfStateReq.PageSize <= 0 {
+			c.return400("request parameter is invalid!")
+			return
+		}
+		db.Where("status = ?", transactionsOfStateReq.State).Scopes(scope).Limit(transactionsOfStateReq.PageSize + 1).Find(&transactions)
+		var transactionNum int64
+		if transactionsOfStateReq.WithCount {
+			db.Model(&models.WrapperTransaction{}).Where("status = ?", transactionsOfStateReq.State).Count(&transactionNum)
+		}
+		var nextCursor string
+		transactions, nextCursor = nextWrappersCursor(transactions, transactionsOfStateReq.PageSize)
+		rsp := models.MakeTransactionsOfStateRsp(transactionsOfStateReq.PageSize, 0, 0, int(transactionNum), transactions)
+		rsp.NextCursor = nextCursor
+		c.Data["json"] = rsp
+		c.ServeJSON()
+		return
+	}
 	db.Where("status = ?", transactionsOfStateReq.State).Limit(transactionsOfStateReq.PageSize).Offset(transactionsOfStateReq.PageSize * transactionsOfStateReq.PageNo).Order("time asc").Find(&transactions)
 	var transactionNum int64
 	db.Model(&models.WrapperTransaction{}).Where("status = ?", transactionsOfStateReq.State).Count(&transactionNum)
@@ -370,6 +583,7 @@ func (c *TransactionController) TransactionsOfUnfinished() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
 	}
 	srcPolyDstRelations := make([]*models.SrcPolyDstRelation, 0)
 	tt := time.Now().Unix()
@@ -421,6 +635,21 @@ func (c *TransactionController) TransactionsOfAsset() {
 		c.Data["json"] = models.MakeErrorRsp(fmt.Sprintf("request parameter is invalid!"))
 		c.Ctx.ResponseWriter.WriteHeader(400)
 		c.ServeJSON()
+		return
+	}
+	useCursor := transactionsOfAssetReq.UseCursor || transactionsOfAssetReq.Cursor != ""
+	page := func(tx *gorm.DB) *gorm.DB {
+		return tx.Limit(transactionsOfAssetReq.PageSize).Offset(transactionsOfAssetReq.PageSize * transactionsOfAssetReq.PageNo).Order("src_transactions.time desc")
+	}
+	if useCursor {
+		scope, err := cursorScope("src_transactions", transactionsOfAssetReq.Cursor, true)
+		if err != nil || transactionsOfAssetReq.PageSize <= 0 {
+			c.return400("request parameter is invalid!")
+			return
+		}
+		page = func(tx *gorm.DB) *gorm.DB {
+			return tx.Scopes(scope).Limit(transactionsOfAssetReq.PageSize + 1)
+		}
 	}
 	srcPolyDstRelations := make([]*models.SrcPolyDstRelation, 0)
 	res := db.Table("src_transactions").
@@ -441,8 +670,7 @@ func (c *TransactionController) TransactionsOfAsset() {
 		Preload("Token").
 		Preload("Token.TokenBasic").
 		Preload("FeeToken").
-		Limit(transactionsOfAssetReq.PageSize).Offset(transactionsOfAssetReq.PageSize * transactionsOfAssetReq.PageNo).
-		Order("src_transactions.time desc").
+		Scopes(page).
 		Find(&srcPolyDstRelations)
 	if res.Error != nil {
 		c.Data["json"] = res.Error.Error()
@@ -451,14 +679,25 @@ func (c *TransactionController) TransactionsOfAsset() {
 		return
 	}
 	var transactionNum int64
-	db.Table("src_transactions").
-		Where("src_transfers.asset = ?", transactionsOfAssetReq.Asset).
-		Where("src_transfers.chain_id = ?", transactionsOfAssetReq.Chain).
-		Where("src_transactions.standard = ?", 0).
-		Joins("left join src_transfers on src_transactions.hash = src_transfers.tx_hash").
-		Joins("left join poly_transactions on src_transactions.hash = poly_transactions.src_hash").
-		Joins("left join dst_transactions on poly_transactions.hash = dst_transactions.poly_hash").
-		Count(&transactionNum)
+	if !useCursor || transactionsOfAssetReq.WithCount {
+		db.Table("src_transactions").
+			Where("src_transfers.asset = ?", transactionsOfAssetReq.Asset).
+			Where("src_transfers.chain_id = ?", transactionsOfAssetReq.Chain).
+			Where("src_transactions.standard = ?", 0).
+			Joins("left join src_transfers on src_transactions.hash = src_transfers.tx_hash").
+			Joins("left join poly_transactions on src_transactions.hash = poly_transactions.src_hash").
+			Joins("left join dst_transactions on poly_transactions.hash = dst_transactions.poly_hash").
+			Count(&transactionNum)
+	}
+	if useCursor {
+		var nextCursor string
+		srcPolyDstRelations, nextCursor = nextRelationsCursor(srcPolyDstRelations, transactionsOfAssetReq.PageSize)
+		rsp := models.MakeTransactionOfUnfinishedRsp(transactionsOfAssetReq.PageSize, 0, 0, int(transactionNum), srcPolyDstRelations)
+		rsp.NextCursor = nextCursor
+		c.Data["json"] = rsp
+		c.ServeJSON()
+		return
+	}
 	c.Data["json"] = models.MakeTransactionOfUnfinishedRsp(transactionsOfAssetReq.PageSize, transactionsOfAssetReq.PageNo,
 		(int(transactionNum)+transactionsOfAssetReq.PageSize-1)/transactionsOfAssetReq.PageSize, int(transactionNum), srcPolyDstRelations)
 	c.ServeJSON()
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"poly-bridge/basedef"
+	"poly-bridge/conf"
+	"poly-bridge/database"
+	"poly-bridge/models"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// useTestDB points the package db to a new sqlite database until the returned
+// func is called.
+func useTestDB(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "http")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbCfg := &conf.DBConfig{Driver: basedef.DB_DRIVER_SQLITE, URL: filepath.Join(dir, "bridge.db"), AutoMigrate: true}
+	testDB, err := database.Open(dbCfg, &gorm.Config{})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	saved := db
+	db = testDB
+	return func() {
+		db = saved
+		os.RemoveAll(dir)
+	}
+}
+
+func TestQueryTransactionsOfHashes(t *testing.T) {
+	defer useTestDB(t)()
+	zero := models.NewBigIntFromInt(0)
+	for _, hash := range []string{"ab01", "ab11"} {
+		db.Create(&models.WrapperTransaction{Hash: hash, SrcChainId: 2, DstChainId: 6, FeeAmount: zero})
+		db.Create(&models.SrcTransaction{Hash: hash, ChainId: 2, DstChainId: 6, Fee: zero})
+	}
+	db.Create(&models.PolyTransaction{Hash: "cd02", SrcHash: "ab01", SrcChainId: 2, DstChainId: 6, Fee: zero})
+	db.Create(&models.DstTransaction{Hash: "ef03", PolyHash: "cd02", ChainId: 6, SrcChainId: 2, Fee: zero})
+
+	cases := []struct {
+		name     string
+		hashes   []string
+		expect   map[string]string
+		notFound []string
+	}{
+		{"source", []string{"ab01"}, map[string]string{"ab01": "ab01"}, nil},
+		{"poly", []string{"cd02"}, map[string]string{"cd02": "ab01"}, nil},
+		{"destination", []string{"ef03"}, map[string]string{"ef03": "ab01"}, nil},
+		{"case and prefix", []string{"0xAB01", "0xCD02", "EF03"}, map[string]string{"0xAB01": "ab01", "0xCD02": "ab01", "EF03": "ab01"}, nil},
+		{"repeated", []string{"ab11", "ab11", "0xab11"}, map[string]string{"ab11": "ab11", "0xab11": "ab11"}, nil},
+		{"unknown", []string{"ab01", "ff99", "ff99"}, map[string]string{"ab01": "ab01"}, []string{"ff99"}},
+	}
+	for _, c := range cases {
+		rsp, err := QueryTransactionsOfHashes(c.hashes)
+		if err != nil {
+			t.Errorf("%s: err %v", c.name, err)
+			continue
+		}
+		if len(rsp.Transactions) != len(c.expect)+len(c.notFound) {
+			t.Errorf("%s: got %d transactions, expect %d", c.name, len(rsp.Transactions), len(c.expect)+len(c.notFound))
+		}
+		for hash, srcHash := range c.expect {
+			if transaction := rsp.Transactions[hash]; transaction == nil || transaction.Hash != srcHash {
+				t.Errorf("%s: transaction of %s = %+v, expect source %s", c.name, hash, transaction, srcHash)
+			}
+		}
+		notFound := c.notFound
+		if notFound == nil {
+			notFound = []string{}
+		}
+		if !reflect.DeepEqual(rsp.NotFound, notFound) {
+			t.Errorf("%s: not found %v, expect %v", c.name, rsp.NotFound, notFound)
+		}
+	}
+
+	if _, err := QueryTransactionsOfHashes(nil); err == nil {
+		t.Errorf("no hashes should be invalid")
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"poly-bridge/crosschaindao/bridgedao"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+	"gorm.io/gorm"
+)
+
+const (
+	ExportFormatCsv    = "csv"
+	ExportFormatNdjson = "ndjson"
+
+	// exportBatchSize is the number of transactions loaded at a time, the
+	// export is flushed after each batch
+	exportBatchSize = 500
+)
+
+var exportContentTypes = map[string]string{
+	ExportFormatCsv:    "text/csv; charset=utf-8",
+	ExportFormatNdjson: "application/x-ndjson",
+}
+
+var exportCsvHeader = []string{"Time", "SrcChainId", "DstChainId", "From", "To", "Token", "TokenHash", "Amount", "AmountUsd",
+	"FeeToken", "FeeAmount", "FeeUsd", "State", "SrcHash", "PolyHash", "DstHash"}
+
+type exportWriter interface {
+	write(row *models.TransactionExportRow) error
+	flush() error
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (e *csvExportWriter) write(row *models.TransactionExportRow) error {
+	return e.w.Write([]string{
+		strconv.FormatUint(row.Time, 10),
+		strconv.FormatUint(row.SrcChainId, 10),
+		strconv.FormatUint(row.DstChainId, 10),
+		row.From, row.To, row.Token, row.TokenHash, row.Amount, row.AmountUsd,
+		row.FeeToken, row.FeeAmount, row.FeeUsd,
+		strconv.FormatUint(row.State, 10),
+		row.SrcHash, row.PolyHash, row.DstHash,
+	})
+}
+
+func (e *csvExportWriter) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type ndjsonExportWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (e *ndjsonExportWriter) write(row *models.TransactionExportRow) error {
+	return e.enc.Encode(row)
+}
+
+func (e *ndjsonExportWriter) flush() error {
+	return e.w.Flush()
+}
+
+func newExportWriter(format string, w io.Writer) exportWriter {
+	if format == ExportFormatNdjson {
+		buffer := bufio.NewWriter(w)
+		return &ndjsonExportWriter{w: buffer, enc: json.NewEncoder(buffer)}
+	}
+	writer := csv.NewWriter(w)
+	// the error of the header shows up on the first flush
+	writer.Write(exportCsvHeader)
+	return &csvExportWriter{w: writer}
+}
+
+// checkTransactionsExportReq fills the default format and checks the request.
+func checkTransactionsExportReq(req *models.TransactionsExportReq) error {
+	if req.Format == "" {
+		req.Format = ExportFormatCsv
+	}
+	if _, ok := exportContentTypes[req.Format]; !ok {
+		return invalidParameter(fmt.Sprintf("format %s is not supported!", req.Format))
+	}
+	if len(req.Addresses) == 0 {
+		return invalidParameter("request parameter is invalid!")
+	}
+	if req.EndTime > 0 && req.EndTime <= req.StartTime {
+		return invalidParameter("time range is invalid!")
+	}
+	return nil
+}
+
+// ExportTransactions writes the transactions of the request to w in the
+// order they were sent. They are loaded by batches and w is flushed after
+// each one, so the size of the export is not bounded by the memory. Nothing
+// is written when the request is invalid.
+func ExportTransactions(tx *gorm.DB, req *models.TransactionsExportReq, w io.Writer) error {
+	if err := checkTransactionsExportReq(req); err != nil {
+		return err
+	}
+	timeRange := func(tx *gorm.DB) *gorm.DB {
+		if req.StartTime > 0 {
+			tx = tx.Where("src_transactions.time >= ?", req.StartTime)
+		}
+		if req.EndTime > 0 {
+			tx = tx.Where("src_transactions.time < ?", req.EndTime)
+		}
+		return tx
+	}
+	writer := newExportWriter(req.Format, w)
+	cursor := ""
+	for {
+		page, err := cursorScope("src_transactions", cursor, false)
+		if err != nil {
+			return err
+		}
+		relations := make([]*models.SrcPolyDstRelation, 0)
+		err = transactionsWithFilter(tx, req.Addresses, req.Assets, req.SrcChainId, req.DstChainId).
+			Scopes(timeRange).
+			Preload("WrapperTransaction").
+			Preload("SrcTransaction").
+			Preload("SrcTransaction.SrcTransfer").
+			Preload("Token").
+			Preload("Token.TokenBasic").
+			Preload("FeeToken").
+			Preload("FeeToken.TokenBasic").
+			Scopes(page).
+			Limit(exportBatchSize + 1).
+			Find(&relations).Error
+		if err != nil {
+			return err
+		}
+		relations, cursor = nextRelationsCursor(relations, exportBatchSize)
+		prices, err := exportPriceSamples(tx, relations)
+		if err != nil {
+			return err
+		}
+		for _, relation := range relations {
+			row := models.MakeTransactionExportRow(relation, prices)
+			if row == nil {
+				continue
+			}
+			if err := writer.write(row); err != nil {
+				return err
+			}
+		}
+		if err := writer.flush(); err != nil {
+			return err
+		}
+		if flusher, ok := w.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+		if cursor == "" {
+			return nil
+		}
+	}
+}
+
+func (c *TransactionController) TransactionsExport() {
+	var req models.TransactionsExportReq
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &req); err != nil {
+		c.return400("request parameter is invalid!")
+		return
+	}
+	if err := checkTransactionsExportReq(&req); err != nil {
+		c.return400(err.Error())
+		return
+	}
+	c.EnableRender = false
+	w := c.Ctx.ResponseWriter
+	w.Header().Set("Content-Type", exportContentTypes[req.Format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"transactions.%s\"", req.Format))
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(200)
+	if err := ExportTransactions(db, &req, w); err != nil {
+		// the status is sent already, the client gets a truncated export
+		logs.Error("export transactions of %v err: %v", req.Addresses, err)
+	}
+}
+
+// exportPriceSamples returns the kept prices of the tokens of the
+// transactions over their time.
+func exportPriceSamples(tx *gorm.DB, relations []*models.SrcPolyDstRelation) (models.TokenPriceSamples, error) {
+	names := make([]string, 0)
+	from, to := int64(-1), int64(0)
+	for _, relation := range relations {
+		if relation.WrapperTransaction == nil {
+			continue
+		}
+		for _, token := range []*models.Token{relation.Token, relation.FeeToken} {
+			if token != nil {
+				names = append(names, token.TokenBasicName)
+			}
+		}
+		time := int64(relation.WrapperTransaction.Time)
+		if from < 0 || time < from {
+			from = time
+		}
+		if time > to {
+			to = time
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	return bridgedao.NewBridgeDaoWithDB(tx).GetTokenPriceSamples(names, from, to)
+}
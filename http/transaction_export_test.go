@@ -0,0 +1,104 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"poly-bridge/models"
+	"strings"
+	"testing"
+)
+
+func exportRelation() *models.SrcPolyDstRelation {
+	fee, _ := new(big.Int).SetString("10000000000000000", 10)
+	return &models.SrcPolyDstRelation{
+		SrcHash:  "src",
+		PolyHash: "poly",
+		WrapperTransaction: &models.WrapperTransaction{Time: 1622476800, User: "alice", SrcChainId: 2, DstChainId: 6,
+			FeeAmount: models.NewBigInt(fee), Status: 0},
+		SrcTransaction: &models.SrcTransaction{SrcTransfer: &models.SrcTransfer{Amount: models.NewBigIntFromInt(1500000), DstUser: "bob"}},
+		TokenHash:      "usdt",
+		Token:          &models.Token{Name: "USDT", Precision: 6, TokenBasicName: "USDT", TokenBasic: &models.TokenBasic{Price: 200000000, Ind: 1}},
+		FeeToken:       &models.Token{Name: "ETH", Precision: 18, TokenBasicName: "ETH"},
+	}
+}
+
+func TestExportCsv(t *testing.T) {
+	out := new(bytes.Buffer)
+	writer := newExportWriter(ExportFormatCsv, out)
+	if err := writer.write(models.MakeTransactionExportRow(exportRelation(), nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.flush(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || lines[0] != strings.Join(exportCsvHeader, ",") {
+		t.Fatalf("csv export = %q", out.String())
+	}
+	if want := "1622476800,2,6,alice,bob,USDT,usdt,1.5,3,ETH,0.01,,0,src,poly,"; lines[1] != want {
+		t.Errorf("csv row = %s, want %s", lines[1], want)
+	}
+}
+
+func TestExportNdjson(t *testing.T) {
+	out := new(bytes.Buffer)
+	writer := newExportWriter(ExportFormatNdjson, out)
+	for i := 0; i < 2; i++ {
+		if err := writer.write(models.MakeTransactionExportRow(exportRelation(), nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if out.Len() != 0 {
+		t.Errorf("rows are written before the flush")
+	}
+	if err := writer.flush(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ndjson export = %q", out.String())
+	}
+	row := new(models.TransactionExportRow)
+	if err := json.Unmarshal([]byte(lines[1]), row); err != nil {
+		t.Fatal(err)
+	}
+	if row.Amount != "1.5" || row.AmountUsd != "3" || row.FeeAmount != "0.01" || row.FeeUsd != "" {
+		t.Errorf("ndjson row = %+v", row)
+	}
+}
+
+func TestExportHistoryPrice(t *testing.T) {
+	prices := models.TokenPriceSamples{
+		"USDT": {{TokenBasicName: "USDT", Price: 100000000, Time: 1622476000}, {TokenBasicName: "USDT", Price: 300000000, Time: 1622477600}},
+		"ETH":  {{TokenBasicName: "ETH", Price: 250000000000, Time: 1622470000}},
+	}
+	row := models.MakeTransactionExportRow(exportRelation(), prices)
+	if row.AmountUsd != "3" || row.FeeUsd != "25" {
+		t.Errorf("usd values at the prices of the time = %s, %s", row.AmountUsd, row.FeeUsd)
+	}
+	prices["USDT"] = prices["USDT"][1:]
+	if row := models.MakeTransactionExportRow(exportRelation(), prices); row.AmountUsd != "4.5" {
+		t.Errorf("usd value at the nearest price = %s", row.AmountUsd)
+	}
+}
+
+func TestCheckTransactionsExportReq(t *testing.T) {
+	req := &models.TransactionsExportReq{Addresses: []string{"alice"}, Assets: []string{"usdt"}}
+	if err := checkTransactionsExportReq(req); err != nil || req.Format != ExportFormatCsv {
+		t.Errorf("default format = %s, err %v", req.Format, err)
+	}
+	if err := checkTransactionsExportReq(&models.TransactionsExportReq{Addresses: []string{"alice"}}); err != nil {
+		t.Errorf("assets should be optional, err %v", err)
+	}
+	invalids := []*models.TransactionsExportReq{
+		{Addresses: []string{"alice"}, Assets: []string{"usdt"}, Format: "xlsx"},
+		{Addresses: []string{"alice"}, Assets: []string{"usdt"}, StartTime: 10, EndTime: 10},
+		{Assets: []string{"usdt"}},
+	}
+	for _, req := range invalids {
+		if err := checkTransactionsExportReq(req); err == nil {
+			t.Errorf("request %+v should be invalid", req)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"poly-bridge/cacheRedis"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+	goredis "github.com/go-redis/redis"
+)
+
+const (
+	statusStreamBuffer    = 16
+	statusStreamKeepAlive = time.Second * 15
+	statusResubscribeMin  = time.Second
+	statusResubscribeMax  = time.Minute
+)
+
+// statusSubscriber is one streaming client. Once an event matches, the hashes
+// of the other legs are remembered so later legs that only carry the poly hash
+// are delivered too.
+type statusSubscriber struct {
+	hashes map[string]bool
+	user   string
+	events chan *models.TransactionStatusEvent
+}
+
+func newStatusSubscriber(hashes []string, user string) *statusSubscriber {
+	s := &statusSubscriber{
+		hashes: make(map[string]bool),
+		user:   normalizeHash(user),
+		events: make(chan *models.TransactionStatusEvent, statusStreamBuffer),
+	}
+	for _, hash := range hashes {
+		if hash = normalizeHash(hash); hash != "" {
+			s.hashes[hash] = true
+		}
+	}
+	return s
+}
+
+func (s *statusSubscriber) match(event *models.TransactionStatusEvent) bool {
+	hashes := []string{normalizeHash(event.Hash), normalizeHash(event.PolyHash), normalizeHash(event.DstHash)}
+	matched := s.user != "" && (normalizeHash(event.User) == s.user || normalizeHash(event.DstUser) == s.user)
+	for _, hash := range hashes {
+		if hash != "" && s.hashes[hash] {
+			matched = true
+		}
+	}
+	if matched {
+		for _, hash := range hashes {
+			if hash != "" {
+				s.hashes[hash] = true
+			}
+		}
+	}
+	return matched
+}
+
+func normalizeHash(hash string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(hash)), "0x")
+}
+
+// statusHub holds one redis subscription per http instance and fans the
+// events out to the local streaming clients.
+type statusHub struct {
+	subscribers map[*statusSubscriber]bool
+	mutex       sync.Mutex
+	once        sync.Once
+}
+
+var transactionStatusHub = &statusHub{
+	subscribers: make(map[*statusSubscriber]bool),
+}
+
+func (h *statusHub) subscribe(s *statusSubscriber) {
+	h.once.Do(func() {
+		go h.run()
+	})
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.subscribers[s] = true
+}
+
+func (h *statusHub) unsubscribe(s *statusSubscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.subscribers, s)
+}
+
+// run keeps the redis subscription, it is made again with a growing backoff
+// each time it is lost. The events published in between are missed.
+func (h *statusHub) run() {
+	backoff := statusResubscribeMin
+	for {
+		if h.listen() {
+			backoff = statusResubscribeMin
+		}
+		logs.Warn("resubscribe transaction status in %v", backoff)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > statusResubscribeMax {
+			backoff = statusResubscribeMax
+		}
+	}
+}
+
+// listen dispatches the events of one subscription until it is lost and
+// tells if it was made. The connection is pinged when no event comes for a
+// while, a ping without answer means it is lost.
+func (h *statusHub) listen() bool {
+	pubsub := cacheRedis.Redis.SubscribeTransactionStatus()
+	defer pubsub.Close()
+	if _, err := pubsub.Receive(); err != nil {
+		logs.Error("subscribe transaction status err: %v", err)
+		return false
+	}
+	pinged := false
+	for {
+		message, err := pubsub.ReceiveTimeout(statusStreamKeepAlive * 2)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() && !pinged {
+				if err = pubsub.Ping(); err == nil {
+					pinged = true
+					continue
+				}
+			}
+			logs.Error("transaction status subscription is lost, err: %v", err)
+			return true
+		}
+		pinged = false
+		if message, ok := message.(*goredis.Message); ok {
+			event := new(models.TransactionStatusEvent)
+			if err := json.Unmarshal([]byte(message.Payload), event); err != nil {
+				logs.Error("unmarshal transaction status %s err: %v", message.Payload, err)
+				continue
+			}
+			h.dispatch(event)
+		}
+	}
+}
+
+// dispatch never blocks on a slow client, its events are dropped instead.
+func (h *statusHub) dispatch(event *models.TransactionStatusEvent) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for s := range h.subscribers {
+		if !s.match(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			logs.Warn("transaction status stream is full, drop event of %s", event.Hash)
+		}
+	}
+}
+
+// WatchTransactionStatus subscribes to the status transitions of the
+// transactions selected by hash or user, the returned func ends the watch.
+func WatchTransactionStatus(hashes []string, user string) (<-chan *models.TransactionStatusEvent, func()) {
+	s := newStatusSubscriber(hashes, user)
+	transactionStatusHub.subscribe(s)
+	return s.events, func() {
+		transactionStatusHub.unsubscribe(s)
+	}
+}
+
+// TransactionStatus streams the status transitions of the transactions
+// selected by hash, polyhash or user as server sent events.
+func (c *TransactionController) TransactionStatus() {
+	hash, polyHash, user := c.GetString("hash"), c.GetString("polyhash"), c.GetString("user")
+	if hash == "" && polyHash == "" && user == "" {
+		c.return400("request parameter is invalid!")
+		return
+	}
+	c.EnableRender = false
+	events, stop := WatchTransactionStatus([]string{hash, polyHash}, user)
+	defer stop()
+
+	w := c.Ctx.ResponseWriter
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(200)
+	w.Flush()
+
+	keepAlive := time.NewTicker(statusStreamKeepAlive)
+	defer keepAlive.Stop()
+	done := c.Ctx.Request.Context().Done()
+	for {
+		select {
+		case <-done:
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+				return
+			}
+		}
+		w.Flush()
+	}
+}
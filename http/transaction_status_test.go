@@ -0,0 +1,27 @@
+package http
+
+import (
+	"poly-bridge/models"
+	"testing"
+)
+
+func TestStatusSubscriberMatch(t *testing.T) {
+	s := newStatusSubscriber([]string{"0xAB01", ""}, "")
+	if s.match(&models.TransactionStatusEvent{PolyHash: "cd02"}) {
+		t.Errorf("unknown poly hash should not match")
+	}
+	if !s.match(&models.TransactionStatusEvent{Hash: "ab01", PolyHash: "cd02"}) {
+		t.Errorf("source hash should match")
+	}
+	if !s.match(&models.TransactionStatusEvent{PolyHash: "0xCD02", DstHash: "ef03"}) {
+		t.Errorf("poly hash learned from the source leg should match")
+	}
+
+	u := newStatusSubscriber(nil, "0xUser")
+	if !u.match(&models.TransactionStatusEvent{Hash: "ab01", DstUser: "user"}) {
+		t.Errorf("destination user should match")
+	}
+	if u.match(&models.TransactionStatusEvent{Hash: "ab02", User: "other"}) {
+		t.Errorf("other user should not match")
+	}
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package http
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"poly-bridge/webhook"
+
+	"github.com/beego/beego/v2/server/web"
+	"gorm.io/gorm"
+)
+
+type WebhookController struct {
+	web.Controller
+}
+
+func (c *WebhookController) return400(message string) {
+	c.Data["json"] = models.MakeFieldErrorRsp(400, message, "")
+	c.Ctx.ResponseWriter.WriteHeader(400)
+	c.ServeJSON()
+}
+
+// Prepare rejects the webhook apis when the api keys are not checked, they
+// need a key with the webhook scope.
+func (c *WebhookController) Prepare() {
+	if apiAuth == nil {
+		c.Data["json"] = models.MakeErrorRsp("api auth is not enabled!")
+		c.Ctx.ResponseWriter.WriteHeader(400)
+		c.ServeJSON()
+		c.StopRun()
+	}
+}
+
+func (c *WebhookController) Subscribe() {
+	var req models.WebhookSubscribeReq
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &req); err != nil {
+		c.return400("request parameter is invalid!")
+		return
+	}
+	if err := webhook.CheckUrl(req.Url, conf.GlobalConfig.WebhookConfig.GetAllowPrivateReceivers()); err != nil {
+		c.return400("url is invalid!")
+		return
+	}
+	if req.User == "" && req.Asset == "" && req.ServerId == 0 {
+		c.return400("one of user, asset and server id is required!")
+		return
+	}
+	for _, event := range req.Events {
+		if event != models.WEBHOOK_EVENT_POLY && event != models.WEBHOOK_EVENT_DESTINATION && event != models.WEBHOOK_EVENT_STUCK {
+			c.return400("event " + event + " is invalid!")
+			return
+		}
+	}
+	req.User, req.Asset = webhook.Normalize(req.User), webhook.Normalize(req.Asset)
+	var count int64
+	err := db.Model(&models.WebhookSubscription{}).
+		Where(map[string]interface{}{"user": req.User, "asset": req.Asset, "server_id": req.ServerId}).
+		Count(&count).Error
+	if err != nil {
+		c.return400("query subscriptions failed!")
+		return
+	}
+	if count >= conf.GlobalConfig.WebhookConfig.GetMaxSubscriptions() {
+		c.return400("too many subscriptions of the user, asset and server id!")
+		return
+	}
+	if req.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			c.return400("generate secret failed!")
+			return
+		}
+		req.Secret = hex.EncodeToString(secret)
+	}
+	subscription := &models.WebhookSubscription{
+		Url:      req.Url,
+		Secret:   req.Secret,
+		User:     req.User,
+		Asset:    req.Asset,
+		ServerId: req.ServerId,
+		Events:   strings.Join(req.Events, ","),
+		Time:     uint64(time.Now().Unix()),
+	}
+	if err := db.Create(subscription).Error; err != nil {
+		c.return400("save subscription failed!")
+		return
+	}
+	c.Data["json"] = models.MakeWebhookSubscriptionRsp(subscription)
+	c.ServeJSON()
+}
+
+// getSubscription returns the subscription only when the secret matches.
+func (c *WebhookController) getSubscription(id int64, secret string) *models.WebhookSubscription {
+	subscription := new(models.WebhookSubscription)
+	res := db.Where("id = ?", id).Limit(1).Find(subscription)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(subscription.Secret), []byte(secret)) != 1 {
+		return nil
+	}
+	return subscription
+}
+
+func (c *WebhookController) Unsubscribe() {
+	var req models.WebhookUnsubscribeReq
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &req); err != nil {
+		c.return400("request parameter is invalid!")
+		return
+	}
+	subscription := c.getSubscription(req.Id, req.Secret)
+	if subscription == nil {
+		c.return400("subscription does not exist!")
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", subscription.Id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("subscription_id = ?", subscription.Id).Delete(&models.WebhookDeadLetter{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(subscription).Error
+	})
+	if err != nil {
+		c.return400("delete subscription failed!")
+		return
+	}
+	c.Data["json"] = models.MakeWebhookSubscriptionRsp(subscription)
+	c.ServeJSON()
+}
+
+// Replay queues the dead letters of a subscription for delivery again.
+func (c *WebhookController) Replay() {
+	var req models.WebhookReplayReq
+	if err := json.Unmarshal(c.Ctx.Input.RequestBody, &req); err != nil {
+		c.return400("request parameter is invalid!")
+		return
+	}
+	subscription := c.getSubscription(req.Id, req.Secret)
+	if subscription == nil {
+		c.return400("subscription does not exist!")
+		return
+	}
+	deadLetters := make([]*models.WebhookDeadLetter, 0)
+	query := db.Where("subscription_id = ?", subscription.Id)
+	if len(req.Ids) > 0 {
+		query = query.Where("id in ?", req.Ids)
+	}
+	if err := query.Find(&deadLetters).Error; err != nil {
+		c.return400("query dead letters failed!")
+		return
+	}
+	if len(deadLetters) > 0 {
+		now := uint64(time.Now().Unix())
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for _, deadLetter := range deadLetters {
+				delivery := &models.WebhookDelivery{
+					SubscriptionId: deadLetter.SubscriptionId,
+					Hash:           deadLetter.Hash,
+					Event:          deadLetter.Event,
+					Payload:        deadLetter.Payload,
+					NextTime:       now,
+					Time:           now,
+				}
+				if err := tx.Create(delivery).Error; err != nil {
+					return err
+				}
+				if err := tx.Delete(deadLetter).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			c.return400("replay dead letters failed!")
+			return
+		}
+	}
+	c.Data["json"] = &models.WebhookReplayRsp{Replayed: len(deadLetters)}
+	c.ServeJSON()
+}
@@ -29,6 +29,8 @@ import (
 	"poly-bridge/explorer"
 	"poly-bridge/http"
 	"poly-bridge/nft_http"
+	"poly-bridge/openapi"
+	"poly-bridge/rpc"
 
 	"github.com/beego/beego/v2/core/logs"
 	"github.com/beego/beego/v2/server/web"
@@ -80,12 +82,16 @@ func run(ctx *cli.Context) {
 		&cors.Options{
 			AllowAllOrigins:  true,
 			AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-			AllowHeaders:     []string{"Origin", "Authorization", "Access-Control-Allow-Origin", "Access-Control-Allow-Headers", "Content-Type"},
-			ExposeHeaders:    []string{"Content-Length", "Access-Control-Allow-Origin", "Access-Control-Allow-Headers", "Content-Type"},
+			AllowHeaders:     []string{"Origin", "Authorization", "Access-Control-Allow-Origin", "Access-Control-Allow-Headers", "Content-Type", "X-Api-Key"},
+			ExposeHeaders:    []string{"Content-Length", "Access-Control-Allow-Origin", "Access-Control-Allow-Headers", "Content-Type", "X-RateLimit-Limit", "X-RateLimit-Remaining", "Retry-After"},
 			AllowCredentials: true,
 		},
 	))
 
+	if config.ApiAuthConfig != nil {
+		web.InsertFilter("/v1/*", web.BeforeRouter, http.InitApiAuth(config.ApiAuthConfig, config.BotConfig))
+	}
+
 	// bridge http
 	http.Init()
 	// explorer http
@@ -102,6 +108,17 @@ func run(ctx *cli.Context) {
 		),
 	)
 
+	// openapi document and request validation
+	web.Get("/v1/openapi.json", openapi.ServeDocument)
+	web.InsertFilter("/v1/*", web.BeforeExec, openapi.Filter("/v1"))
+
+	// grpc server of the bridge queries
+	if config.GrpcConfig != nil {
+		if err := rpc.StartServer(config.GrpcConfig); err != nil {
+			panic(fmt.Sprintf("start grpc server err: %v", err))
+		}
+	}
+
 	// Insert web config
 	web.BConfig.Listen.HTTPAddr = config.HttpConfig.Address
 	web.BConfig.Listen.HTTPPort = config.HttpConfig.Port
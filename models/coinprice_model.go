@@ -61,6 +61,15 @@ type PriceMarket struct {
 	TokenBasic     *TokenBasic `gorm:"foreignKey:TokenBasicName;references:Name"`
 }
 
+// TokenPriceHistory is a price of a token basic at the time it was updated,
+// one is kept for every update of the price.
+type TokenPriceHistory struct {
+	Id             int64  `gorm:"primaryKey;autoIncrement"`
+	TokenBasicName string `gorm:"uniqueIndex:idx_token_price_time;size:64;not null"`
+	Price          int64  `gorm:"type:bigint(20);not null"`
+	Time           int64  `gorm:"uniqueIndex:idx_token_price_time;type:bigint(20);not null"`
+}
+
 type ChainFee struct {
 	Id             int64       `gorm:"primaryKey;autoIncrement"`
 	ChainId        uint64      `gorm:"uniqueIndex;type:bigint(20);not null"`
@@ -82,6 +91,7 @@ type CheckFeeRequest struct {
 	Paid                        float64
 	Min                         float64
 	Status                      CheckFeeStatus
+	QuoteId                     string
 	SrcTransaction              *SrcTransaction              `json:"-"`
 	WrapperTransactionWithToken *WrapperTransactionWithToken `json:"-"`
 }
@@ -24,6 +24,7 @@ type Chain struct {
 	Height              uint64 `gorm:"type:bigint(20);not null"`
 	HeightSwap          uint64 `gorm:"type:bigint(20);not null"`
 	BackwardBlockNumber uint64 `gorm:"type:bigint(20);not null"`
+	FinalizedHeight     uint64 `gorm:"type:bigint(20);not null;default:0"`
 }
 
 type ChainStatistic struct {
@@ -37,21 +38,22 @@ type ChainStatistic struct {
 }
 
 type SrcTransaction struct {
-	Id          int64        `gorm:"primaryKey;autoIncrement"`
-	Hash        string       `gorm:"uniqueIndex;size:66;not null"`
-	ChainId     uint64       `gorm:"type:bigint(20);not null"`
-	Standard    uint8        `gorm:"type:int(8);not null"`
-	State       uint64       `gorm:"type:bigint(20);not null"`
-	Time        uint64       `gorm:"type:bigint(20);not null"`
-	Fee         *BigInt      `gorm:"type:varchar(64);not null"`
-	Height      uint64       `gorm:"type:bigint(20);not null"`
-	User        string       `gorm:"type:varchar(66);not null"`
-	DstChainId  uint64       `gorm:"type:bigint(20);not null"`
-	Contract    string       `gorm:"type:varchar(66);not null"`
-	Key         string       `gorm:"index;size:128;not null"`
-	Param       string       `gorm:"type:varchar(8192);not null"`
-	SrcTransfer *SrcTransfer `gorm:"foreignKey:TxHash;references:Hash"`
-	SrcSwap     *SrcSwap     `gorm:"foreignKey:TxHash;references:Hash"`
+	Id            int64        `gorm:"primaryKey;autoIncrement"`
+	Hash          string       `gorm:"uniqueIndex;size:66;not null"`
+	ChainId       uint64       `gorm:"index:idx_src_transactions_chain_height;type:bigint(20);not null"`
+	Standard      uint8        `gorm:"type:int(8);not null"`
+	State         uint64       `gorm:"type:bigint(20);not null"`
+	Time          uint64       `gorm:"type:bigint(20);not null"`
+	Fee           *BigInt      `gorm:"type:varchar(64);not null"`
+	Height        uint64       `gorm:"index:idx_src_transactions_chain_height;type:bigint(20);not null"`
+	User          string       `gorm:"type:varchar(66);not null"`
+	DstChainId    uint64       `gorm:"type:bigint(20);not null"`
+	Contract      string       `gorm:"type:varchar(66);not null"`
+	Key           string       `gorm:"index;size:128;not null"`
+	Param         string       `gorm:"type:varchar(8192);not null"`
+	Confirmations uint64       `gorm:"type:bigint(20);not null;default:0"`
+	SrcTransfer   *SrcTransfer `gorm:"foreignKey:TxHash;references:Hash"`
+	SrcSwap       *SrcSwap     `gorm:"foreignKey:TxHash;references:Hash"`
 }
 
 type SrcTransfer struct {
@@ -108,19 +110,20 @@ type PolySrcRelation struct {
 }
 
 type DstTransaction struct {
-	Id          int64        `gorm:"primaryKey;autoIncrement"`
-	Hash        string       `gorm:"uniqueIndex;size:66;not null"`
-	ChainId     uint64       `gorm:"type:bigint(20);not null"`
-	Standard    uint8        `gorm:"type:int(8);not null"`
-	State       uint64       `gorm:"type:bigint(20);not null"`
-	Time        uint64       `gorm:"type:bigint(20);not null"`
-	Fee         *BigInt      `gorm:"type:varchar(64);not null"`
-	Height      uint64       `gorm:"type:bigint(20);not null"`
-	SrcChainId  uint64       `gorm:"type:bigint(20);not null"`
-	Contract    string       `gorm:"type:varchar(66);not null"`
-	PolyHash    string       `gorm:"index;size:66;not null"`
-	DstTransfer *DstTransfer `gorm:"foreignKey:TxHash;references:Hash"`
-	DstSwap     *DstSwap     `gorm:"foreignKey:TxHash;references:Hash"`
+	Id            int64        `gorm:"primaryKey;autoIncrement"`
+	Hash          string       `gorm:"uniqueIndex;size:66;not null"`
+	ChainId       uint64       `gorm:"index:idx_dst_transactions_chain_height;type:bigint(20);not null"`
+	Standard      uint8        `gorm:"type:int(8);not null"`
+	State         uint64       `gorm:"type:bigint(20);not null"`
+	Time          uint64       `gorm:"type:bigint(20);not null"`
+	Fee           *BigInt      `gorm:"type:varchar(64);not null"`
+	Height        uint64       `gorm:"index:idx_dst_transactions_chain_height;type:bigint(20);not null"`
+	SrcChainId    uint64       `gorm:"type:bigint(20);not null"`
+	Contract      string       `gorm:"type:varchar(66);not null"`
+	PolyHash      string       `gorm:"index;size:66;not null"`
+	Confirmations uint64       `gorm:"type:bigint(20);not null;default:0"`
+	DstTransfer   *DstTransfer `gorm:"foreignKey:TxHash;references:Hash"`
+	DstSwap       *DstSwap     `gorm:"foreignKey:TxHash;references:Hash"`
 }
 
 type DstTransfer struct {
@@ -232,6 +235,7 @@ type LockTokenStatistic struct {
 type AssetInfo struct {
 	Amount         *BigInt
 	Txnum          uint64
+	Time           int64
 	Price          int64
 	TokenBasicName string
 	Precision      uint64
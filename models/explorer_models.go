@@ -436,7 +436,7 @@ func MakeCrossTxResp(srcPolyDst *PolyTxRelation) *CrossTxResp {
 }
 
 type CrossTxListReq struct {
-	PageSize int
+	PageSize int `validate:"min=1"`
 	PageNo   int
 }
 
@@ -480,9 +480,9 @@ func MakeCrossTxListResp(txs []*SrcPolyDstRelation, counter int64) *CrossTxListR
 }
 
 type TokenTxListReq struct {
-	ChainId  uint64 `json:"chain"`
-	Token    string `json:"token"`
-	PageSize int
+	ChainId  uint64 `json:"chain" validate:"required"`
+	Token    string `json:"token" validate:"required"`
+	PageSize int    `validate:"min=1"`
 	PageNo   int
 }
 
@@ -524,9 +524,9 @@ func MakeTokenTxList(transactoins []*TransactionOnToken, counter int64, token *T
 }
 
 type AddressTxListReq struct {
-	PageSize int
+	PageSize int `validate:"min=1"`
 	PageNo   int
-	Address  string `json:"address"`
+	Address  string `json:"address" validate:"required"`
 	ChainId  uint64 `json:"chain"`
 }
 
@@ -818,5 +818,5 @@ func MakeLockTokenInfoResp(lockTokenStatistics []*LockTokenStatistic) []*LockTok
 }
 
 type NftSignReq struct {
-	Address string `json:"address"`
+	Address string `json:"address" validate:"required,min=2"`
 }
@@ -26,13 +26,24 @@ import (
 	"time"
 )
 
+// ApiUsageRsp has the request counters of the api clients by route, the
+// total field counts every route.
+type ApiUsageRsp struct {
+	Day   string
+	Usage map[string]map[string]string
+}
+
 type PolyBridgeResp struct {
 	Version string
 	URL     string
 }
 
+// ErrorRsp is the body of the failed requests, Code and Field are set by the
+// request validation.
 type ErrorRsp struct {
+	Code    int `json:",omitempty"`
 	Message string
+	Field   string `json:",omitempty"`
 }
 
 func MakeErrorRsp(messgae string) *ErrorRsp {
@@ -42,6 +53,14 @@ func MakeErrorRsp(messgae string) *ErrorRsp {
 	return errorRsp
 }
 
+func MakeFieldErrorRsp(code int, message string, field string) *ErrorRsp {
+	return &ErrorRsp{
+		Code:    code,
+		Message: message,
+		Field:   field,
+	}
+}
+
 type TokenBasicReq struct {
 	Name string
 }
@@ -115,7 +134,7 @@ func MakeTokenBasicsRsp(tokenBasics []*TokenBasic) *TokenBasicsRsp {
 }
 
 type TokenBasicsInfoReq struct {
-	PageSize int
+	PageSize int `validate:"required,min=1"`
 	PageNo   int
 	Order    string
 }
@@ -174,8 +193,8 @@ func MakeTokenBasicsInfoRsp(req *TokenBasicsInfoReq, count uint64, tokenBasics [
 }
 
 type TokenReq struct {
-	ChainId uint64
-	Hash    string
+	ChainId uint64 `validate:"required"`
+	Hash    string `validate:"required"`
 }
 
 type TokenRsp struct {
@@ -241,8 +260,55 @@ func MakePriceMarketRsp(priceMarket *PriceMarket) *PriceMarketRsp {
 	return priceMarketRsp
 }
 
+// TokenPriceHistoryReq selects the prices of a token basic from From to To in
+// unix seconds, one every Interval seconds. To is now by default and From a day
+// before To, the interval is chosen by the range when it is 0.
+type TokenPriceHistoryReq struct {
+	Name     string `validate:"required"`
+	From     int64
+	To       int64
+	Interval int64
+}
+
+type TokenPriceRsp struct {
+	Time  int64
+	Price string
+}
+
+type TokenPriceHistoryRsp struct {
+	Name     string
+	From     int64
+	To       int64
+	Interval int64
+	Prices   []*TokenPriceRsp
+}
+
+// MakeTokenPriceHistoryRsp samples the prices at every interval of the range
+// of the request, see TokenPriceAt. There is no price before the first one kept.
+func MakeTokenPriceHistoryRsp(req *TokenPriceHistoryReq, histories []*TokenPriceHistory) *TokenPriceHistoryRsp {
+	rsp := &TokenPriceHistoryRsp{
+		Name:     req.Name,
+		From:     req.From,
+		To:       req.To,
+		Interval: req.Interval,
+		Prices:   make([]*TokenPriceRsp, 0),
+	}
+	if len(histories) == 0 {
+		return rsp
+	}
+	for t := req.From; t <= req.To; t += req.Interval {
+		if t < histories[0].Time {
+			continue
+		}
+		price, _ := TokenPriceAt(histories, t)
+		value := new(big.Float).Quo(new(big.Float).SetInt64(price), new(big.Float).SetInt64(basedef.PRICE_PRECISION))
+		rsp.Prices = append(rsp.Prices, &TokenPriceRsp{Time: t, Price: value.String()})
+	}
+	return rsp
+}
+
 type TokensReq struct {
-	ChainId uint64
+	ChainId uint64 `validate:"required"`
 }
 
 type TokensRsp struct {
@@ -261,8 +327,8 @@ func MakeTokensRsp(tokens []*Token) *TokensRsp {
 }
 
 type TokenMapReq struct {
-	ChainId uint64
-	Hash    string
+	ChainId uint64 `validate:"required"`
+	Hash    string `validate:"required"`
 }
 
 type TokenMapRsp struct {
@@ -311,9 +377,9 @@ func MakeTokenMapsRsp(tokenMaps []*TokenMap) *TokenMapsRsp {
 }
 
 type GetFeeReq struct {
-	SrcChainId    uint64
-	Hash          string
-	DstChainId    uint64
+	SrcChainId    uint64 `validate:"required"`
+	Hash          string `validate:"required"`
+	DstChainId    uint64 `validate:"required"`
 	SwapTokenHash string
 }
 
@@ -327,6 +393,22 @@ type GetFeeRsp struct {
 	SwapTokenHash            string
 	Balance                  string
 	BalanceWithPrecision     string
+	Quote                    *FeeQuote
+}
+
+// FeeQuote is the fee of a GetFeeRsp signed by the server. A transaction of
+// SrcChainId to DstChainId sent between Time and Expiry, both in unix
+// seconds, and paying at least Amount of the token is taken as paid. Amount
+// is in the precision of the token.
+type FeeQuote struct {
+	Id         string
+	SrcChainId uint64
+	Hash       string
+	DstChainId uint64
+	Amount     string
+	Time       int64
+	Expiry     int64
+	Signature  string
 }
 
 func MakeGetFeeRsp(srcChainId uint64, hash string, dstChainId uint64, usdtAmount *big.Float, tokenAmount *big.Float, tokenAmountWithPrecision *big.Float,
@@ -370,7 +452,7 @@ type CheckFeeRsp struct {
 }
 
 type CheckFeesReq struct {
-	Checks []*CheckFeeReq
+	Checks []*CheckFeeReq `validate:"required"`
 }
 
 type CheckFeesRsp struct {
@@ -445,12 +527,12 @@ func MakeWrapperTransactionRsp(transaction *WrapperTransaction) *WrapperTransact
 }
 
 type WrapperTransactionsReq struct {
-	PageSize int
+	PageSize int `validate:"required,min=1"`
 	PageNo   int
 }
 
 type WrapperTransactionsWithFilterReq struct {
-	PageSize   int
+	PageSize   int `validate:"required,min=1"`
 	PageNo     int
 	SrcChainId int
 	DstChainId int
@@ -462,6 +544,7 @@ type WrapperTransactionsRsp struct {
 	PageNo       int
 	TotalPage    int
 	TotalCount   int
+	NextCursor   string
 	Transactions []*WrapperTransactionRsp
 }
 
@@ -479,7 +562,20 @@ func MakeWrapperTransactionsRsp(pageSize int, pageNo int, totalPage int, totalCo
 }
 
 type TransactionOfHashReq struct {
-	Hash string
+	Hash string `validate:"required"`
+}
+
+// TransactionsOfHashesReq looks up source, poly and destination hashes in one
+// request, with or without 0x in any case.
+type TransactionsOfHashesReq struct {
+	Hashes []string `validate:"required,min=1,max=1000"`
+}
+
+// TransactionsOfHashesRsp is keyed by the requested hashes, the ones that can
+// not be resolved map to null and are listed in NotFound.
+type TransactionsOfHashesRsp struct {
+	Transactions map[string]*TransactionRsp
+	NotFound     []string
 }
 
 type TransactionStateRsp struct {
@@ -488,6 +584,35 @@ type TransactionStateRsp struct {
 	Blocks     uint64
 	NeedBlocks uint64
 	Time       uint64
+	// Confirmations is 0 until the transaction is final and can not be reverted,
+	// then it is the blocks of the indexed chain on top of the transaction
+	Confirmations uint64
+}
+
+// confirmations counts the blocks on top of a final transaction from the
+// indexed chain height, the stored value only marks it final.
+func confirmations(stored uint64, height uint64, chain *Chain) uint64 {
+	if stored == 0 || chain == nil || chain.Height < height {
+		return stored
+	}
+	if current := chain.Height - height + 1; current > stored {
+		return current
+	}
+	return stored
+}
+
+// TransactionStatusEvent is pushed to the status stream subscribers when a
+// cross chain transaction moves on, hashes of legs not known yet are empty.
+type TransactionStatusEvent struct {
+	Hash       string
+	PolyHash   string
+	DstHash    string
+	User       string
+	DstUser    string
+	SrcChainId uint64
+	DstChainId uint64
+	Status     uint64
+	Time       uint64
 }
 
 type TransactionRsp struct {
@@ -584,6 +709,7 @@ func MakeTransactionRsp(transaction *SrcPolyDstRelation, chainsMap map[uint64]*C
 		srcTransactionState.Hash = transaction.SrcTransaction.Hash
 		srcTransactionState.ChainId = transaction.SrcTransaction.ChainId
 		srcTransactionState.Time = transaction.SrcTransaction.Time
+		srcTransactionState.Confirmations = confirmations(transaction.SrcTransaction.Confirmations, height, chainsMap[transaction.SrcTransaction.ChainId])
 
 		srcChain, ok := chainsMap[srcTransactionState.ChainId]
 		if ok {
@@ -633,6 +759,7 @@ func MakeTransactionRsp(transaction *SrcPolyDstRelation, chainsMap map[uint64]*C
 		dstTransactionState.Hash = transaction.DstTransaction.Hash
 		dstTransactionState.ChainId = transaction.DstTransaction.ChainId
 		dstTransactionState.Time = transaction.DstTransaction.Time
+		dstTransactionState.Confirmations = confirmations(transaction.DstTransaction.Confirmations, transaction.DstTransaction.Height, chainsMap[transaction.DstTransaction.ChainId])
 		dstTransactionState.NeedBlocks = 1
 
 		dstTransactionState.Blocks = transaction.DstTransaction.Height
@@ -707,10 +834,11 @@ func MakeCurveTransactionRsp(transaction1 *SrcPolyDstRelation, transaction2 *Src
 	}
 	if transaction1.SrcTransaction != nil {
 		transactionRsp.TransactionState = append(transactionRsp.TransactionState, &TransactionStateRsp{
-			Hash:    transaction1.SrcTransaction.Hash,
-			ChainId: transaction1.SrcTransaction.ChainId,
-			Blocks:  transaction1.SrcTransaction.Height,
-			Time:    transaction1.SrcTransaction.Time,
+			Hash:          transaction1.SrcTransaction.Hash,
+			ChainId:       transaction1.SrcTransaction.ChainId,
+			Blocks:        transaction1.SrcTransaction.Height,
+			Time:          transaction1.SrcTransaction.Time,
+			Confirmations: confirmations(transaction1.SrcTransaction.Confirmations, transaction1.SrcTransaction.Height, chainsMap[transaction1.SrcTransaction.ChainId]),
 		})
 	} else {
 		transactionRsp.TransactionState = append(transactionRsp.TransactionState, &TransactionStateRsp{
@@ -737,10 +865,11 @@ func MakeCurveTransactionRsp(transaction1 *SrcPolyDstRelation, transaction2 *Src
 	}
 	if transaction1.DstTransaction != nil {
 		transactionRsp.TransactionState = append(transactionRsp.TransactionState, &TransactionStateRsp{
-			Hash:    transaction1.DstTransaction.Hash,
-			ChainId: transaction1.DstTransaction.ChainId,
-			Blocks:  transaction1.DstTransaction.Height,
-			Time:    transaction1.DstTransaction.Time,
+			Hash:          transaction1.DstTransaction.Hash,
+			ChainId:       transaction1.DstTransaction.ChainId,
+			Blocks:        transaction1.DstTransaction.Height,
+			Time:          transaction1.DstTransaction.Time,
+			Confirmations: confirmations(transaction1.DstTransaction.Confirmations, transaction1.DstTransaction.Height, chainsMap[transaction1.DstTransaction.ChainId]),
 		})
 	} else {
 		transactionRsp.TransactionState = append(transactionRsp.TransactionState, &TransactionStateRsp{
@@ -767,10 +896,11 @@ func MakeCurveTransactionRsp(transaction1 *SrcPolyDstRelation, transaction2 *Src
 	}
 	if transaction2.DstTransaction != nil {
 		transactionRsp.TransactionState = append(transactionRsp.TransactionState, &TransactionStateRsp{
-			Hash:    transaction2.DstTransaction.Hash,
-			ChainId: transaction2.DstTransaction.ChainId,
-			Blocks:  transaction2.DstTransaction.Height,
-			Time:    transaction2.DstTransaction.Time,
+			Hash:          transaction2.DstTransaction.Hash,
+			ChainId:       transaction2.DstTransaction.ChainId,
+			Blocks:        transaction2.DstTransaction.Height,
+			Time:          transaction2.DstTransaction.Time,
+			Confirmations: confirmations(transaction2.DstTransaction.Confirmations, transaction2.DstTransaction.Height, chainsMap[transaction2.DstTransaction.ChainId]),
 		})
 	} else {
 		transactionRsp.TransactionState = append(transactionRsp.TransactionState, &TransactionStateRsp{
@@ -802,11 +932,16 @@ func MakeCurveTransactionRsp(transaction1 *SrcPolyDstRelation, transaction2 *Src
 	return transactionRsp
 }
 
+// Cursor paging is used when UseCursor or Cursor is set, PageNo is ignored
+// and TotalCount is only counted with WithCount.
 type TransactionsOfAddressReq struct {
 	State     int // -1 表示查全部
 	Addresses []string
-	PageSize  int
+	PageSize  int `validate:"min=1"`
 	PageNo    int
+	UseCursor bool
+	Cursor    string
+	WithCount bool
 }
 
 type TransactionsOfAddressWithFilterReq struct {
@@ -815,8 +950,11 @@ type TransactionsOfAddressWithFilterReq struct {
 	SrcChainId int
 	DstChainId int
 	Assets     []string
-	PageSize   int
+	PageSize   int `validate:"required,min=1"`
 	PageNo     int
+	UseCursor  bool
+	Cursor     string
+	WithCount  bool
 }
 
 type TransactionsOfAddressRsp struct {
@@ -824,6 +962,7 @@ type TransactionsOfAddressRsp struct {
 	PageNo       int
 	TotalPage    int
 	TotalCount   int
+	NextCursor   string
 	Transactions []*TransactionRsp
 }
 
@@ -841,22 +980,112 @@ func MakeTransactionsOfUserRsp(pageSize int, pageNo int, totalPage int, totalCou
 	return transactionsRsp
 }
 
+// TransactionsExportReq selects the transactions of TransactionsOfAddressWithFilterReq
+// sent in [StartTime, EndTime), both in unix seconds and 0 means unbounded.
+// No Assets exports the transfers of every asset.
+type TransactionsExportReq struct {
+	Addresses  []string `validate:"required,min=1"`
+	SrcChainId int
+	DstChainId int
+	Assets     []string
+	StartTime  uint64
+	EndTime    uint64
+	Format     string `validate:"enum=csv|ndjson"`
+}
+
+// TransactionExportRow is a transaction of the export, the amounts are
+// normalized by the precision of their tokens and valued in usd at the price
+// of the time of the transaction, or the current one when no price was kept
+// for it. The usd values are empty when no price is available.
+type TransactionExportRow struct {
+	Time       uint64
+	SrcChainId uint64
+	DstChainId uint64
+	From       string
+	To         string
+	Token      string
+	TokenHash  string
+	Amount     string
+	AmountUsd  string
+	FeeToken   string
+	FeeAmount  string
+	FeeUsd     string
+	State      uint64
+	SrcHash    string
+	PolyHash   string
+	DstHash    string
+}
+
+func MakeTransactionExportRow(transaction *SrcPolyDstRelation, prices TokenPriceSamples) *TransactionExportRow {
+	if transaction.WrapperTransaction == nil || transaction.SrcTransaction == nil {
+		return nil
+	}
+	row := &TransactionExportRow{
+		Time:       transaction.WrapperTransaction.Time,
+		SrcChainId: transaction.WrapperTransaction.SrcChainId,
+		DstChainId: transaction.WrapperTransaction.DstChainId,
+		From:       transaction.WrapperTransaction.User,
+		To:         transaction.WrapperTransaction.DstUser,
+		TokenHash:  transaction.TokenHash,
+		State:      transaction.WrapperTransaction.Status,
+		SrcHash:    transaction.SrcHash,
+		PolyHash:   transaction.PolyHash,
+		DstHash:    transaction.DstHash,
+	}
+	if transfer := transaction.SrcTransaction.SrcTransfer; transfer != nil {
+		row.To = transfer.DstUser
+		row.Amount, row.AmountUsd = exportAmount(transfer.Amount, transaction.Token, prices, row.Time)
+	}
+	if transaction.Token != nil {
+		row.Token = transaction.Token.Name
+	}
+	if transaction.FeeToken != nil {
+		row.FeeToken = transaction.FeeToken.Name
+	}
+	row.FeeAmount, row.FeeUsd = exportAmount(transaction.WrapperTransaction.FeeAmount, transaction.FeeToken, prices, row.Time)
+	return row
+}
+
+// exportAmount returns the amount in units of the token and its usd value at
+// the time.
+func exportAmount(amount *BigInt, token *Token, prices TokenPriceSamples, time uint64) (string, string) {
+	if amount == nil || token == nil {
+		return "", ""
+	}
+	value := decimal.NewFromBigInt(&amount.Int, -int32(token.Precision))
+	tokenPrice, ok := prices.PriceAt(token.TokenBasicName, int64(time))
+	if !ok {
+		if token.TokenBasic == nil || token.TokenBasic.Ind == 0 {
+			return value.String(), ""
+		}
+		tokenPrice = token.TokenBasic.Price
+	}
+	price := decimal.New(tokenPrice, 0).Div(decimal.NewFromInt(basedef.PRICE_PRECISION))
+	return value.String(), value.Mul(price).Round(6).String()
+}
+
 type TransactionsOfStateReq struct {
-	State    uint64
-	PageSize int
-	PageNo   int
+	State     uint64
+	PageSize  int `validate:"min=1"`
+	PageNo    int
+	UseCursor bool
+	Cursor    string
+	WithCount bool
 }
 
 type TransactionsOfUnfinishedReq struct {
-	PageSize int
+	PageSize int `validate:"min=1"`
 	PageNo   int
 }
 
 type TransactionsOfAssetReq struct {
-	Asset    string
-	Chain    int
-	PageSize int
-	PageNo   int
+	Asset     string
+	Chain     int
+	PageSize  int `validate:"min=1"`
+	PageNo    int
+	UseCursor bool
+	Cursor    string
+	WithCount bool
 }
 
 type CrossChainTransactionRsp struct {
@@ -872,6 +1101,7 @@ type TransactionOfUnfinishedRsp struct {
 	PageNo       int
 	TotalPage    int
 	TotalCount   int
+	NextCursor   string
 	Transactions []*CrossChainTransactionRsp
 }
 
@@ -1044,8 +1274,8 @@ func MakeTransactionsOfStateRsp(pageSize int, pageNo int, totalPage int, totalCo
 }
 
 type AddressReq struct {
-	ChainId     uint64
-	AddressHash string
+	ChainId     uint64 `validate:"required"`
+	AddressHash string `validate:"required"`
 }
 
 type AddressRsp struct {
@@ -1097,7 +1327,7 @@ func MakePolyTransactionRsp(transaction *PolyTransaction) *PolyTransactionRsp {
 }
 
 type PolyTransactionsReq struct {
-	PageSize int
+	PageSize int `validate:"min=1"`
 	PageNo   int
 }
 
@@ -1123,8 +1353,8 @@ func MakePolyTransactionsRsp(pageSize int, pageNo int, totalPage int, totalCount
 }
 
 type ExpectTimeReq struct {
-	SrcChainId uint64
-	DstChainId uint64
+	SrcChainId uint64 `validate:"required"`
+	DstChainId uint64 `validate:"required"`
 }
 
 type ExpectTimeRsp struct {
@@ -1143,7 +1373,7 @@ func MakeExpectTimeRsp(srcchainId uint64, dstchainid uint64, time uint64) *Expec
 }
 
 type TokenAssetReq struct {
-	NameOrHash string
+	NameOrHash string `validate:"required"`
 }
 
 type AssetDetailRes struct {
@@ -1160,9 +1390,10 @@ type DstChainAssetRes struct {
 }
 
 type CheckFeeResult struct {
-	Pass bool
-	Paid float64
-	Min  float64
+	Pass    bool
+	Paid    float64
+	Min     float64
+	QuoteId string
 }
 
 type BotTx struct {
@@ -1254,7 +1485,7 @@ func MakeBottxsRsp(pageSize int, pageNo int, totalPage int, totalCount int, tran
 }
 
 type ManualTxDataReq struct {
-	PolyHash string
+	PolyHash string `validate:"required"`
 }
 
 type ManualTxDataResp struct {
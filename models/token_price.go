@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package models
+
+import (
+	"math/big"
+	"sort"
+)
+
+// TokenPriceAt returns the price at t of the samples sorted by time. Between
+// two samples the price is interpolated linearly, before the first or after the
+// last one the nearest sample is used. ok is false when there is no sample.
+func TokenPriceAt(samples []*TokenPriceHistory, t int64) (price int64, ok bool) {
+	if len(samples) == 0 {
+		return 0, false
+	}
+	i := sort.Search(len(samples), func(i int) bool { return samples[i].Time >= t })
+	if i == len(samples) {
+		return samples[i-1].Price, true
+	}
+	if i == 0 || samples[i].Time == t {
+		return samples[i].Price, true
+	}
+	prev, next := samples[i-1], samples[i]
+	// prices times seconds may overflow int64
+	delta := new(big.Int).Mul(big.NewInt(next.Price-prev.Price), big.NewInt(t-prev.Time))
+	delta.Quo(delta, big.NewInt(next.Time-prev.Time))
+	return prev.Price + delta.Int64(), true
+}
+
+// TokenPriceSamples are the price samples of token basics by name, each sorted
+// by time.
+type TokenPriceSamples map[string][]*TokenPriceHistory
+
+// PriceAt returns the price of the token basic at t, see TokenPriceAt.
+func (s TokenPriceSamples) PriceAt(name string, t int64) (int64, bool) {
+	return TokenPriceAt(s[name], t)
+}
@@ -0,0 +1,41 @@
+package models
+
+import "testing"
+
+func TestTokenPriceAt(t *testing.T) {
+	if _, ok := TokenPriceAt(nil, 100); ok {
+		t.Errorf("price of no sample is found")
+	}
+	samples := []*TokenPriceHistory{
+		{Price: 100, Time: 1000},
+		{Price: 200, Time: 1100},
+		{Price: 150, Time: 1300},
+		{Price: 4e15 + 150, Time: 1e9 + 1300},
+	}
+	cases := []struct {
+		time  int64
+		price int64
+	}{
+		{0, 100},
+		{1000, 100},
+		{1050, 150},
+		{1100, 200},
+		{1200, 175},
+		{1300, 150},
+		{1e9 + 1300, 4e15 + 150},
+		{2e9, 4e15 + 150},
+		{5e8 + 1300, 2e15 + 150},
+	}
+	for _, c := range cases {
+		if price, ok := TokenPriceAt(samples, c.time); !ok || price != c.price {
+			t.Errorf("price at %d = %d, want %d", c.time, price, c.price)
+		}
+	}
+	prices := TokenPriceSamples{"ETH": samples}
+	if price, ok := prices.PriceAt("ETH", 1050); !ok || price != 150 {
+		t.Errorf("price of ETH at 1050 = %d", price)
+	}
+	if _, ok := prices.PriceAt("BTC", 1050); ok {
+		t.Errorf("price of BTC is found")
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package models
+
+const (
+	WEBHOOK_EVENT_POLY        = "poly"
+	WEBHOOK_EVENT_DESTINATION = "destination"
+	WEBHOOK_EVENT_STUCK       = "stuck"
+)
+
+// WebhookSubscription selects transfers by user, asset and wrapper server id,
+// the fields that are set must all match. Events is a comma separated list of
+// event names, empty for every event.
+type WebhookSubscription struct {
+	Id       int64  `gorm:"primaryKey;autoIncrement"`
+	Url      string `gorm:"type:varchar(512);not null"`
+	Secret   string `gorm:"type:varchar(128);not null"`
+	User     string `gorm:"index;type:varchar(66);not null"`
+	Asset    string `gorm:"index;type:varchar(120);not null"`
+	ServerId uint64 `gorm:"index;type:bigint(20);not null"`
+	Events   string `gorm:"type:varchar(128);not null"`
+	Time     uint64 `gorm:"type:bigint(20);not null"`
+}
+
+// WebhookDelivery is a payload waiting to be posted, it is retried at NextTime
+// until the receiver accepts it or the attempts run out.
+type WebhookDelivery struct {
+	Id             int64  `gorm:"primaryKey;autoIncrement"`
+	SubscriptionId int64  `gorm:"index;type:bigint(20);not null"`
+	Hash           string `gorm:"size:66;not null"`
+	Event          string `gorm:"type:varchar(32);not null"`
+	Payload        string `gorm:"type:text;not null"`
+	Attempts       int    `gorm:"type:int;not null"`
+	NextTime       uint64 `gorm:"index;type:bigint(20);not null"`
+	Error          string `gorm:"type:varchar(512);not null"`
+	Time           uint64 `gorm:"type:bigint(20);not null"`
+}
+
+// WebhookDeadLetter is a delivery that failed every attempt, it stays here
+// until it is replayed.
+type WebhookDeadLetter struct {
+	Id             int64  `gorm:"primaryKey;autoIncrement"`
+	SubscriptionId int64  `gorm:"index;type:bigint(20);not null"`
+	Hash           string `gorm:"size:66;not null"`
+	Event          string `gorm:"type:varchar(32);not null"`
+	Payload        string `gorm:"type:text;not null"`
+	Attempts       int    `gorm:"type:int;not null"`
+	Error          string `gorm:"type:varchar(512);not null"`
+	Time           uint64 `gorm:"type:bigint(20);not null"`
+}
+
+type WebhookPayload struct {
+	Event       string
+	Time        uint64
+	Transaction *TransactionRsp
+}
+
+type WebhookSubscribeReq struct {
+	Url      string `validate:"required"`
+	Secret   string
+	User     string
+	Asset    string
+	ServerId uint64
+	Events   []string
+}
+
+type WebhookSubscriptionRsp struct {
+	Id       int64
+	Url      string
+	Secret   string
+	User     string
+	Asset    string
+	ServerId uint64
+	Events   string
+}
+
+func MakeWebhookSubscriptionRsp(subscription *WebhookSubscription) *WebhookSubscriptionRsp {
+	return &WebhookSubscriptionRsp{
+		Id:       subscription.Id,
+		Url:      subscription.Url,
+		Secret:   subscription.Secret,
+		User:     subscription.User,
+		Asset:    subscription.Asset,
+		ServerId: subscription.ServerId,
+		Events:   subscription.Events,
+	}
+}
+
+type WebhookUnsubscribeReq struct {
+	Id     int64  `validate:"required"`
+	Secret string `validate:"required"`
+}
+
+// WebhookReplayReq replays the dead letters of a subscription, all of them
+// when Ids is empty.
+type WebhookReplayReq struct {
+	Id     int64  `validate:"required"`
+	Secret string `validate:"required"`
+	Ids    []int64
+}
+
+type WebhookReplayRsp struct {
+	Replayed int
+}
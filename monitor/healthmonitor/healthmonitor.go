@@ -8,13 +8,6 @@ import (
 	"poly-bridge/cacheRedis"
 	"poly-bridge/common"
 	"poly-bridge/conf"
-	"poly-bridge/monitor/healthmonitor/ethereummonitor"
-	"poly-bridge/monitor/healthmonitor/neo3monitor"
-	"poly-bridge/monitor/healthmonitor/neomonitor"
-	"poly-bridge/monitor/healthmonitor/ontologymonitor"
-	"poly-bridge/monitor/healthmonitor/polymonitor"
-	"poly-bridge/monitor/healthmonitor/switcheomonitor"
-	"poly-bridge/monitor/healthmonitor/zilliqamonitor"
 	"runtime/debug"
 	"time"
 )
@@ -28,6 +21,7 @@ func StartHealthMonitor(config *conf.Config, relayerConfig *conf.RelayerConfig)
 	}
 	for _, cfg := range config.ChainListenConfig {
 		healthMonitorConfigMap[cfg.ChainId].CCMContract = cfg.CCMContract
+		healthMonitorConfigMap[cfg.ChainId].Family = cfg.Family
 	}
 	for _, cfg := range relayerConfig.RelayAccountConfig {
 		healthMonitorConfigMap[cfg.ChainId].RelayerAccount = cfg
@@ -297,25 +291,9 @@ func sendRelayerAccountStatusDingAlarm(relayerStatus *basedef.RelayerAccountStat
 }
 
 func NewHealthMonitorHandle(monitorConfig *conf.HealthMonitorConfig) MonitorHandle {
-	switch monitorConfig.ChainId {
-	case basedef.POLY_CROSSCHAIN_ID:
-		return polymonitor.NewPolyHealthMonitor(monitorConfig)
-	case basedef.ETHEREUM_CROSSCHAIN_ID, basedef.O3_CROSSCHAIN_ID, basedef.BSC_CROSSCHAIN_ID, basedef.PLT_CROSSCHAIN_ID,
-		basedef.OK_CROSSCHAIN_ID, basedef.HECO_CROSSCHAIN_ID, basedef.MATIC_CROSSCHAIN_ID, basedef.ARBITRUM_CROSSCHAIN_ID,
-		basedef.XDAI_CROSSCHAIN_ID, basedef.FANTOM_CROSSCHAIN_ID, basedef.AVAX_CROSSCHAIN_ID, basedef.OPTIMISTIC_CROSSCHAIN_ID,
-		basedef.METIS_CROSSCHAIN_ID, basedef.RINKEBY_CROSSCHAIN_ID, basedef.BOBA_CROSSCHAIN_ID, basedef.OASIS_CROSSCHAIN_ID:
-		return ethereummonitor.NewEthereumHealthMonitor(monitorConfig)
-	case basedef.NEO_CROSSCHAIN_ID:
-		return neomonitor.NewNeoHealthMonitor(monitorConfig)
-	case basedef.ONT_CROSSCHAIN_ID:
-		return ontologymonitor.NewOntologyHealthMonitor(monitorConfig)
-	case basedef.SWITCHEO_CROSSCHAIN_ID:
-		return switcheomonitor.NewSwitcheoHealthMonitor(monitorConfig)
-	case basedef.NEO3_CROSSCHAIN_ID:
-		return neo3monitor.NewNeo3HealthMonitor(monitorConfig)
-	case basedef.ZILLIQA_CROSSCHAIN_ID:
-		return zilliqamonitor.NewZilliqaHealthMonitor(monitorConfig)
-	default:
+	factory, ok := monitorHandleFactories[monitorConfig.GetFamily()]
+	if !ok {
 		return nil
 	}
+	return factory(monitorConfig)
 }
@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package healthmonitor
+
+import (
+	"poly-bridge/basedef"
+	"poly-bridge/conf"
+	"poly-bridge/monitor/healthmonitor/ethereummonitor"
+	"poly-bridge/monitor/healthmonitor/neo3monitor"
+	"poly-bridge/monitor/healthmonitor/neomonitor"
+	"poly-bridge/monitor/healthmonitor/ontologymonitor"
+	"poly-bridge/monitor/healthmonitor/polymonitor"
+	"poly-bridge/monitor/healthmonitor/switcheomonitor"
+	"poly-bridge/monitor/healthmonitor/zilliqamonitor"
+)
+
+type MonitorHandleFactory func(monitorConfig *conf.HealthMonitorConfig) MonitorHandle
+
+var monitorHandleFactories = make(map[string]MonitorHandleFactory)
+
+// RegisterMonitorHandle sets the factory of the health monitors of a chain family.
+func RegisterMonitorHandle(family string, factory MonitorHandleFactory) {
+	monitorHandleFactories[family] = factory
+}
+
+func init() {
+	RegisterMonitorHandle(basedef.FAMILY_POLY, func(cfg *conf.HealthMonitorConfig) MonitorHandle {
+		return polymonitor.NewPolyHealthMonitor(cfg)
+	})
+	RegisterMonitorHandle(basedef.FAMILY_EVM, func(cfg *conf.HealthMonitorConfig) MonitorHandle {
+		return ethereummonitor.NewEthereumHealthMonitor(cfg)
+	})
+	// o3 is listened differently but its nodes are ethereum compatible
+	RegisterMonitorHandle(basedef.FAMILY_O3, func(cfg *conf.HealthMonitorConfig) MonitorHandle {
+		return ethereummonitor.NewEthereumHealthMonitor(cfg)
+	})
+	RegisterMonitorHandle(basedef.FAMILY_NEO, func(cfg *conf.HealthMonitorConfig) MonitorHandle {
+		return neomonitor.NewNeoHealthMonitor(cfg)
+	})
+	RegisterMonitorHandle(basedef.FAMILY_NEO3, func(cfg *conf.HealthMonitorConfig) MonitorHandle {
+		return neo3monitor.NewNeo3HealthMonitor(cfg)
+	})
+	RegisterMonitorHandle(basedef.FAMILY_ONTOLOGY, func(cfg *conf.HealthMonitorConfig) MonitorHandle {
+		return ontologymonitor.NewOntologyHealthMonitor(cfg)
+	})
+	RegisterMonitorHandle(basedef.FAMILY_SWITCHEO, func(cfg *conf.HealthMonitorConfig) MonitorHandle {
+		return switcheomonitor.NewSwitcheoHealthMonitor(cfg)
+	})
+	RegisterMonitorHandle(basedef.FAMILY_ZILLIQA, func(cfg *conf.HealthMonitorConfig) MonitorHandle {
+		return zilliqamonitor.NewZilliqaHealthMonitor(cfg)
+	})
+}
@@ -80,7 +80,7 @@ func (s *AssetRsp) instance(t *models.Token) *AssetRsp {
 
 type HomeReq struct {
 	ChainId  uint64
-	PageSize int
+	PageSize int `validate:"min=1"`
 	PageNo   int
 }
 
@@ -135,8 +135,8 @@ func (s *AssetsRsp) instance(assets []*models.Token) *AssetsRsp {
 }
 
 type AssetReq struct {
-	ChainId uint64
-	Hash    string
+	ChainId uint64 `validate:"required"`
+	Hash    string `validate:"required"`
 }
 
 //
@@ -172,9 +172,9 @@ type AssetReq struct {
 //}
 
 type ItemsOfAddressReq struct {
-	ChainId  uint64
-	Asset    string
-	Address  string
+	ChainId  uint64 `validate:"required"`
+	Asset    string `validate:"required"`
+	Address  string `validate:"required"`
 	TokenId  string
 	PageSize int
 	PageNo   int
@@ -237,14 +237,14 @@ func (s *WrapperTransactionRsp) instance(transaction *models.WrapperTransaction)
 }
 
 type TransactionBriefsReq struct {
-	PageSize int
+	PageSize int `validate:"required,min=1,max=10"`
 	PageNo   int
 }
 
 type TransactionBriefsOfAddressReq struct {
-	PageSize  int
+	PageSize  int `validate:"required,min=1,max=10"`
 	PageNo    int
-	Addresses []string
+	Addresses []string `validate:"required"`
 }
 
 type TransactionBriefRelation struct {
@@ -334,7 +334,7 @@ type PolyChainRsp struct {
 }
 
 type TransactionDetailReq struct {
-	Hash string
+	Hash string `validate:"required"`
 }
 
 type TransactionDetailRsp struct {
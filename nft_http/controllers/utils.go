@@ -24,6 +24,7 @@ import (
 	"math/big"
 	"poly-bridge/chainsdk"
 	"poly-bridge/conf"
+	"poly-bridge/database"
 	"poly-bridge/models"
 	"poly-bridge/nft_http/meta"
 	"regexp"
@@ -34,7 +35,6 @@ import (
 	"github.com/beego/beego/v2/server/web"
 	"github.com/ethereum/go-ethereum/common"
 	lru "github.com/hashicorp/golang-lru"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -54,16 +54,11 @@ var (
 )
 
 func NewDB(cfg *conf.DBConfig) *gorm.DB {
-	user := cfg.User
-	password := cfg.Password
-	url := cfg.URL
-	scheme := cfg.Scheme
 	Logger := logger.Default
 	if cfg.Debug {
 		Logger = Logger.LogMode(logger.Info)
 	}
-	format := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8", user, password, url, scheme)
-	db, err := gorm.Open(mysql.Open(format), &gorm.Config{Logger: Logger})
+	db, err := database.Open(cfg, &gorm.Config{Logger: Logger})
 	if err != nil {
 		panic(err)
 	}
@@ -248,14 +243,14 @@ func input(c *web.Controller, req interface{}) bool {
 }
 
 func customInput(c *web.Controller, code int, msg string) {
-	c.Data["json"] = models.MakeErrorRsp(msg)
+	c.Data["json"] = models.MakeFieldErrorRsp(code, msg, "")
 	c.Ctx.ResponseWriter.WriteHeader(code)
 	c.ServeJSON()
 }
 
 func notExist(c *web.Controller) {
 	code := ErrCodeNotExist
-	c.Data["json"] = models.MakeErrorRsp(errMap[code])
+	c.Data["json"] = models.MakeFieldErrorRsp(code, errMap[code], "")
 	c.Ctx.ResponseWriter.WriteHeader(code)
 	c.ServeJSON()
 }
@@ -265,7 +260,7 @@ func checkPageSize(c *web.Controller, size int) bool {
 		return true
 	}
 	code := ErrCodeRequest
-	c.Data["json"] = models.MakeErrorRsp("page size too big, should be smaller than 10")
+	c.Data["json"] = models.MakeFieldErrorRsp(code, "page size too big, should be smaller than 10", "PageSize")
 	c.Ctx.ResponseWriter.WriteHeader(code)
 	c.ServeJSON()
 	return false
@@ -293,7 +288,7 @@ func checkNumString(numStr string) (*big.Int, error) {
 
 func nodeInvalid(c *web.Controller) {
 	code := ErrCodeNodeInvalid
-	c.Data["json"] = models.MakeErrorRsp(errMap[code])
+	c.Data["json"] = models.MakeFieldErrorRsp(code, errMap[code], "")
 	c.Ctx.ResponseWriter.WriteHeader(code)
 	c.ServeJSON()
 }
@@ -304,7 +299,7 @@ func output(c *web.Controller, data interface{}) {
 }
 
 func customOutput(c *web.Controller, code int, msg string) {
-	c.Data["json"] = models.MakeErrorRsp(msg)
+	c.Data["json"] = models.MakeFieldErrorRsp(code, msg, "")
 	c.Ctx.ResponseWriter.WriteHeader(code)
 	c.ServeJSON()
 }
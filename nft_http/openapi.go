@@ -0,0 +1,52 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package nft_http
+
+import (
+	"poly-bridge/models"
+	"poly-bridge/nft_http/controllers"
+	"poly-bridge/openapi"
+)
+
+// the routes of Init, keep them in the same order
+func init() {
+	openapi.Register("nft", "/nft",
+		&openapi.Route{Method: "get", Path: "/", Name: "Info", Summary: "version of the api and the wrapper contracts",
+			Response: controllers.PolyBridgeInfoResp{}},
+		&openapi.Route{Method: "post", Path: "/assetshow/", Name: "Home", Summary: "items shown on the home page",
+			Request: controllers.HomeReq{}, Response: controllers.HomeRsp{}},
+		&openapi.Route{Method: "post", Path: "/asset/", Name: "Asset", Summary: "nft asset of a chain",
+			Request: controllers.AssetReq{}, Response: controllers.AssetMap{}},
+		&openapi.Route{Method: "post", Path: "/assets/", Name: "Assets", Summary: "nft assets of a chain",
+			Request: controllers.AssetsReq{}, Response: controllers.AssetsRsp{}},
+		&openapi.Route{Method: "post", Path: "/items/", Name: "Items", Summary: "items of an address",
+			Request: controllers.ItemsOfAddressReq{}, Response: controllers.ItemsOfAddressRsp{}},
+		&openapi.Route{Method: "post", Path: "/getfee/", Name: "GetFee", Summary: "fee of a transfer",
+			Request: models.GetFeeReq{}, Response: models.GetFeeRsp{}},
+		&openapi.Route{Method: "post", Path: "/exp_transactions/", Name: "ExpTransactions", Summary: "nft transactions by page",
+			Request: controllers.TransactionBriefsReq{}, Response: controllers.TransactionBriefsRsp{}},
+		&openapi.Route{Method: "post", Path: "/exp_transactionsofaddress/", Name: "ExpTransactionsOfAddress", Summary: "nft transactions of addresses by page",
+			Request: controllers.TransactionBriefsOfAddressReq{}, Response: controllers.TransactionBriefsRsp{}},
+		&openapi.Route{Method: "post", Path: "/exp_transactionofhash/", Name: "ExpTransactionOfHash", Summary: "nft transaction of a hash",
+			Request: controllers.TransactionDetailReq{}, Response: controllers.TransactionDetailRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionsofaddress/", Name: "TransactionsOfAddress", Summary: "nft transactions of addresses",
+			Request: models.TransactionsOfAddressReq{}, Response: controllers.TransactionsOfAddressRsp{}},
+		&openapi.Route{Method: "post", Path: "/transactionofhash/", Name: "TransactionOfHash", Summary: "nft transaction of a source hash",
+			Request: models.TransactionOfHashReq{}, Response: controllers.TransactionRsp{}},
+	)
+}
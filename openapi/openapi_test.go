@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/url"
+	"poly-bridge/models"
+	"reflect"
+	"testing"
+)
+
+type testItem struct {
+	Hash    string `validate:"required"`
+	ChainId uint64
+}
+
+type testNode struct {
+	Name     string
+	Children []*testNode
+}
+
+type testEmbedded struct {
+	Cursor string
+}
+
+type testReq struct {
+	testEmbedded
+	PageSize int    `validate:"required,min=1,max=10"`
+	Address  string `json:"address" validate:"min=2"`
+	State    string `validate:"enum=wait|done"`
+	Items    []*testItem
+	Amount   *models.BigInt
+	Node     *testNode
+	Ignored  string `json:"-"`
+	hidden   string
+}
+
+func TestSchema(t *testing.T) {
+	b := newSchemaBuilder()
+	ref := b.schema(reflect.TypeOf(testReq{}))
+	if ref.Ref != componentsPrefix+"openapi.testReq" {
+		t.Fatalf("ref = %s", ref.Ref)
+	}
+	schema := b.components["openapi.testReq"]
+	for _, name := range []string{"Cursor", "PageSize", "address", "State", "Items", "Amount", "Node"} {
+		if _, ok := schema.Properties[name]; !ok {
+			t.Errorf("property %s is missing", name)
+		}
+	}
+	for _, name := range []string{"Ignored", "hidden", "Address"} {
+		if _, ok := schema.Properties[name]; ok {
+			t.Errorf("property %s should not be present", name)
+		}
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "PageSize" {
+		t.Errorf("required = %v", schema.Required)
+	}
+	pageSize := schema.Properties["PageSize"]
+	if pageSize.Type != "integer" || *pageSize.Minimum != 1 || *pageSize.Maximum != 10 {
+		t.Errorf("PageSize = %+v", pageSize)
+	}
+	if *schema.Properties["address"].MinLength != 2 {
+		t.Errorf("address min length is not set")
+	}
+	if schema.Properties["Amount"].Type != "integer" {
+		t.Errorf("Amount = %+v", schema.Properties["Amount"])
+	}
+	if schema.Properties["Items"].Items.Ref != componentsPrefix+"openapi.testItem" {
+		t.Errorf("Items = %+v", schema.Properties["Items"].Items)
+	}
+	node := b.components["openapi.testNode"]
+	if node == nil || node.Properties["Children"].Items.Ref != componentsPrefix+"openapi.testNode" {
+		t.Errorf("testNode = %+v", node)
+	}
+	if _, err := json.Marshal(b.components); err != nil {
+		t.Errorf("marshal components err: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	r := newRegistry()
+	r.register("test", "/test",
+		&Route{Method: "post", Path: "/list/", Name: "List", Request: testReq{}},
+		&Route{Method: "get", Path: "/get", Name: "Get", Query: []*Parameter{
+			QueryParam("chain", "integer", true, ""),
+			QueryParam("user", "string", false, ""),
+		}},
+	)
+	if _, ok := r.spec.Paths["/test/list/"]["post"]; !ok {
+		t.Fatalf("operation is not registered")
+	}
+
+	cases := []struct {
+		method  string
+		path    string
+		body    string
+		query   string
+		invalid bool
+		field   string
+	}{
+		{"post", "/test/list/", `{"PageSize":10,"address":"0x12","Items":[{"Hash":"ab"}],"Amount":123456789012345678901234567890}`, "", false, ""},
+		{"POST", "/test/list", `{"pagesize":1}`, "", false, ""},
+		{"post", "/test/list/", ``, "", false, ""},
+		{"post", "/test/list/", `{"PageSize":1,"State":null,"Node":null}`, "", false, ""},
+		{"post", "/test/list/", `{}`, "", true, "PageSize"},
+		{"post", "/test/list/", `{"PageSize":null}`, "", true, "PageSize"},
+		{"post", "/test/list/", `{"PageSize":"10"}`, "", true, "PageSize"},
+		{"post", "/test/list/", `{"PageSize":1.5}`, "", true, "PageSize"},
+		{"post", "/test/list/", `{"PageSize":0}`, "", true, "PageSize"},
+		{"post", "/test/list/", `{"PageSize":11}`, "", true, "PageSize"},
+		{"post", "/test/list/", `{"PageSize":1,"address":"0"}`, "", true, "address"},
+		{"post", "/test/list/", `{"PageSize":1,"State":"skip"}`, "", true, "State"},
+		{"post", "/test/list/", `{"PageSize":1,"Items":[{"Hash":"ab"},{"ChainId":2}]}`, "", true, "Items[1].Hash"},
+		{"post", "/test/list/", `{"PageSize":1,"Items":[{"Hash":"ab","ChainId":-2}]}`, "", true, "Items[0].ChainId"},
+		{"post", "/test/list/", `{"PageSize":1,"Amount":1.5}`, "", true, "Amount"},
+		{"post", "/test/list/", `{"PageSize":1,"Node":{"Children":[{"Name":1}]}}`, "", true, "Node.Children[0].Name"},
+		{"post", "/test/list/", `[]`, "", true, ""},
+		{"post", "/test/list/", `{"PageSize":`, "", true, ""},
+		{"GET", "/test/get", ``, "chain=2", false, ""},
+		{"get", "/test/get/", ``, "user=a", true, "chain"},
+		{"get", "/test/get/", ``, "chain=a", true, "chain"},
+		{"post", "/test/unknown/", `{`, "", false, ""},
+	}
+	for i, c := range cases {
+		query, _ := url.ParseQuery(c.query)
+		err := r.validate(c.method, c.path, []byte(c.body), query)
+		if (err != nil) != c.invalid {
+			t.Errorf("case %d: err = %v, expected invalid %v", i, err, c.invalid)
+			continue
+		}
+		if err != nil && err.Field != c.field {
+			t.Errorf("case %d: err field = %s, expected %s", i, err.Field, c.field)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package openapi describes the http apis as an OpenAPI 3 document, which is
+// served at runtime and used to validate the requests before the controllers.
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	ContentJSON        = "application/json"
+	ContentHTML        = "text/html"
+	ContentEventStream = "text/event-stream"
+	ContentCSV         = "text/csv"
+)
+
+type Spec struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       *Info               `json:"info"`
+	Servers    []*Server           `json:"servers,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components *Components         `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem maps the lower case http methods of a path to their operations.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	OperationId string               `json:"operationId"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Route describes an api of a router. Request and Response are values of the
+// types the controller reads and writes, nil when there is no body.
+type Route struct {
+	Method   string
+	Path     string
+	Name     string
+	Summary  string
+	Query    []*Parameter
+	Request  interface{}
+	Response interface{}
+	// Produces is the content type of the response, json by default.
+	Produces string
+}
+
+// QueryParam describes a query string parameter, typ is a schema type like
+// "string" or "integer".
+func QueryParam(name, typ string, required bool, description string) *Parameter {
+	return &Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Required:    required,
+		Schema:      &Schema{Type: typ},
+	}
+}
+
+type operation struct {
+	query   []*Parameter
+	request *Schema
+}
+
+type registry struct {
+	mutex      sync.RWMutex
+	spec       *Spec
+	schemas    *schemaBuilder
+	operations map[string]*operation
+}
+
+var defaultRegistry = newRegistry()
+
+func newRegistry() *registry {
+	schemas := newSchemaBuilder()
+	return &registry{
+		spec: &Spec{
+			OpenAPI:    "3.0.3",
+			Info:       &Info{Title: "poly bridge", Version: "v1"},
+			Servers:    []*Server{{URL: "/v1"}},
+			Paths:      make(map[string]PathItem),
+			Components: &Components{Schemas: schemas.components},
+		},
+		schemas:    schemas,
+		operations: make(map[string]*operation),
+	}
+}
+
+// Register adds the routes of the namespace prefix to the document, the
+// operations are tagged with tag.
+func Register(tag string, prefix string, routes ...*Route) {
+	defaultRegistry.register(tag, prefix, routes...)
+}
+
+// Document returns the document of the registered routes.
+func Document() *Spec {
+	return defaultRegistry.spec
+}
+
+func (r *registry) register(tag string, prefix string, routes ...*Route) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	errorRsp := r.schemas.schema(errorRspType)
+	for _, route := range routes {
+		method := strings.ToLower(route.Method)
+		path := prefix + route.Path
+		op := &Operation{
+			Tags:        []string{tag},
+			Summary:     route.Summary,
+			OperationId: tag + route.Name,
+			Parameters:  route.Query,
+			Responses: map[string]*Response{
+				"400": {Description: "invalid request", Content: map[string]*MediaType{ContentJSON: {Schema: errorRsp}}},
+			},
+		}
+		var request *Schema
+		if route.Request != nil {
+			request = r.schemas.schema(reflect.TypeOf(route.Request))
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{ContentJSON: {Schema: request}},
+			}
+		}
+		produces := route.Produces
+		if produces == "" {
+			produces = ContentJSON
+		}
+		var response *Schema
+		if route.Response != nil {
+			response = r.schemas.schema(reflect.TypeOf(route.Response))
+		}
+		op.Responses["200"] = &Response{Description: "success", Content: map[string]*MediaType{produces: {Schema: response}}}
+
+		item, ok := r.spec.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			r.spec.Paths[path] = item
+		}
+		item[method] = op
+		r.operations[operationKey(method, path)] = &operation{query: route.Query, request: request}
+	}
+}
+
+func (r *registry) operation(method, path string) *operation {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.operations[operationKey(strings.ToLower(method), path)]
+}
+
+// operationKey ignores the trailing slash, beego routes the path either way.
+func operationKey(method, path string) string {
+	return method + " " + strings.TrimSuffix(path, "/")
+}
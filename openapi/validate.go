@@ -0,0 +1,272 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/server/web"
+	"github.com/beego/beego/v2/server/web/context"
+)
+
+// FieldError is a request not matching the schema of its route. Field is
+// the path of the invalid value, like "Checks[0].Hash", empty for the body.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+func fieldError(field string, format string, args ...interface{}) *FieldError {
+	name := field
+	if name == "" {
+		name = "request"
+	}
+	return &FieldError{Field: field, Message: fmt.Sprintf("%s %s!", name, fmt.Sprintf(format, args...))}
+}
+
+// Validate checks the query and the json body of a request against the
+// schema of the route registered for method and path. The requests of the
+// routes that are not registered are not checked.
+func Validate(method, path string, body []byte, query url.Values) *FieldError {
+	return defaultRegistry.validate(method, path, body, query)
+}
+
+func (r *registry) validate(method, path string, body []byte, query url.Values) *FieldError {
+	op := r.operation(method, path)
+	if op == nil {
+		return nil
+	}
+	for _, param := range op.query {
+		value := query.Get(param.Name)
+		if value == "" {
+			if param.Required {
+				return fieldError(param.Name, "is required")
+			}
+			continue
+		}
+		if param.Schema.Type == "integer" {
+			if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+				return fieldError(param.Name, "should be an integer")
+			}
+		}
+	}
+	if op.request == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return fieldError("", "is not valid json")
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.check(op.request, value, "")
+}
+
+func (r *registry) resolve(schema *Schema) *Schema {
+	for schema.Ref != "" {
+		schema = r.schemas.components[strings.TrimPrefix(schema.Ref, componentsPrefix)]
+	}
+	return schema
+}
+
+// property finds the schema of a json key the way encoding/json does, which
+// prefers the exact name and falls back to a case insensitive match.
+func property(schema *Schema, key string) (string, *Schema) {
+	if p, ok := schema.Properties[key]; ok {
+		return key, p
+	}
+	for name, p := range schema.Properties {
+		if strings.EqualFold(name, key) {
+			return name, p
+		}
+	}
+	return "", nil
+}
+
+func join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// check walks the json value along the schema. null is accepted anywhere
+// but for required fields, as the controllers decode it to the zero value.
+func (r *registry) check(schema *Schema, value interface{}, field string) *FieldError {
+	schema = r.resolve(schema)
+	if value == nil {
+		return nil
+	}
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fieldError(field, "should be an object")
+		}
+		for _, name := range schema.Required {
+			found := false
+			for key, v := range object {
+				if strings.EqualFold(key, name) && v != nil {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fieldError(join(field, name), "is required")
+			}
+		}
+		for key, v := range object {
+			if schema.AdditionalProperties != nil {
+				if err := r.check(schema.AdditionalProperties, v, join(field, key)); err != nil {
+					return err
+				}
+				continue
+			}
+			name, p := property(schema, key)
+			if p == nil {
+				continue
+			}
+			if err := r.check(p, v, join(field, name)); err != nil {
+				return err
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fieldError(field, "should be an array")
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			return fieldError(field, "should have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			return fieldError(field, "should have at most %d items", *schema.MaxItems)
+		}
+		for i, item := range items {
+			if err := r.check(schema.Items, item, fmt.Sprintf("%s[%d]", field, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fieldError(field, "should be a string")
+		}
+		length := utf8.RuneCountInString(s)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			return fieldError(field, "should have at least %d characters", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			return fieldError(field, "should have at most %d characters", *schema.MaxLength)
+		}
+		if len(schema.Enum) > 0 {
+			for _, e := range schema.Enum {
+				if s == e {
+					return nil
+				}
+			}
+			return fieldError(field, "should be one of %s", strings.Join(schema.Enum, ", "))
+		}
+	case "integer", "number":
+		n, ok := value.(json.Number)
+		if schema.Type == "integer" && (!ok || !isInteger(n.String(), schema.Format)) {
+			return fieldError(field, "should be an integer")
+		}
+		if !ok {
+			return fieldError(field, "should be a number")
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return fieldError(field, "should be a number")
+		}
+		if schema.Minimum != nil && f < *schema.Minimum {
+			return fieldError(field, "should not be less than %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && f > *schema.Maximum {
+			return fieldError(field, "should not be greater than %v", *schema.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fieldError(field, "should be a boolean")
+		}
+	}
+	return nil
+}
+
+// isInteger checks the number fits the go type of the format, the integers
+// without a format are big numbers.
+func isInteger(s string, format string) bool {
+	var err error
+	switch format {
+	case "int32":
+		_, err = strconv.ParseInt(s, 10, 32)
+	case "int64":
+		_, err = strconv.ParseInt(s, 10, 64)
+	case "uint32":
+		_, err = strconv.ParseUint(s, 10, 32)
+	case "uint64":
+		_, err = strconv.ParseUint(s, 10, 64)
+	default:
+		digits := strings.TrimPrefix(s, "-")
+		if digits == "" {
+			return false
+		}
+		for _, c := range digits {
+			if c < '0' || c > '9' {
+				return false
+			}
+		}
+	}
+	return err == nil
+}
+
+// Filter rejects the requests not matching the document with 400 before they
+// reach the controllers, prefix is the namespace the routers are added to.
+func Filter(prefix string) web.FilterFunc {
+	return func(ctx *context.Context) {
+		pattern, _ := ctx.Input.GetData("RouterPattern").(string)
+		if pattern == "" {
+			return
+		}
+		err := Validate(ctx.Input.Method(), strings.TrimPrefix(pattern, prefix), ctx.Input.RequestBody, ctx.Request.URL.Query())
+		if err != nil {
+			ctx.Output.SetStatus(400)
+			ctx.Output.JSON(models.MakeFieldErrorRsp(400, err.Message, err.Field), false, false)
+		}
+	}
+}
+
+// ServeDocument writes the document as json.
+func ServeDocument(ctx *context.Context) {
+	defaultRegistry.mutex.RLock()
+	defer defaultRegistry.mutex.RUnlock()
+	ctx.Output.JSON(defaultRegistry.spec, false, false)
+}
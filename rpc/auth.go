@@ -0,0 +1,90 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"path"
+	"strings"
+
+	"poly-bridge/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyHeader is the metadata carrying the api key of a call, the same key
+// as the X-Api-Key header of the http api.
+const apiKeyHeader = "x-api-key"
+
+// methodRoute returns the http route of a grpc method, the scope and usage of
+// the api key are those of the route.
+func methodRoute(fullMethod string) string {
+	return "/bridge/" + strings.ToLower(path.Base(fullMethod)) + "/"
+}
+
+// authorize checks the api key of a call with the scopes and rate limits of
+// the http api.
+func authorize(ctx context.Context, auth *http.ApiAuth, fullMethod string) error {
+	key := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(apiKeyHeader); len(keys) > 0 {
+			key = keys[0]
+		}
+	}
+	ip := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	code, message := auth.Authorize(key, methodRoute(fullMethod), ip)
+	switch code {
+	case 200:
+		return nil
+	case 401:
+		return status.Error(codes.Unauthenticated, message)
+	case 403:
+		return status.Error(codes.PermissionDenied, message)
+	default:
+		return status.Error(codes.ResourceExhausted, message)
+	}
+}
+
+func unaryAuth(auth *http.ApiAuth) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, auth, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(auth *http.ApiAuth) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(stream.Context(), auth, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
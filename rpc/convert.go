@@ -0,0 +1,169 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"poly-bridge/bridgepb"
+	"poly-bridge/models"
+)
+
+func toTokenBasic(tokenBasic *models.TokenBasicRsp) *bridgepb.TokenBasic {
+	if tokenBasic == nil {
+		return nil
+	}
+	return &bridgepb.TokenBasic{
+		Name:      tokenBasic.Name,
+		Precision: tokenBasic.Precision,
+		Price:     tokenBasic.Price,
+		Ind:       tokenBasic.Ind,
+		Time:      tokenBasic.Time,
+		Property:  tokenBasic.Property,
+		Meta:      tokenBasic.Meta,
+	}
+}
+
+func toToken(token *models.TokenRsp) *bridgepb.Token {
+	if token == nil {
+		return nil
+	}
+	rsp := &bridgepb.Token{
+		Hash:            token.Hash,
+		ChainId:         token.ChainId,
+		Name:            token.Name,
+		Property:        token.Property,
+		TokenBasicName:  token.TokenBasicName,
+		Precision:       token.Precision,
+		AvailableAmount: token.AvailableAmount,
+		TokenBasic:      toTokenBasic(token.TokenBasic),
+	}
+	for _, tokenMap := range token.TokenMaps {
+		rsp.TokenMaps = append(rsp.TokenMaps, toTokenMap(tokenMap))
+	}
+	return rsp
+}
+
+func toTokenMap(tokenMap *models.TokenMapRsp) *bridgepb.TokenMap {
+	return &bridgepb.TokenMap{
+		SrcTokenHash: tokenMap.SrcTokenHash,
+		SrcToken:     toToken(tokenMap.SrcToken),
+		DstTokenHash: tokenMap.DstTokenHash,
+		DstToken:     toToken(tokenMap.DstToken),
+		Property:     tokenMap.Property,
+	}
+}
+
+func toGetFeeResponse(getFee *models.GetFeeRsp) *bridgepb.GetFeeResponse {
+	return &bridgepb.GetFeeResponse{
+		SrcChainId:               getFee.SrcChainId,
+		Hash:                     getFee.Hash,
+		DstChainId:               getFee.DstChainId,
+		UsdtAmount:               getFee.UsdtAmount,
+		TokenAmount:              getFee.TokenAmount,
+		TokenAmountWithPrecision: getFee.TokenAmountWithPrecision,
+		SwapTokenHash:            getFee.SwapTokenHash,
+		Balance:                  getFee.Balance,
+		BalanceWithPrecision:     getFee.BalanceWithPrecision,
+		Quote:                    toFeeQuote(getFee.Quote),
+	}
+}
+
+func toFeeQuote(quote *models.FeeQuote) *bridgepb.FeeQuote {
+	if quote == nil {
+		return nil
+	}
+	return &bridgepb.FeeQuote{
+		Id:         quote.Id,
+		SrcChainId: quote.SrcChainId,
+		Hash:       quote.Hash,
+		DstChainId: quote.DstChainId,
+		Amount:     quote.Amount,
+		Time:       quote.Time,
+		Expiry:     quote.Expiry,
+		Signature:  quote.Signature,
+	}
+}
+
+func toFeeCheckResult(checkFee *models.CheckFeeRsp) *bridgepb.FeeCheckResult {
+	return &bridgepb.FeeCheckResult{
+		ChainId:     checkFee.ChainId,
+		Hash:        checkFee.Hash,
+		PayState:    int32(checkFee.PayState),
+		Amount:      checkFee.Amount,
+		MinProxyFee: checkFee.MinProxyFee,
+	}
+}
+
+func toTransaction(transaction *models.TransactionRsp) *bridgepb.Transaction {
+	rsp := &bridgepb.Transaction{
+		Hash:           transaction.Hash,
+		User:           transaction.User,
+		SrcChainId:     transaction.SrcChainId,
+		BlockHeight:    transaction.BlockHeight,
+		Time:           transaction.Time,
+		DstChainId:     transaction.DstChainId,
+		FeeAmount:      transaction.FeeAmount,
+		TransferAmount: transaction.TransferAmount,
+		DstUser:        transaction.DstUser,
+		ServerId:       transaction.ServerId,
+		State:          transaction.State,
+		Token:          toToken(transaction.Token),
+		FeeToken:       toToken(transaction.FeeToken),
+	}
+	for _, state := range transaction.TransactionState {
+		rsp.TransactionState = append(rsp.TransactionState, &bridgepb.TransactionState{
+			Hash:          state.Hash,
+			ChainId:       state.ChainId,
+			Blocks:        state.Blocks,
+			NeedBlocks:    state.NeedBlocks,
+			Time:          state.Time,
+			Confirmations: state.Confirmations,
+		})
+	}
+	return rsp
+}
+
+func toTransactionsOfAddressResponse(transactions *models.TransactionsOfAddressRsp) *bridgepb.TransactionsOfAddressResponse {
+	rsp := &bridgepb.TransactionsOfAddressResponse{
+		PageSize:   int32(transactions.PageSize),
+		PageNo:     int32(transactions.PageNo),
+		TotalPage:  int32(transactions.TotalPage),
+		TotalCount: int32(transactions.TotalCount),
+		NextCursor: transactions.NextCursor,
+	}
+	for _, transaction := range transactions.Transactions {
+		// the relations without a wrapper or source transaction are null in json
+		if transaction != nil {
+			rsp.Transactions = append(rsp.Transactions, toTransaction(transaction))
+		}
+	}
+	return rsp
+}
+
+func toStatusEvent(event *models.TransactionStatusEvent) *bridgepb.TransactionStatusEvent {
+	return &bridgepb.TransactionStatusEvent{
+		Hash:       event.Hash,
+		PolyHash:   event.PolyHash,
+		DstHash:    event.DstHash,
+		User:       event.User,
+		DstUser:    event.DstUser,
+		SrcChainId: event.SrcChainId,
+		DstChainId: event.DstChainId,
+		Status:     event.Status,
+		Time:       event.Time,
+	}
+}
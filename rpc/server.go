@@ -0,0 +1,243 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+
+	"poly-bridge/bridgepb"
+	"poly-bridge/conf"
+	"poly-bridge/http"
+	"poly-bridge/models"
+	"poly-bridge/openapi"
+
+	"github.com/beego/beego/v2/core/logs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// server answers the grpc queries with the query functions of the http
+// controllers, so both apis return the same data.
+type server struct {
+	bridgepb.UnimplementedBridgeServer
+}
+
+// StartServer serves the bridge queries over grpc on the configured address,
+// with tls when a certificate is configured. The database and redis of the
+// http package must be initialized before, and the api auth too when the api
+// keys are checked.
+func StartServer(config *conf.GrpcConfig) error {
+	options := make([]grpc.ServerOption, 0)
+	if config.CertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(config.CertFile, config.KeyFile)
+		if err != nil {
+			return err
+		}
+		options = append(options, grpc.Creds(creds))
+	}
+	if auth := http.GetApiAuth(); auth != nil {
+		options = append(options, grpc.UnaryInterceptor(unaryAuth(auth)), grpc.StreamInterceptor(streamAuth(auth)))
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", config.Address, config.Port))
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer(options...)
+	bridgepb.RegisterBridgeServer(grpcServer, new(server))
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			logs.Error("grpc server stopped, err: %v", err)
+		}
+	}()
+	logs.Info("grpc server is listening on %s", listener.Addr())
+	return nil
+}
+
+// validate checks a request against the rules of the http route it mirrors.
+func validate(path string, req interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "request parameter is invalid!")
+	}
+	if fieldErr := openapi.Validate("POST", path, body, nil); fieldErr != nil {
+		return status.Error(codes.InvalidArgument, fieldErr.Message)
+	}
+	return nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, http.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, http.ErrInvalidParameter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func (s *server) Token(ctx context.Context, req *bridgepb.TokenRequest) (*bridgepb.Token, error) {
+	if err := validate("/bridge/token/", &models.TokenReq{ChainId: req.ChainId, Hash: req.Hash}); err != nil {
+		return nil, err
+	}
+	token, err := http.QueryToken(req.ChainId, req.Hash)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toToken(models.MakeTokenRsp(token)), nil
+}
+
+func (s *server) Tokens(ctx context.Context, req *bridgepb.TokensRequest) (*bridgepb.TokensResponse, error) {
+	if err := validate("/bridge/tokens/", &models.TokensReq{ChainId: req.ChainId}); err != nil {
+		return nil, err
+	}
+	tokensRsp := models.MakeTokensRsp(http.QueryTokens(req.ChainId))
+	rsp := &bridgepb.TokensResponse{TotalCount: tokensRsp.TotalCount}
+	for _, token := range tokensRsp.Tokens {
+		rsp.Tokens = append(rsp.Tokens, toToken(token))
+	}
+	return rsp, nil
+}
+
+func (s *server) TokenMap(ctx context.Context, req *bridgepb.TokenMapRequest) (*bridgepb.TokenMapsResponse, error) {
+	return s.tokenMaps("/bridge/tokenmap/", req, false)
+}
+
+func (s *server) TokenMapReverse(ctx context.Context, req *bridgepb.TokenMapRequest) (*bridgepb.TokenMapsResponse, error) {
+	return s.tokenMaps("/bridge/tokenmapreverse/", req, true)
+}
+
+func (s *server) tokenMaps(path string, req *bridgepb.TokenMapRequest, reverse bool) (*bridgepb.TokenMapsResponse, error) {
+	if err := validate(path, &models.TokenMapReq{ChainId: req.ChainId, Hash: req.Hash}); err != nil {
+		return nil, err
+	}
+	tokenMaps, err := http.QueryTokenMaps(req.ChainId, req.Hash, reverse)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	tokenMapsRsp := models.MakeTokenMapsRsp(tokenMaps)
+	rsp := &bridgepb.TokenMapsResponse{TotalCount: tokenMapsRsp.TotalCount}
+	for _, tokenMap := range tokenMapsRsp.TokenMaps {
+		rsp.TokenMaps = append(rsp.TokenMaps, toTokenMap(tokenMap))
+	}
+	return rsp, nil
+}
+
+func (s *server) GetFee(ctx context.Context, req *bridgepb.GetFeeRequest) (*bridgepb.GetFeeResponse, error) {
+	getFeeReq := &models.GetFeeReq{
+		SrcChainId:    req.SrcChainId,
+		Hash:          req.Hash,
+		DstChainId:    req.DstChainId,
+		SwapTokenHash: req.SwapTokenHash,
+	}
+	if err := validate("/bridge/getfee/", getFeeReq); err != nil {
+		return nil, err
+	}
+	getFeeRsp, err := http.QueryFee(getFeeReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toGetFeeResponse(getFeeRsp), nil
+}
+
+func (s *server) CheckFee(ctx context.Context, req *bridgepb.CheckFeeRequest) (*bridgepb.CheckFeeResponse, error) {
+	checkFeesReq := &models.CheckFeesReq{Checks: make([]*models.CheckFeeReq, 0, len(req.Checks))}
+	for _, check := range req.Checks {
+		checkFeesReq.Checks = append(checkFeesReq.Checks, &models.CheckFeeReq{ChainId: check.ChainId, Hash: check.Hash})
+	}
+	if err := validate("/bridge/checkfee/", checkFeesReq); err != nil {
+		return nil, err
+	}
+	checkFeesRsp := models.MakeCheckFeesRsp(http.QueryCheckFee(checkFeesReq.Checks))
+	rsp := &bridgepb.CheckFeeResponse{TotalCount: checkFeesRsp.TotalCount}
+	for _, checkFee := range checkFeesRsp.CheckFees {
+		rsp.CheckFees = append(rsp.CheckFees, toFeeCheckResult(checkFee))
+	}
+	return rsp, nil
+}
+
+func (s *server) TransactionOfHash(ctx context.Context, req *bridgepb.TransactionOfHashRequest) (*bridgepb.Transaction, error) {
+	if err := validate("/bridge/transactionofhash/", &models.TransactionOfHashReq{Hash: req.Hash}); err != nil {
+		return nil, err
+	}
+	transaction, err := http.QueryTransactionOfHash(req.Hash)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTransaction(transaction), nil
+}
+
+func (s *server) TransactionsOfHashes(ctx context.Context, req *bridgepb.TransactionsOfHashesRequest) (*bridgepb.TransactionsOfHashesResponse, error) {
+	transactionsRsp, err := http.QueryTransactionsOfHashes(req.Hashes)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	rsp := &bridgepb.TransactionsOfHashesResponse{
+		Transactions: make(map[string]*bridgepb.Transaction),
+		NotFound:     transactionsRsp.NotFound,
+	}
+	for hash, transaction := range transactionsRsp.Transactions {
+		if transaction != nil {
+			rsp.Transactions[hash] = toTransaction(transaction)
+		}
+	}
+	return rsp, nil
+}
+
+func (s *server) TransactionsOfAddress(ctx context.Context, req *bridgepb.TransactionsOfAddressRequest) (*bridgepb.TransactionsOfAddressResponse, error) {
+	transactionsOfAddressReq := &models.TransactionsOfAddressReq{
+		Addresses: req.Addresses,
+		PageSize:  int(req.PageSize),
+		PageNo:    int(req.PageNo),
+		UseCursor: req.UseCursor,
+		Cursor:    req.Cursor,
+		WithCount: req.WithCount,
+	}
+	if err := validate("/bridge/transactionsofaddress/", transactionsOfAddressReq); err != nil {
+		return nil, err
+	}
+	transactionsRsp, err := http.QueryTransactionsOfAddress(transactionsOfAddressReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTransactionsOfAddressResponse(transactionsRsp), nil
+}
+
+func (s *server) WatchStatus(req *bridgepb.WatchStatusRequest, stream bridgepb.Bridge_WatchStatusServer) error {
+	if req.Hash == "" && req.PolyHash == "" && req.User == "" {
+		return status.Error(codes.InvalidArgument, "request parameter is invalid!")
+	}
+	events, stop := http.WatchTransactionStatus([]string{req.Hash, req.PolyHash}, req.User)
+	defer stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			if err := stream.Send(toStatusEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poly-bridge/basedef"
+	"poly-bridge/conf"
+	"poly-bridge/http"
+	"poly-bridge/models"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("token: (abc,2) does not exist: %w", http.ErrNotFound), codes.NotFound},
+		{fmt.Errorf("cursor is invalid!: %w", http.ErrInvalidParameter), codes.InvalidArgument},
+		{errors.New("service error!"), codes.Internal},
+	}
+	for _, c := range cases {
+		if code := status.Code(toStatus(c.err)); code != c.code {
+			t.Errorf("toStatus(%v) = %v, want %v", c.err, code, c.code)
+		}
+	}
+}
+
+func TestToTransaction(t *testing.T) {
+	transaction := toTransaction(&models.TransactionRsp{
+		Hash:       "a1",
+		SrcChainId: 2,
+		DstChainId: 6,
+		Token: &models.TokenRsp{
+			Hash:       "t1",
+			TokenBasic: &models.TokenBasicRsp{Name: "USDT", Price: "1.00"},
+			TokenMaps:  []*models.TokenMapRsp{{SrcTokenHash: "t1", DstTokenHash: "t2"}},
+		},
+		TransactionState: []*models.TransactionStateRsp{{Hash: "a1", ChainId: 2, Blocks: 3, NeedBlocks: 12}},
+	})
+	if transaction.Hash != "a1" || transaction.SrcChainId != 2 || transaction.DstChainId != 6 {
+		t.Errorf("transaction = %v", transaction)
+	}
+	if transaction.FeeToken != nil {
+		t.Errorf("fee token should be nil")
+	}
+	if transaction.Token.TokenBasic.Price != "1.00" || len(transaction.Token.TokenMaps) != 1 || transaction.Token.TokenMaps[0].SrcToken != nil {
+		t.Errorf("token = %v", transaction.Token)
+	}
+	if len(transaction.TransactionState) != 1 || transaction.TransactionState[0].NeedBlocks != 12 {
+		t.Errorf("transaction state = %v", transaction.TransactionState)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	auth := http.NewApiAuth(&conf.ApiAuthConfig{
+		Keys: []*conf.ApiKeyConfig{{Name: "indexer", Key: "k1", Scopes: []string{basedef.API_SCOPE_READ}}},
+	}, nil)
+	cases := []struct {
+		method string
+		key    string
+		code   codes.Code
+	}{
+		{"/bridge.Bridge/GetFee", "k1", codes.PermissionDenied},
+		{"/bridge.Bridge/CheckFee", "k1", codes.PermissionDenied},
+		{"/bridge.Bridge/TransactionOfHash", "k2", codes.Unauthenticated},
+		{"/bridge.Bridge/WatchStatus", "k2", codes.Unauthenticated},
+	}
+	for _, c := range cases {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiKeyHeader, c.key))
+		if code := status.Code(authorize(ctx, auth, c.method)); code != c.code {
+			t.Errorf("%s with key %s code = %v, want %v", c.method, c.key, code, c.code)
+		}
+	}
+	if route := methodRoute("/bridge.Bridge/TokenMapReverse"); route != "/bridge/tokenmapreverse/" {
+		t.Errorf("route = %s", route)
+	}
+}
@@ -0,0 +1,140 @@
+package fee
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"poly-bridge/cacheRedis"
+	"poly-bridge/conf"
+	"poly-bridge/models"
+	"strings"
+	"time"
+
+	"github.com/beego/beego/v2/core/logs"
+)
+
+const (
+	// feeQuoteSlot is the seconds of the slots of the quotes, a route quoted
+	// the same amount in a slot gets the same quote
+	feeQuoteSlot = 60
+
+	defaultFeeQuoteValidity  = 600
+	defaultFeeQuoteRetention = 86400
+)
+
+// feeQuoteConfig returns the config of the quotes, nil when they are disabled.
+func feeQuoteConfig() *conf.FeeQuoteConfig {
+	if conf.GlobalConfig == nil || conf.GlobalConfig.FeeQuoteConfig == nil || conf.GlobalConfig.FeeQuoteConfig.Key == "" {
+		return nil
+	}
+	return conf.GlobalConfig.FeeQuoteConfig
+}
+
+func feeQuoteValidity(cfg *conf.FeeQuoteConfig) int64 {
+	if cfg.Validity > 0 {
+		return cfg.Validity
+	}
+	return defaultFeeQuoteValidity
+}
+
+func feeQuoteRetention(cfg *conf.FeeQuoteConfig) time.Duration {
+	if cfg.Retention > 0 {
+		return time.Duration(cfg.Retention) * time.Second
+	}
+	return defaultFeeQuoteRetention * time.Second
+}
+
+func feeQuotePayload(quote *models.FeeQuote) string {
+	return fmt.Sprintf("%d|%s|%d|%s|%d|%d", quote.SrcChainId, quote.Hash, quote.DstChainId, quote.Amount, quote.Time, quote.Expiry)
+}
+
+func feeQuoteSignature(key string, quote *models.FeeQuote) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(quote.Id + "|" + feeQuotePayload(quote)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewFeeQuote returns the quote of amount for the route signed with key. The
+// quote is valid for validity seconds from the start of the slot of now.
+func NewFeeQuote(key string, srcChainId uint64, hash string, dstChainId uint64, amount *big.Int, now int64, validity int64) *models.FeeQuote {
+	issued := now - now%feeQuoteSlot
+	quote := &models.FeeQuote{
+		SrcChainId: srcChainId,
+		Hash:       strings.ToLower(hash),
+		DstChainId: dstChainId,
+		Amount:     amount.String(),
+		Time:       issued,
+		Expiry:     issued + validity,
+	}
+	id := sha256.Sum256([]byte(feeQuotePayload(quote)))
+	quote.Id = hex.EncodeToString(id[:16])
+	quote.Signature = feeQuoteSignature(key, quote)
+	return quote
+}
+
+// VerifyFeeQuote tells whether the quote is signed with key.
+func VerifyFeeQuote(key string, quote *models.FeeQuote) bool {
+	signature, err := hex.DecodeString(quote.Signature)
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(feeQuoteSignature(key, quote))
+	return hmac.Equal(signature, expected)
+}
+
+// QuoteFee attaches a signed quote of the token amount of the response, the
+// amount is rounded up to an integer. Nothing is attached when the quotes are
+// disabled or the quote can not be saved for the checks.
+func QuoteFee(getFeeRsp *models.GetFeeRsp) {
+	cfg := feeQuoteConfig()
+	if cfg == nil || cacheRedis.Redis == nil {
+		return
+	}
+	value, ok := new(big.Float).SetString(getFeeRsp.TokenAmountWithPrecision)
+	if !ok || value.Sign() <= 0 {
+		return
+	}
+	amount, accuracy := value.Int(nil)
+	if accuracy == big.Below {
+		amount.Add(amount, big.NewInt(1))
+	}
+	quote := NewFeeQuote(cfg.Key, getFeeRsp.SrcChainId, getFeeRsp.Hash, getFeeRsp.DstChainId, amount, time.Now().Unix(), feeQuoteValidity(cfg))
+	if err := cacheRedis.Redis.SaveFeeQuote(quote, feeQuoteRetention(cfg)); err != nil {
+		logs.Error("save fee quote %s err: %v", quote.Id, err)
+		return
+	}
+	getFeeRsp.Quote = quote
+}
+
+// HonoredFeeQuote returns a quote of the route of the transaction that was
+// valid when the transaction was sent and is paid by its fee, nil when there
+// is none. The wrapper transaction does not carry the quote id, so any quote of
+// the route is honored, not only the one returned to the sender.
+func HonoredFeeQuote(tx *models.WrapperTransactionWithToken) *models.FeeQuote {
+	cfg := feeQuoteConfig()
+	if cfg == nil || cacheRedis.Redis == nil || tx.FeeAmount == nil {
+		return nil
+	}
+	sent := int64(tx.Time)
+	quotes, err := cacheRedis.Redis.GetFeeQuotes(tx.SrcChainId, tx.FeeTokenHash, tx.DstChainId, sent, sent+feeQuoteValidity(cfg))
+	if err != nil {
+		logs.Error("get fee quotes of %s err: %v", tx.Hash, err)
+		return nil
+	}
+	return honoredFeeQuote(cfg.Key, quotes, sent, &tx.FeeAmount.Int)
+}
+
+func honoredFeeQuote(key string, quotes []*models.FeeQuote, sent int64, paid *big.Int) *models.FeeQuote {
+	for _, quote := range quotes {
+		if quote.Time > sent || quote.Expiry < sent || !VerifyFeeQuote(key, quote) {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(quote.Amount, 10)
+		if ok && paid.Cmp(amount) >= 0 {
+			return quote
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package fee
+
+import (
+	"math/big"
+	"poly-bridge/models"
+	"testing"
+)
+
+func TestFeeQuoteSignature(t *testing.T) {
+	quote := NewFeeQuote("secret", 2, "0xABCD", 6, big.NewInt(1500), 1622476830, 600)
+	if quote.Time != 1622476800 || quote.Expiry != 1622477400 || quote.Hash != "0xabcd" {
+		t.Errorf("quote = %+v", quote)
+	}
+	if same := NewFeeQuote("secret", 2, "0xabcd", 6, big.NewInt(1500), 1622476859, 600); same.Id != quote.Id || same.Signature != quote.Signature {
+		t.Errorf("quotes of the same slot differ: %s, %s", quote.Id, same.Id)
+	}
+	if next := NewFeeQuote("secret", 2, "0xabcd", 6, big.NewInt(1500), 1622476860, 600); next.Id == quote.Id {
+		t.Errorf("quotes of the next slot have the same id %s", quote.Id)
+	}
+	if !VerifyFeeQuote("secret", quote) {
+		t.Errorf("quote is not verified")
+	}
+	if VerifyFeeQuote("other", quote) {
+		t.Errorf("quote is verified with another key")
+	}
+	tampered := *quote
+	tampered.Amount = "1"
+	if VerifyFeeQuote("secret", &tampered) {
+		t.Errorf("tampered quote is verified")
+	}
+}
+
+func TestHonoredFeeQuote(t *testing.T) {
+	low := NewFeeQuote("secret", 2, "abcd", 6, big.NewInt(1000), 1000, 600)
+	high := NewFeeQuote("secret", 2, "abcd", 6, big.NewInt(2000), 1300, 600)
+	forged := NewFeeQuote("other", 2, "abcd", 6, big.NewInt(1), 1000, 600)
+	quotes := []*models.FeeQuote{forged, low, high}
+	cases := []struct {
+		sent int64
+		paid int64
+		want *models.FeeQuote
+	}{
+		{1100, 1000, low},
+		{1100, 999, nil},
+		{1700, 1000, nil},
+		{1700, 2000, high},
+		{1860, 2000, high},
+		{1861, 2000, nil},
+		{900, 5000, nil},
+	}
+	for _, c := range cases {
+		if got := honoredFeeQuote("secret", quotes, c.sent, big.NewInt(c.paid)); got != c.want {
+			t.Errorf("sent at %d paying %d honors %v, want %v", c.sent, c.paid, got, c.want)
+		}
+	}
+}
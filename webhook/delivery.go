@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+	"gorm.io/gorm"
+)
+
+const (
+	deliverBatch    = 100
+	maxErrorLength  = 512
+	HeaderEvent     = "X-Bridge-Event"
+	HeaderDelivery  = "X-Bridge-Delivery"
+	HeaderSignature = "X-Bridge-Signature"
+)
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload, receivers compare it
+// with the X-Bridge-Signature header.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the wait before the next attempt, doubled on every failure.
+func (w *Webhook) backoff(attempts int) time.Duration {
+	interval := time.Second * time.Duration(w.cfg.RetryInterval)
+	max := time.Second * time.Duration(w.cfg.MaxRetryInterval)
+	for i := 1; i < attempts && interval < max; i++ {
+		interval *= 2
+	}
+	if interval > max {
+		interval = max
+	}
+	return interval
+}
+
+func (w *Webhook) deliver() error {
+	deliveries := make([]*models.WebhookDelivery, 0)
+	res := w.db.Where("next_time <= ?", time.Now().Unix()).Order("next_time asc").Limit(deliverBatch).Find(&deliveries)
+	if res.Error != nil {
+		return res.Error
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.SubscriptionId)
+	}
+	subscriptions := make([]*models.WebhookSubscription, 0)
+	if res := w.db.Where("id in ?", ids).Find(&subscriptions); res.Error != nil {
+		return res.Error
+	}
+	id2Subscriptions := make(map[int64]*models.WebhookSubscription)
+	for _, subscription := range subscriptions {
+		id2Subscriptions[subscription.Id] = subscription
+	}
+	wg := new(sync.WaitGroup)
+	for _, delivery := range deliveries {
+		subscription, ok := id2Subscriptions[delivery.SubscriptionId]
+		if !ok {
+			w.db.Delete(delivery)
+			continue
+		}
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			if err := w.attempt(subscription, delivery); err != nil {
+				logs.Error("webhook delivery %d of subscription %d err: %v", delivery.Id, subscription.Id, err)
+			}
+		}(delivery)
+	}
+	wg.Wait()
+	return nil
+}
+
+// attempt posts a delivery once, on failure it is rescheduled or moved to the
+// dead letters when the attempts run out.
+func (w *Webhook) attempt(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) error {
+	err := w.post(subscription, delivery)
+	if err == nil {
+		return w.db.Delete(delivery).Error
+	}
+	delivery.Attempts++
+	delivery.Error = err.Error()
+	if len(delivery.Error) > maxErrorLength {
+		delivery.Error = delivery.Error[:maxErrorLength]
+	}
+	if delivery.Attempts < w.cfg.MaxAttempts {
+		delivery.NextTime = uint64(time.Now().Add(w.backoff(delivery.Attempts)).Unix())
+		return w.db.Save(delivery).Error
+	}
+	logs.Warn("webhook delivery %d of subscription %d failed %d times, move to dead letters", delivery.Id, subscription.Id, delivery.Attempts)
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		deadLetter := &models.WebhookDeadLetter{
+			SubscriptionId: delivery.SubscriptionId,
+			Hash:           delivery.Hash,
+			Event:          delivery.Event,
+			Payload:        delivery.Payload,
+			Attempts:       delivery.Attempts,
+			Error:          delivery.Error,
+			Time:           uint64(time.Now().Unix()),
+		}
+		if err := tx.Create(deadLetter).Error; err != nil {
+			return err
+		}
+		return tx.Delete(delivery).Error
+	})
+}
+
+func (w *Webhook) post(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) error {
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(w, http.MethodPost, subscription.Url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.Id, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(subscription.Secret, payload))
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("receiver responded %s", resp.Status)
+	}
+	return nil
+}
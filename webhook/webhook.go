@@ -0,0 +1,335 @@
+/*
+ * Copyright (C) 2020 The poly network Authors
+ * This file is part of The poly network library.
+ *
+ * The  poly network  is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The  poly network  is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The poly network .  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"poly-bridge/basedef"
+	"poly-bridge/cacheRedis"
+	"poly-bridge/conf"
+	"poly-bridge/database"
+	"poly-bridge/models"
+
+	"github.com/beego/beego/v2/core/logs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+const webhookSentExpiration = time.Hour * 72
+
+type Webhook struct {
+	context.Context
+	cancel context.CancelFunc
+	cfg    *conf.WebhookConfig
+	db     *gorm.DB
+	client *http.Client
+	wg     sync.WaitGroup
+}
+
+var wh *Webhook
+
+// StartWebhook posts the lifecycle events of the transfers to the subscribed
+// receivers.
+func StartWebhook(ctx context.Context, server string, cfg *conf.WebhookConfig, dbCfg *conf.DBConfig) {
+	if server != basedef.SERVER_POLY_BRIDGE {
+		panic("Webhook Only runs on bridge server")
+	}
+	Logger := logger.Default
+	if dbCfg.Debug == true {
+		Logger = Logger.LogMode(logger.Info)
+	}
+	db, err := database.Open(dbCfg, &gorm.Config{Logger: Logger})
+	if err != nil {
+		panic(err)
+	}
+	wh = NewWebhook(ctx, cfg, db)
+	wh.Start()
+}
+
+func StopWebhook() {
+	if wh != nil {
+		wh.Stop()
+		wh = nil
+	}
+}
+
+func NewWebhook(ctx context.Context, cfg *conf.WebhookConfig, db *gorm.DB) *Webhook {
+	c := *cfg
+	if c.DeliverInterval == 0 {
+		c.DeliverInterval = 5
+	}
+	if c.StuckInterval == 0 {
+		c.StuckInterval = 60
+	}
+	if c.StuckWindow == 0 {
+		c.StuckWindow = 86400
+	}
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 8
+	}
+	if c.RetryInterval == 0 {
+		c.RetryInterval = 10
+	}
+	if c.MaxRetryInterval == 0 {
+		c.MaxRetryInterval = 3600
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 10
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Webhook{
+		Context: ctx,
+		cancel:  cancel,
+		cfg:     &c,
+		db:      db,
+		client:  newClient(time.Second*time.Duration(c.Timeout), c.AllowPrivateReceivers),
+	}
+}
+
+func (w *Webhook) Start() {
+	w.wg.Add(1)
+	go w.listen()
+	w.start(w.cfg.StuckInterval, w.checkStuck)
+	w.start(w.cfg.DeliverInterval, w.deliver)
+}
+
+func (w *Webhook) Stop() {
+	logs.Info("Stopping webhook server")
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *Webhook) start(interval int64, f func() error) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(time.Second * time.Duration(interval))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := f(); err != nil {
+					logs.Error("webhook run error: %v", err)
+				}
+			case <-w.Done():
+				return
+			}
+		}
+	}()
+}
+
+// listen turns the status events published by the listeners and the effect
+// into poly and destination webhook events.
+func (w *Webhook) listen() {
+	defer w.wg.Done()
+	pubsub := cacheRedis.Redis.SubscribeTransactionStatus()
+	defer pubsub.Close()
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-w.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			event := new(models.TransactionStatusEvent)
+			if err := json.Unmarshal([]byte(message.Payload), event); err != nil {
+				logs.Error("webhook unmarshal transaction status %s err: %v", message.Payload, err)
+				continue
+			}
+			var name string
+			switch event.Status {
+			case basedef.STATE_POLY_CONFIRMED:
+				name = models.WEBHOOK_EVENT_POLY
+			case basedef.STATE_DESTINATION_DONE, basedef.STATE_FINISHED:
+				name = models.WEBHOOK_EVENT_DESTINATION
+			default:
+				continue
+			}
+			hash := event.Hash
+			if hash == "" {
+				polyTransaction := new(models.PolyTransaction)
+				res := w.db.Where("hash = ?", event.PolyHash).Limit(1).Find(polyTransaction)
+				if res.Error != nil || res.RowsAffected == 0 {
+					continue
+				}
+				hash = polyTransaction.SrcHash
+			}
+			if err := w.enqueue(name, hash); err != nil {
+				logs.Error("webhook enqueue %s of %s err: %v", name, hash, err)
+			}
+		}
+	}
+}
+
+func (w *Webhook) checkStuck() error {
+	wrapperTransactions := make([]*models.WrapperTransaction, 0)
+	start := time.Now().Unix() - w.cfg.StuckWindow
+	res := w.db.Where("status in ? and time > ?", []int{basedef.STATE_WAIT, basedef.STATE_SKIP}, start).Find(&wrapperTransactions)
+	if res.Error != nil {
+		return res.Error
+	}
+	for _, tx := range wrapperTransactions {
+		if err := w.enqueue(models.WEBHOOK_EVENT_STUCK, tx.Hash); err != nil {
+			logs.Error("webhook enqueue %s of %s err: %v", models.WEBHOOK_EVENT_STUCK, tx.Hash, err)
+		}
+	}
+	return nil
+}
+
+// enqueue stores a delivery for every subscription matching the transfer,
+// each event of a transfer is sent once per subscription.
+func (w *Webhook) enqueue(event, hash string) error {
+	transaction, err := w.getTransaction(hash)
+	if err != nil || transaction == nil {
+		return err
+	}
+	subscriptions, err := w.getSubscriptions(transaction)
+	if err != nil {
+		return err
+	}
+	now := uint64(time.Now().Unix())
+	payload, err := json.Marshal(&models.WebhookPayload{Event: event, Time: now, Transaction: transaction})
+	if err != nil {
+		return err
+	}
+	for _, subscription := range subscriptions {
+		if !match(subscription, event, transaction) {
+			continue
+		}
+		key := fmt.Sprintf("%s%d_%s_%s", cacheRedis.WebhookSentPrefix, subscription.Id, event, strings.ToLower(hash))
+		if ok, err := cacheRedis.Redis.Lock(key, "done", webhookSentExpiration); err != nil || !ok {
+			continue
+		}
+		delivery := &models.WebhookDelivery{
+			SubscriptionId: subscription.Id,
+			Hash:           hash,
+			Event:          event,
+			Payload:        string(payload),
+			NextTime:       now,
+			Time:           now,
+		}
+		if res := w.db.Create(delivery); res.Error != nil {
+			cacheRedis.Redis.Del(key)
+			return res.Error
+		}
+	}
+	return nil
+}
+
+// getSubscriptions selects the subscriptions whose user, asset and server id
+// are empty or equal to the ones of the transfer, users and assets are stored
+// normalized.
+func (w *Webhook) getSubscriptions(transaction *models.TransactionRsp) ([]*models.WebhookSubscription, error) {
+	anyOf := func(column string, empty interface{}, values ...interface{}) clause.Expression {
+		return clause.Or(
+			clause.Eq{Column: clause.Column{Name: column}, Value: empty},
+			clause.IN{Column: clause.Column{Name: column}, Values: values},
+		)
+	}
+	asset := ""
+	if transaction.Token != nil {
+		asset = Normalize(transaction.Token.Hash)
+	}
+	subscriptions := make([]*models.WebhookSubscription, 0)
+	res := w.db.Clauses(clause.Where{Exprs: []clause.Expression{
+		anyOf("user", "", Normalize(transaction.User), Normalize(transaction.DstUser)),
+		anyOf("asset", "", asset),
+		anyOf("server_id", 0, transaction.ServerId),
+	}}).Find(&subscriptions)
+	return subscriptions, res.Error
+}
+
+func (w *Webhook) getTransaction(hash string) (*models.TransactionRsp, error) {
+	srcPolyDstRelation := new(models.SrcPolyDstRelation)
+	res := w.db.Table("src_transactions").
+		Select("src_transactions.hash as src_hash, poly_transactions.hash as poly_hash, dst_transactions.hash as dst_hash, src_transactions.chain_id as chain_id, src_transfers.asset as token_hash, wrapper_transactions.fee_token_hash as fee_token_hash").
+		Where("src_transactions.hash = ?", hash).
+		Joins("left join wrapper_transactions on src_transactions.hash = wrapper_transactions.hash").
+		Joins("left join src_transfers on src_transactions.hash = src_transfers.tx_hash").
+		Joins("left join poly_transactions on src_transactions.hash = poly_transactions.src_hash").
+		Joins("left join dst_transactions on poly_transactions.hash = dst_transactions.poly_hash").
+		Preload("WrapperTransaction").
+		Preload("SrcTransaction").
+		Preload("SrcTransaction.SrcTransfer").
+		Preload("PolyTransaction").
+		Preload("DstTransaction").
+		Preload("DstTransaction.DstTransfer").
+		Preload("Token").
+		Preload("Token.TokenBasic").
+		Preload("FeeToken").
+		Find(srcPolyDstRelation)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	chains := make([]*models.Chain, 0)
+	w.db.Model(&models.Chain{}).Find(&chains)
+	chainsMap := make(map[uint64]*models.Chain)
+	for _, chain := range chains {
+		chainsMap[chain.ChainId] = chain
+	}
+	return models.MakeTransactionRsp(srcPolyDstRelation, chainsMap), nil
+}
+
+func match(subscription *models.WebhookSubscription, event string, transaction *models.TransactionRsp) bool {
+	if subscription.Events != "" {
+		found := false
+		for _, v := range strings.Split(subscription.Events, ",") {
+			if strings.TrimSpace(v) == event {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if subscription.User != "" {
+		user := Normalize(subscription.User)
+		if Normalize(transaction.User) != user && Normalize(transaction.DstUser) != user {
+			return false
+		}
+	}
+	if subscription.Asset != "" {
+		if transaction.Token == nil || Normalize(transaction.Token.Hash) != Normalize(subscription.Asset) {
+			return false
+		}
+	}
+	if subscription.ServerId != 0 && subscription.ServerId != transaction.ServerId {
+		return false
+	}
+	return true
+}
+
+// Normalize lowercases a user or asset hash and drops the 0x prefix, the way
+// subscriptions are stored.
+func Normalize(hash string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(hash)), "0x")
+}
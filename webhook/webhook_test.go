@@ -0,0 +1,224 @@
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"poly-bridge/basedef"
+	"poly-bridge/conf"
+	"poly-bridge/database"
+	"poly-bridge/models"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func newTestWebhook(t *testing.T, cfg *conf.WebhookConfig) (*Webhook, func()) {
+	dir, err := ioutil.TempDir("", "webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbCfg := &conf.DBConfig{Driver: basedef.DB_DRIVER_SQLITE, URL: filepath.Join(dir, "bridge.db"), AutoMigrate: true}
+	db, err := database.Open(dbCfg, &gorm.Config{})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	w := NewWebhook(context.Background(), cfg, db)
+	return w, func() {
+		w.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get(HeaderSignature) != "sha256="+Sign("secret", body) {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received <- r
+	}))
+	defer receiver.Close()
+
+	w, cleanup := newTestWebhook(t, &conf.WebhookConfig{AllowPrivateReceivers: true})
+	defer cleanup()
+	subscription := &models.WebhookSubscription{Url: receiver.URL, Secret: "secret", User: "user"}
+	w.db.Create(subscription)
+	w.db.Create(&models.WebhookDelivery{SubscriptionId: subscription.Id, Hash: "ab01", Event: models.WEBHOOK_EVENT_POLY, Payload: `{"Event":"poly"}`})
+	if err := w.deliver(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case r := <-received:
+		if r.Header.Get(HeaderEvent) != models.WEBHOOK_EVENT_POLY {
+			t.Errorf("event header = %s", r.Header.Get(HeaderEvent))
+		}
+	default:
+		t.Fatal("receiver got no delivery")
+	}
+	var count int64
+	w.db.Model(&models.WebhookDelivery{}).Count(&count)
+	if count != 0 {
+		t.Errorf("delivered payload should be removed, %d left", count)
+	}
+}
+
+func TestDeliverRejectsPrivate(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		received <- r
+	}))
+	defer receiver.Close()
+
+	w, cleanup := newTestWebhook(t, &conf.WebhookConfig{})
+	defer cleanup()
+	subscription := &models.WebhookSubscription{Url: receiver.URL, Secret: "secret", User: "user"}
+	w.db.Create(subscription)
+	delivery := &models.WebhookDelivery{SubscriptionId: subscription.Id, Hash: "ab01", Event: models.WEBHOOK_EVENT_POLY, Payload: "{}"}
+	w.db.Create(delivery)
+	w.deliver()
+	select {
+	case <-received:
+		t.Fatal("receiver on a loopback address got a delivery")
+	default:
+	}
+	w.db.First(delivery, delivery.Id)
+	if delivery.Attempts != 1 {
+		t.Errorf("rejected delivery should be rescheduled, attempts %d", delivery.Attempts)
+	}
+}
+
+func TestDeliverDeadLetter(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	w, cleanup := newTestWebhook(t, &conf.WebhookConfig{MaxAttempts: 2, RetryInterval: 1, AllowPrivateReceivers: true})
+	defer cleanup()
+	subscription := &models.WebhookSubscription{Url: receiver.URL, Secret: "secret", ServerId: 1}
+	w.db.Create(subscription)
+	delivery := &models.WebhookDelivery{SubscriptionId: subscription.Id, Hash: "ab01", Event: models.WEBHOOK_EVENT_STUCK, Payload: "{}"}
+	w.db.Create(delivery)
+
+	w.deliver()
+	w.db.First(delivery, delivery.Id)
+	if delivery.Attempts != 1 || delivery.NextTime == 0 {
+		t.Fatalf("failed delivery should be rescheduled, attempts %d next %d", delivery.Attempts, delivery.NextTime)
+	}
+	w.db.Model(delivery).Update("next_time", 0)
+	w.deliver()
+	var count int64
+	w.db.Model(&models.WebhookDelivery{}).Count(&count)
+	if count != 0 {
+		t.Errorf("delivery should be moved to dead letters, %d left", count)
+	}
+	deadLetters := make([]*models.WebhookDeadLetter, 0)
+	w.db.Find(&deadLetters)
+	if len(deadLetters) != 1 || deadLetters[0].Attempts != 2 || deadLetters[0].Hash != "ab01" {
+		t.Errorf("dead letters = %+v", deadLetters)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	w := NewWebhook(context.Background(), &conf.WebhookConfig{RetryInterval: 10, MaxRetryInterval: 60}, nil)
+	expects := []time.Duration{10, 20, 40, 60, 60}
+	for i, expect := range expects {
+		if got := w.backoff(i + 1); got != expect*time.Second {
+			t.Errorf("backoff(%d) = %v, expect %v", i+1, got, expect*time.Second)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	transaction := &models.TransactionRsp{User: "0xAB", DstUser: "cd", ServerId: 1, Token: &models.TokenRsp{Hash: "ef"}}
+	cases := []struct {
+		subscription *models.WebhookSubscription
+		event        string
+		expect       bool
+	}{
+		{&models.WebhookSubscription{User: "ab"}, models.WEBHOOK_EVENT_POLY, true},
+		{&models.WebhookSubscription{User: "0xCD", Asset: "ef"}, models.WEBHOOK_EVENT_POLY, true},
+		{&models.WebhookSubscription{User: "ab", Asset: "00"}, models.WEBHOOK_EVENT_POLY, false},
+		{&models.WebhookSubscription{ServerId: 2}, models.WEBHOOK_EVENT_POLY, false},
+		{&models.WebhookSubscription{ServerId: 1, Events: "stuck,destination"}, models.WEBHOOK_EVENT_POLY, false},
+		{&models.WebhookSubscription{ServerId: 1, Events: "stuck,destination"}, models.WEBHOOK_EVENT_STUCK, true},
+	}
+	for i, c := range cases {
+		if got := match(c.subscription, c.event, transaction); got != c.expect {
+			t.Errorf("case %d match = %v", i, got)
+		}
+	}
+}
+
+func TestGetSubscriptions(t *testing.T) {
+	w, cleanup := newTestWebhook(t, &conf.WebhookConfig{})
+	defer cleanup()
+	subscriptions := []*models.WebhookSubscription{
+		{User: "ab"},
+		{User: "cd", Asset: "ef"},
+		{User: "ab", Asset: "00"},
+		{ServerId: 2},
+		{ServerId: 1, Events: "stuck"},
+	}
+	for _, subscription := range subscriptions {
+		w.db.Create(subscription)
+	}
+	transaction := &models.TransactionRsp{User: "0xAB", DstUser: "cd", ServerId: 1, Token: &models.TokenRsp{Hash: "0xEF"}}
+	got, err := w.getSubscriptions(transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expects := map[int64]bool{subscriptions[0].Id: true, subscriptions[1].Id: true, subscriptions[4].Id: true}
+	if len(got) != len(expects) {
+		t.Fatalf("got %d subscriptions, expect %d", len(got), len(expects))
+	}
+	for _, subscription := range got {
+		if !expects[subscription.Id] {
+			t.Errorf("subscription %d should not be selected", subscription.Id)
+		}
+	}
+}
+
+func TestCheckUrl(t *testing.T) {
+	cases := map[string]bool{
+		"https://8.8.8.8/callback":                 true,
+		"ftp://8.8.8.8/callback":                   false,
+		"http://127.0.0.1:8080/callback":           false,
+		"http://169.254.169.254/latest/meta-data/": false,
+		"http://10.1.2.3/callback":                 false,
+		"http://172.20.0.1/callback":               false,
+		"http://192.168.1.1/callback":              false,
+		"http://[::1]/callback":                    false,
+		"http://[fd00::1]/callback":                false,
+		"http://0.0.0.0/callback":                  false,
+	}
+	for url, expect := range cases {
+		if err := CheckUrl(url, false); (err == nil) != expect {
+			t.Errorf("CheckUrl(%s) err = %v", url, err)
+		}
+	}
+	if err := CheckUrl("http://127.0.0.1:8080/callback", true); err != nil {
+		t.Errorf("CheckUrl should allow private receivers, err = %v", err)
+	}
+	if err := CheckUrl("ftp://127.0.0.1/callback", true); err == nil {
+		t.Errorf("CheckUrl should reject ftp with private receivers allowed")
+	}
+}
+
+func TestClientRejectsLoopback(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {}))
+	defer receiver.Close()
+	w := NewWebhook(context.Background(), &conf.WebhookConfig{}, nil)
+	if resp, err := w.client.Get(receiver.URL); err == nil {
+		resp.Body.Close()
+		t.Errorf("client should not connect to %s", receiver.URL)
+	}
+}